@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/providers"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff OLD NEW",
+	Short: "Compare the metadata of two webpages or snapshots",
+	Long: `Scrape two URLs, or compare a URL against a JSON snapshot saved with
+"glypto scrape --format json -o FILE", and print the resolved fields and
+provider tags that were added, removed or changed.
+
+Arguments starting with http:// or https:// are scraped; anything else is
+read as a snapshot file.
+
+Examples:
+  glypto diff https://staging.example.com https://example.com
+  glypto scrape --format json -o before.json https://example.com
+  glypto diff --exit-code before.json https://example.com`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unsupported format %q: use text, json or yaml", format)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	client, done, err := newFetcher(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	sides := make([]*metadata.Metadata, len(args))
+	for i, source := range args {
+		result, err := loadMetadata(ctx, client, source)
+		if err != nil {
+			return err
+		}
+		sides[i] = result
+	}
+
+	diff := metadata.Diff(sides[0], sides[1])
+	if err := writeDiff(cmd.OutOrStdout(), args[0], args[1], diff, format); err != nil {
+		return err
+	}
+
+	if exitCode, _ := cmd.Flags().GetBool("exit-code"); exitCode && !diff.Empty() {
+		// A difference is a result, not a misuse of the command
+		cmd.SilenceUsage = true
+		return fmt.Errorf("metadata differs")
+	}
+	return nil
+}
+
+// loadMetadata scrapes source when it is an http(s) URL and reads it as a
+// snapshot file otherwise
+func loadMetadata(ctx context.Context, client *fetcher.Fetcher, source string) (*metadata.Metadata, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return scrapeURL(ctx, client, source, false, nil, scraper.ScrapeOptions{})
+	}
+	return readSnapshot(source)
+}
+
+// readSnapshot reads metadata saved in the JSON schema of
+// metadata.Metadata.MarshalJSON, resolving it with the default providers
+func readSnapshot(path string) (*metadata.Metadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	result := metadata.NewMetadata(providers.NewRegistry(providers.NewLoader().LoadDefaults()))
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return result, nil
+}
+
+// writeDiff writes diff to w as colorized text, or in the JSON schema of
+// metadata.MetadataDiff for json and yaml
+func writeDiff(w io.Writer, oldSource, newSource string, diff *metadata.MetadataDiff, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		return writeYAML(w, diff)
+	default:
+		displayDiff(w, oldSource, newSource, diff)
+		return nil
+	}
+}
+
+// displayDiff prints the changed fields, then each changed provider's keys
+// in sorted order, marking additions with +, removals with - and changes
+// with ~
+func displayDiff(w io.Writer, oldSource, newSource string, diff *metadata.MetadataDiff) {
+	added := color.New(color.FgGreen)
+	removed := color.New(color.FgRed)
+	changed := color.New(color.FgYellow)
+	bold := color.New(color.Bold)
+
+	_, _ = removed.Fprintf(w, "--- %s\n", oldSource)
+	_, _ = added.Fprintf(w, "+++ %s\n", newSource)
+
+	if diff.Empty() {
+		_, _ = added.Fprint(w, "\n✓ No differences\n")
+		return
+	}
+
+	if len(diff.Fields) > 0 {
+		_, _ = bold.Fprint(w, "\nFields:\n")
+		for _, change := range diff.Fields {
+			switch {
+			case change.Old == nil:
+				_, _ = added.Fprintf(w, "+ %s: %q\n", change.Field, *change.New)
+			case change.New == nil:
+				_, _ = removed.Fprintf(w, "- %s: %q\n", change.Field, *change.Old)
+			default:
+				_, _ = changed.Fprintf(w, "~ %s: %q → %q\n", change.Field, *change.Old, *change.New)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(diff.Providers)) {
+		providerDiff := diff.Providers[name]
+		_, _ = bold.Fprintf(w, "\n%s:\n", name)
+
+		keys := slices.Concat(slices.Collect(maps.Keys(providerDiff.Added)), slices.Collect(maps.Keys(providerDiff.Removed)), slices.Collect(maps.Keys(providerDiff.Changed)))
+		slices.Sort(keys)
+		for _, key := range keys {
+			if values, ok := providerDiff.Added[key]; ok {
+				_, _ = added.Fprintf(w, "  + %s: %s\n", key, formatValues(values))
+			} else if values, ok := providerDiff.Removed[key]; ok {
+				_, _ = removed.Fprintf(w, "  - %s: %s\n", key, formatValues(values))
+			} else {
+				change := providerDiff.Changed[key]
+				_, _ = changed.Fprintf(w, "  ~ %s: %s → %s\n", key, formatValues(change.Old), formatValues(change.New))
+			}
+		}
+	}
+}
+
+// formatValues quotes a single value and lists several in brackets
+func formatValues(values []string) string {
+	if len(values) == 1 {
+		return fmt.Sprintf("%q", values[0])
+	}
+
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().String("format", "text", "Output format: text, json or yaml")
+	diffCmd.Flags().Bool("exit-code", false, "Exit non-zero when the metadata differs")
+	diffCmd.Flags().Duration("timeout", 30*time.Second, "Give up after this long (0 for no limit), for both pages")
+	addFetchFlags(diffCmd)
+}
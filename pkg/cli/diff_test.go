@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// newPageServer serves page at every path
+func newPageServer(page string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(page))
+	}))
+}
+
+func TestRunDiff(t *testing.T) {
+	before := newPageServer(`<head><meta property="og:title" content="Old Title"><meta name="description" content="Description"></head>`)
+	defer before.Close()
+	after := newPageServer(`<head><meta property="og:title" content="New Title"><meta name="keywords" content="go"></head>`)
+	defer after.Close()
+
+	var buf bytes.Buffer
+	diffCmd.SetOut(&buf)
+	defer diffCmd.SetOut(nil)
+
+	if err := runDiff(diffCmd, []string{before.URL, after.URL}); err != nil {
+		t.Fatalf("runDiff() failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		`~ title: "Old Title" → "New Title"`,
+		`- description: "Description"`,
+		`+ keywords: "go"`,
+		"openGraph:",
+		`  ~ title: "Old Title" → "New Title"`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Output = %q, want it to contain %q", buf.String(), expected)
+		}
+	}
+}
+
+func TestRunDiff_Snapshot(t *testing.T) {
+	// Relative values resolve against the <base href> on both sides
+	server := newPageServer(`<head><base href="/assets/"><meta property="og:title" content="Title"><meta property="og:image" content="image.png"><link rel="icon" href="icon.png"></head>`)
+	defer server.Close()
+
+	result, err := scrapeURL(context.Background(), &fetcher.Fetcher{}, server.URL, false, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		t.Fatalf("scrapeURL() failed: %v", err)
+	}
+	data, _ := json.Marshal(result)
+	snapshot := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(snapshot, data, 0o644); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+
+	var buf bytes.Buffer
+	diffCmd.SetOut(&buf)
+	defer diffCmd.SetOut(nil)
+	_ = diffCmd.Flags().Set("exit-code", "true")
+	defer func() { _ = diffCmd.Flags().Set("exit-code", "false") }()
+
+	if err := runDiff(diffCmd, []string{snapshot, server.URL}); err != nil {
+		t.Fatalf("runDiff() failed: %v\n%s", err, buf.String())
+	}
+	if !strings.Contains(buf.String(), "No differences") {
+		t.Errorf("Output = %q, want no differences from the page's own snapshot", buf.String())
+	}
+
+	changed := newPageServer(`<head><meta property="og:title" content="Changed"></head>`)
+	defer changed.Close()
+	if err := runDiff(diffCmd, []string{snapshot, changed.URL}); err == nil {
+		t.Error("runDiff() expected an error with --exit-code when the metadata differs")
+	}
+
+	if err := runDiff(diffCmd, []string{filepath.Join(t.TempDir(), "missing.json"), server.URL}); err == nil {
+		t.Error("runDiff() expected an error for a missing snapshot")
+	}
+}
+
+func TestRunDiff_JSON(t *testing.T) {
+	before := newPageServer(`<head><meta property="og:title" content="Old Title"></head>`)
+	defer before.Close()
+	after := newPageServer(`<head><meta property="og:title" content="New Title"></head>`)
+	defer after.Close()
+
+	var buf bytes.Buffer
+	diffCmd.SetOut(&buf)
+	defer diffCmd.SetOut(nil)
+	_ = diffCmd.Flags().Set("format", "json")
+	defer func() { _ = diffCmd.Flags().Set("format", "text") }()
+
+	if err := runDiff(diffCmd, []string{before.URL, after.URL}); err != nil {
+		t.Fatalf("runDiff() failed: %v", err)
+	}
+
+	var diff metadata.MetadataDiff
+	if err := json.Unmarshal(buf.Bytes(), &diff); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if change, ok := diff.Providers["openGraph"].Changed["title"]; !ok || change.New[0] != "New Title" {
+		t.Errorf("Providers = %+v, want the changed og:title", diff.Providers)
+	}
+}
+
+func TestFormatValues(t *testing.T) {
+	tests := []struct {
+		values   []string
+		expected string
+	}{
+		{values: []string{"one"}, expected: `"one"`},
+		{values: []string{"one", "two"}, expected: `["one", "two"]`},
+		{values: nil, expected: "[]"},
+	}
+
+	for _, tt := range tests {
+		if got := formatValues(tt.values); got != tt.expected {
+			t.Errorf("formatValues(%v) = %v, want %v", tt.values, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// faviconCmd represents the favicon command
+var faviconCmd = &cobra.Command{
+	Use:   "favicon [URL]",
+	Short: "Download a webpage's favicon",
+	Long: `Download the best icon a webpage declares, falling back to /favicon.ico,
+after verifying it is an image whose dimensions can be read.
+
+Examples:
+  glypto favicon https://example.com
+  glypto favicon --size 32 --output icon.png https://example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runFavicon,
+}
+
+func runFavicon(cmd *cobra.Command, args []string) error {
+	url, err := getURLFromInput(args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	client, done, err := newFetcher(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	result, err := scrapeURL(ctx, client, url, true, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		return err
+	}
+
+	size, _ := cmd.Flags().GetInt("size")
+	icon, err := client.DownloadFavicon(ctx, result, size)
+	if err != nil {
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = "favicon" + icon.Extension()
+	}
+	if err := os.WriteFile(output, icon.Data, 0o644); err != nil {
+		return fmt.Errorf("failed to write icon: %w", err)
+	}
+
+	dimensions := "scalable"
+	if icon.Width > 0 {
+		dimensions = fmt.Sprintf("%dx%d", icon.Width, icon.Height)
+	}
+	color.Green("✓ Saved %s (%s, %s) to %s", icon.URL, icon.ContentType, dimensions, output)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(faviconCmd)
+
+	faviconCmd.Flags().Int("size", 0, "Preferred icon size in pixels (0 for the largest)")
+	faviconCmd.Flags().StringP("output", "o", "", "File to write the icon to (default favicon.<ext>)")
+	faviconCmd.Flags().Duration("timeout", 30*time.Second, "Give up after this long (0 for no limit)")
+	addFetchFlags(faviconCmd)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunFavicon(t *testing.T) {
+	var icon bytes.Buffer
+	if err := png.Encode(&icon, image.NewRGBA(image.Rect(0, 0, 32, 32))); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/icon.png" {
+			_, _ = w.Write(icon.Bytes())
+			return
+		}
+		_, _ = w.Write([]byte(`<html><head><link rel="icon" sizes="32x32" href="/icon.png"></head></html>`))
+	}))
+	defer server.Close()
+
+	output := filepath.Join(t.TempDir(), "icon.png")
+	if err := faviconCmd.Flags().Set("output", output); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = faviconCmd.Flags().Set("output", "") }()
+
+	if err := runFavicon(faviconCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runFavicon() failed: %v", err)
+	}
+
+	written, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read the written icon: %v", err)
+	}
+	if !bytes.Equal(written, icon.Bytes()) {
+		t.Error("Written icon does not match the served one")
+	}
+}
+
+func TestRunFavicon_NoValidIcon(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><title>No icons</title></head></html>`))
+	}))
+	defer server.Close()
+
+	if err := faviconCmd.Flags().Set("output", filepath.Join(t.TempDir(), "icon")); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = faviconCmd.Flags().Set("output", "") }()
+
+	if err := runFavicon(faviconCmd, []string{server.URL}); err == nil {
+		t.Error("runFavicon() succeeded for a page serving HTML as its favicon")
+	}
+}
+
+func TestFaviconCmd(t *testing.T) {
+	if faviconCmd.Use != "favicon [URL]" {
+		t.Errorf("Expected Use to be 'favicon [URL]', got '%s'", faviconCmd.Use)
+	}
+
+	if faviconCmd.RunE == nil {
+		t.Error("Expected RunE to be set")
+	}
+
+	for _, name := range []string{"size", "output", "timeout"} {
+		if faviconCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag to be registered", name)
+		}
+	}
+}
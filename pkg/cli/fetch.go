@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
+)
+
+// addFetchFlags registers the flags newFetcher reads on every command that
+// fetches pages
+func addFetchFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64("max-body-size", 10<<20, "Fail when a page body exceeds this many bytes (0 for no limit)")
+	cmd.Flags().Int("max-redirects", 10, "Fail when a page redirects more than this many times")
+	cmd.Flags().Bool("no-follow", false, "Stop at the first redirect instead of following it")
+	cmd.Flags().Bool("block-private", false, "Refuse to connect to private, loopback and link-local addresses, even after redirects")
+	cmd.Flags().StringArray("resolve", nil, "Connect to ADDRESS for HOST:PORT instead of resolving it (HOST:PORT:ADDRESS, repeatable)")
+	cmd.Flags().Bool("ipv4", false, "Only connect over IPv4")
+	cmd.Flags().Bool("ipv6", false, "Only connect over IPv6")
+	cmd.MarkFlagsMutuallyExclusive("ipv4", "ipv6")
+	cmd.Flags().Float64("rate-limit", 0, "Send at most this many requests per second to each host (0 for no limit)")
+	cmd.Flags().String("cache-dir", "", "Cache responses in this directory, revalidating them with ETag/Last-Modified on later runs")
+	cmd.Flags().String("cookie-jar", "", "Load cookies from this file and save the session's cookies back to it")
+	cmd.Flags().String("ca-cert", "", "Also trust the certificate authorities in this PEM bundle")
+	cmd.Flags().String("cert", "", "Present this PEM client certificate (with --key) to servers requiring mutual TLS")
+	cmd.Flags().String("key", "", "Private key for --cert")
+	cmd.Flags().Bool("insecure", false, "Skip TLS certificate verification (unsafe; prefer --ca-cert)")
+}
+
+// newFetcher creates a fetcher from the flags addFetchFlags registered on
+// cmd, reporting each request in verbose mode. The returned func saves the
+// cookie jar and must be called once the fetcher is done.
+func newFetcher(cmd *cobra.Command) (*fetcher.Fetcher, func(), error) {
+	client := &fetcher.Fetcher{Hooks: debugHooks()}
+	client.MaxBodySize, _ = cmd.Flags().GetInt64("max-body-size")
+	client.MaxRedirects, _ = cmd.Flags().GetInt("max-redirects")
+	client.NoFollow, _ = cmd.Flags().GetBool("no-follow")
+	client.BlockPrivateNetworks, _ = cmd.Flags().GetBool("block-private")
+
+	resolves, _ := cmd.Flags().GetStringArray("resolve")
+	resolve, err := parseResolve(resolves)
+	if err != nil {
+		return nil, nil, err
+	}
+	client.Resolve = resolve
+
+	if ipv4, _ := cmd.Flags().GetBool("ipv4"); ipv4 {
+		client.Network = "tcp4"
+	}
+	if ipv6, _ := cmd.Flags().GetBool("ipv6"); ipv6 {
+		client.Network = "tcp6"
+	}
+	if rateLimit, _ := cmd.Flags().GetFloat64("rate-limit"); rateLimit > 0 {
+		client.Limiter = fetcher.NewHostLimiter(rateLimit, 0)
+	}
+	if cacheDir, _ := cmd.Flags().GetString("cache-dir"); cacheDir != "" {
+		client.Cache = fetcher.NewDiskCache(cacheDir)
+	}
+
+	httpClient := &http.Client{}
+	var tlsOptions fetcher.TLSOptions
+	tlsOptions.RootCAFile, _ = cmd.Flags().GetString("ca-cert")
+	tlsOptions.CertFile, _ = cmd.Flags().GetString("cert")
+	tlsOptions.KeyFile, _ = cmd.Flags().GetString("key")
+	tlsOptions.InsecureSkipVerify, _ = cmd.Flags().GetBool("insecure")
+	if tlsOptions != (fetcher.TLSOptions{}) {
+		if tlsOptions.InsecureSkipVerify {
+			_, _ = color.New(color.FgRed, color.Bold).Fprintln(os.Stderr, "WARNING: --insecure disables TLS certificate verification; responses may be intercepted or forged")
+		}
+
+		transport, err := tlsOptions.Transport()
+		if err != nil {
+			return nil, nil, err
+		}
+		httpClient.Transport = transport
+	}
+
+	done := func() {}
+	if cookieJar, _ := cmd.Flags().GetString("cookie-jar"); cookieJar != "" {
+		jar, err := fetcher.NewCookieJar(cookieJar)
+		if err != nil {
+			return nil, nil, err
+		}
+		httpClient.Jar = jar
+		done = func() {
+			if err := jar.Save(); err != nil {
+				notice("Warning: %v", err)
+			}
+		}
+	}
+	client.Client = httpClient
+	return client, done, nil
+}
+
+// parseResolve reads curl-style --resolve values, HOST:PORT:ADDRESS, into
+// fetcher.Fetcher.Resolve overrides. IPv6 addresses may be bracketed.
+func parseResolve(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	overrides := make(map[string]string, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid --resolve %q: want HOST:PORT:ADDRESS", value)
+		}
+		address := strings.TrimSuffix(strings.TrimPrefix(parts[2], "["), "]")
+		overrides[parts[0]+":"+parts[1]] = address
+	}
+	return overrides, nil
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/pem"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
+)
+
+func TestNewFetcher(t *testing.T) {
+	cmd := &cobra.Command{}
+	addFetchFlags(cmd)
+	for name, value := range map[string]string{
+		"max-body-size": "1024",
+		"max-redirects": "3",
+		"no-follow":     "true",
+		"block-private": "true",
+		"resolve":       "example.com:443:192.0.2.10",
+		"ipv6":          "true",
+	} {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("Failed to set --%s: %v", name, err)
+		}
+	}
+
+	client, done, err := newFetcher(cmd)
+	if err != nil {
+		t.Fatalf("newFetcher() returned error: %v", err)
+	}
+	done()
+	if client.MaxBodySize != 1024 || client.MaxRedirects != 3 || !client.NoFollow || !client.BlockPrivateNetworks || client.Network != "tcp6" {
+		t.Errorf("newFetcher() = %+v, want the flag values", client)
+	}
+	if client.Resolve["example.com:443"] != "192.0.2.10" {
+		t.Errorf("Resolve = %v, want example.com:443 pinned", client.Resolve)
+	}
+
+	if err := cmd.Flags().Set("resolve", "example.com"); err != nil {
+		t.Fatalf("Failed to set --resolve: %v", err)
+	}
+	if _, _, err := newFetcher(cmd); err == nil {
+		t.Error("newFetcher() expected error for an invalid --resolve")
+	}
+}
+
+func TestFetchFlags_Commands(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`<title>Internal</title>`))
+	}))
+	defer server.Close()
+
+	commands := []struct {
+		cmd  *cobra.Command
+		args []string
+	}{
+		{cmd: validateCmd, args: []string{server.URL}},
+		{cmd: previewCmd, args: []string{server.URL}},
+		{cmd: diffCmd, args: []string{server.URL, server.URL}},
+		{cmd: watchCmd, args: []string{server.URL}},
+		{cmd: faviconCmd, args: []string{server.URL}},
+	}
+
+	for _, tt := range commands {
+		t.Run(tt.cmd.Name(), func(t *testing.T) {
+			if err := tt.cmd.Flags().Set("block-private", "true"); err != nil {
+				t.Fatalf("Failed to set flag: %v", err)
+			}
+			defer func() { _ = tt.cmd.Flags().Set("block-private", "false") }()
+			if tt.cmd == watchCmd {
+				setWatchFlags(t, map[string]string{"count": "1"})
+			}
+
+			// watch reports a failed check and carries on; the others fail
+			err := tt.cmd.RunE(tt.cmd, tt.args)
+			if tt.cmd != watchCmd && !errors.Is(err, fetcher.ErrBlockedAddress) {
+				t.Errorf("%s error = %v, want %v", tt.cmd.Name(), err, fetcher.ErrBlockedAddress)
+			}
+			if got := requests.Load(); got != 0 {
+				t.Errorf("%s sent %d requests, want none with --block-private", tt.cmd.Name(), got)
+			}
+		})
+	}
+}
+
+func TestFetchFlags_CACert(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`<title>Intranet</title>`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	for _, cmd := range []*cobra.Command{validateCmd, previewCmd, faviconCmd} {
+		t.Run(cmd.Name(), func(t *testing.T) {
+			if err := cmd.Flags().Set("ca-cert", caFile); err != nil {
+				t.Fatalf("Failed to set flag: %v", err)
+			}
+			defer func() { _ = cmd.Flags().Set("ca-cert", "") }()
+
+			requests.Store(0)
+			_ = cmd.RunE(cmd, []string{server.URL})
+			if requests.Load() == 0 {
+				t.Errorf("%s sent no requests, want the private CA trusted", cmd.Name())
+			}
+		})
+	}
+}
+
+func TestParseResolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    map[string]string
+		expectError bool
+	}{
+		{name: "none", values: nil, expected: nil},
+		{name: "ipv4", values: []string{"example.com:443:192.0.2.10"}, expected: map[string]string{"example.com:443": "192.0.2.10"}},
+		{name: "bracketed ipv6", values: []string{"example.com:80:[2001:db8::1]"}, expected: map[string]string{"example.com:80": "2001:db8::1"}},
+		{name: "missing address", values: []string{"example.com:443"}, expectError: true},
+		{name: "empty port", values: []string{"example.com::192.0.2.10"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseResolve(tt.values)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("parseResolve() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !maps.Equal(result, tt.expected) {
+				t.Errorf("parseResolve() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
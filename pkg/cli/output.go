@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+// outputFormats are the values accepted by --format
+var outputFormats = []string{"text", "json", "yaml"}
+
+// writeResults writes result to w as colorized text, or in the JSON schema
+// of metadata.Metadata.MarshalJSON for json and yaml
+func writeResults(w io.Writer, result *metadata.Metadata, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		return writeYAML(w, result)
+	default:
+		displayResults(w, result)
+		return nil
+	}
+}
+
+// batchResult is the outcome for one URL of a batch in the json and yaml
+// formats
+type batchResult struct {
+	Input    string             `json:"input"`
+	Metadata *metadata.Metadata `json:"metadata,omitempty"`
+	Error    string             `json:"error,omitempty"`
+
+	// DuplicateOf is the earlier input scraped as the same canonical URL,
+	// whose metadata is not repeated
+	DuplicateOf string `json:"duplicateOf,omitempty"`
+}
+
+// writeBatchResult writes the outcome of scraping input, one JSON object
+// per line for json and one document per URL for yaml, so results can be
+// consumed as they arrive
+func writeBatchResult(w io.Writer, format, input string, result *metadata.Metadata, scrapeErr error) error {
+	entry := batchResult{Input: input, Metadata: result}
+	if scrapeErr != nil {
+		entry.Error = scrapeErr.Error()
+	}
+	return writeBatchEntry(w, format, entry)
+}
+
+// writeBatchAlias records input as an alias of original, an earlier input
+// of the batch that resolved to the same canonical URL
+func writeBatchAlias(w io.Writer, format, input, original string) error {
+	return writeBatchEntry(w, format, batchResult{Input: input, DuplicateOf: original})
+}
+
+// writeBatchEntry writes one batch outcome in format
+func writeBatchEntry(w io.Writer, format string, entry batchResult) error {
+	switch format {
+	case "json":
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		return writeYAML(w, entry)
+	default:
+		_, _ = color.New(color.Bold).Fprintf(w, "\n==> %s\n", entry.Input)
+		switch {
+		case entry.Error != "":
+			_, _ = color.New(color.FgRed).Fprintf(w, "✗ %s\n", entry.Error)
+		case entry.DuplicateOf != "":
+			_, _ = color.New(color.FgYellow).Fprintf(w, "= same page as %s\n", entry.DuplicateOf)
+		default:
+			displayResults(w, entry.Metadata)
+		}
+		return nil
+	}
+}
+
+// writeYAML writes value as YAML with the same keys, in the same order, as
+// its JSON form
+func writeYAML(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	// JSON is YAML, so decoding it into a node tree keeps key order; the
+	// flow and quoting styles it was read with are reset to YAML's defaults
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("failed to convert to YAML: %w", err)
+	}
+	resetStyle(&document)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return encoder.Close()
+}
+
+// resetStyle clears the presentation style of node and its descendants
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// writeOutput calls write with the output at path (see createOutput),
+// closing it afterwards
+func writeOutput(stdout io.Writer, path string, write func(io.Writer) error) error {
+	output, closeOutput, err := createOutput(stdout, path)
+	if err != nil {
+		return err
+	}
+	if err := write(output); err != nil {
+		_ = closeOutput()
+		return err
+	}
+	return closeOutput()
+}
+
+// createOutput opens path for writing the results, creating its parent
+// directories; stdout is used for "" and "-". Colors are disabled for
+// files, and restored by the returned close function.
+func createOutput(stdout io.Writer, path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return stdout, func() error { return nil }, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+
+	noColor := color.NoColor
+	color.NoColor = true
+	return file, func() error {
+		color.NoColor = noColor
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+// Output chrome, set by the global --quiet and --verbose flags
+var (
+	// quiet suppresses progress notices
+	quiet bool
+
+	// verbose adds debug messages, such as each HTTP request made
+	verbose bool
+)
+
+// notice prints progress to stderr, keeping stdout for the results; nothing
+// is printed with --quiet
+func notice(format string, args ...any) {
+	if quiet {
+		return
+	}
+	_, _ = color.New(color.FgYellow).Fprintf(os.Stderr, format+"\n", args...)
+}
+
+// debug prints detail to stderr with --verbose
+func debug(format string, args ...any) {
+	if !verbose {
+		return
+	}
+	_, _ = color.New(color.Faint).Fprintf(os.Stderr, format+"\n", args...)
+}
+
+// debugHooks returns fetcher hooks that print each request with --verbose
+func debugHooks() fetcher.Hooks {
+	if !verbose {
+		return fetcher.Hooks{}
+	}
+	return fetcher.Hooks{RequestDone: func(ctx context.Context, info fetcher.RequestInfo) {
+		if info.StatusCode == 0 {
+			debug("%s %s: %v (%s)", info.Method, info.URL, info.Err, info.Duration.Round(time.Millisecond))
+			return
+		}
+		debug("%s %s → %d, %d bytes in %s", info.Method, info.URL, info.StatusCode, info.Bytes, info.Duration.Round(time.Millisecond))
+	}}
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+const outputFixture = `<html lang="en"><head>
+<title>Page</title>
+<meta property="og:title" content="OG Title">
+<meta property="og:image:width" content="1200">
+<meta name="keywords" content="true, 42">
+</head></html>`
+
+func TestWriteResults(t *testing.T) {
+	result, err := scraper.ScrapePreview(strings.NewReader(outputFixture))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+	expected, _ := json.Marshal(result)
+
+	tests := []struct {
+		format string
+		decode func([]byte, any) error
+	}{
+		{format: "json", decode: json.Unmarshal},
+		{format: "yaml", decode: yaml.Unmarshal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeResults(&buf, result, tt.format); err != nil {
+				t.Fatalf("writeResults() returned error: %v", err)
+			}
+
+			var decoded, want any
+			if err := tt.decode(buf.Bytes(), &decoded); err != nil {
+				t.Fatalf("Failed to decode %s output: %v\n%s", tt.format, err, buf.String())
+			}
+			_ = json.Unmarshal(expected, &want)
+
+			// Round trip through JSON so both sides use the same types
+			got, _ := json.Marshal(decoded)
+			wanted, _ := json.Marshal(want)
+			if string(got) != string(wanted) {
+				t.Errorf("writeResults() %s = %s, want %s", tt.format, got, wanted)
+			}
+		})
+	}
+}
+
+func TestWriteResults_YAMLKeepsKeyOrder(t *testing.T) {
+	result, _ := scraper.ScrapePreview(strings.NewReader(outputFixture))
+
+	var buf bytes.Buffer
+	if err := writeResults(&buf, result, "yaml"); err != nil {
+		t.Fatalf("writeResults() returned error: %v", err)
+	}
+
+	output := buf.String()
+	if title, favicon := strings.Index(output, "title:"), strings.Index(output, "favicon:"); title < 0 || favicon < title {
+		t.Errorf("writeResults() yaml = %s, want title before favicon as in JSON", output)
+	}
+	if strings.Contains(output, `{"`) {
+		t.Errorf("writeResults() yaml = %s, want block style", output)
+	}
+	if !strings.Contains(output, `"1200"`) {
+		t.Errorf("writeResults() yaml = %s, want numeric strings quoted", output)
+	}
+}
+
+func TestCreateOutput(t *testing.T) {
+	var stdout bytes.Buffer
+	for _, path := range []string{"", "-"} {
+		output, closeOutput, err := createOutput(&stdout, path)
+		if err != nil {
+			t.Fatalf("createOutput(%q) returned error: %v", path, err)
+		}
+		if output != &stdout {
+			t.Errorf("createOutput(%q) = %v, want stdout", path, output)
+		}
+		if err := closeOutput(); err != nil {
+			t.Errorf("close returned error: %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "results", "2024", "example.txt")
+	output, closeOutput, err := createOutput(&stdout, path)
+	if err != nil {
+		t.Fatalf("createOutput() returned error: %v", err)
+	}
+	result, _ := scraper.ScrapePreview(strings.NewReader(outputFixture))
+	if err := writeResults(output, result, "text"); err != nil {
+		t.Fatalf("writeResults() returned error: %v", err)
+	}
+	if err := closeOutput(); err != nil {
+		t.Fatalf("close returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !strings.Contains(string(data), "Title: OG Title") || strings.Contains(string(data), "\x1b[") {
+		t.Errorf("output file = %q, want uncolored results", data)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("stdout = %q, want nothing written", stdout.String())
+	}
+}
+
+func TestWriteBatchResult(t *testing.T) {
+	result, _ := scraper.ScrapePreview(strings.NewReader(outputFixture))
+	failure := errors.New("HTTP error! status: 404")
+
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{format: "json", expected: []string{`{"input":"https://a.example","metadata":{`, `{"input":"https://b.example","error":"HTTP error! status: 404"}`, `{"input":"https://a.example/?utm_source=x","duplicateOf":"https://a.example"}`}},
+		{format: "yaml", expected: []string{"---\ninput: https://a.example\nmetadata:\n", "---\ninput: https://b.example\nerror: 'HTTP error! status: 404'\n", "---\ninput: https://a.example/?utm_source=x\nduplicateOf: https://a.example\n"}},
+		{format: "text", expected: []string{"==> https://a.example", "Title: OG Title", "==> https://b.example\n✗ HTTP error! status: 404", "==> https://a.example/?utm_source=x\n= same page as https://a.example"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeBatchResult(&buf, tt.format, "https://a.example", result, nil); err != nil {
+				t.Fatalf("writeBatchResult() returned error: %v", err)
+			}
+			if err := writeBatchResult(&buf, tt.format, "https://b.example", nil, failure); err != nil {
+				t.Fatalf("writeBatchResult() returned error: %v", err)
+			}
+			if err := writeBatchAlias(&buf, tt.format, "https://a.example/?utm_source=x", "https://a.example"); err != nil {
+				t.Fatalf("writeBatchAlias() returned error: %v", err)
+			}
+
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("writeBatchResult() %s = %q, want it to contain %q", tt.format, buf.String(), expected)
+				}
+			}
+		})
+	}
+}
+
+func TestDebugHooks(t *testing.T) {
+	defer func() { verbose = false }()
+
+	verbose = false
+	if hooks := debugHooks(); hooks.RequestStart != nil || hooks.RequestDone != nil {
+		t.Error("debugHooks() set hooks without --verbose")
+	}
+
+	verbose = true
+	if hooks := debugHooks(); hooks.RequestDone == nil {
+		t.Error("debugHooks() = no RequestDone hook, want one with --verbose")
+	}
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// previewWidth is how many columns of text fit inside a drawn card
+const previewWidth = 60
+
+// previewCmd represents the preview command
+var previewCmd = &cobra.Command{
+	Use:   "preview [URL]",
+	Short: "Draw a webpage's link-preview card in the terminal",
+	Long: `Draw an approximation of the card a platform shows when a webpage is shared:
+its site name, title, truncated description and image, chosen and cut off
+the way the platform does.
+
+Examples:
+  glypto preview https://example.com
+  glypto preview --platform slack https://example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPreview,
+}
+
+func runPreview(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unsupported format %q: use text, json or yaml", format)
+	}
+	platform, _ := cmd.Flags().GetString("platform")
+	if !slices.Contains(metadata.Platforms, metadata.Platform(platform)) {
+		return fmt.Errorf("unsupported platform %q: use twitter, facebook, slack or discord", platform)
+	}
+
+	url, err := getURLFromInput(args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	client, done, err := newFetcher(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	result, err := scrapeURL(ctx, client, url, false, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		return err
+	}
+
+	card, err := metadata.NewPlatformCard(result, metadata.Platform(platform))
+	if err != nil {
+		return err
+	}
+	return writeCard(cmd.OutOrStdout(), card, format)
+}
+
+// writeCard writes card to w as a drawn box, or as JSON or YAML
+func writeCard(w io.Writer, card *metadata.PlatformCard, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(card, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		return writeYAML(w, card)
+	default:
+		drawCard(w, card)
+		return nil
+	}
+}
+
+// cardLine is one line of text inside a drawn card and its style
+type cardLine struct {
+	text  string
+	style *color.Color
+}
+
+// drawCard draws card as a box: a large image spans the top, a thumbnail
+// follows the text
+func drawCard(w io.Writer, card *metadata.PlatformCard) {
+	faint := color.New(color.Faint)
+
+	var image []cardLine
+	switch {
+	case card.Image == "":
+		image = append(image, cardLine{"[no image]", faint})
+	default:
+		label := "[image]"
+		if !card.LargeImage {
+			label = "[thumbnail]"
+		}
+		if card.ImageWidth > 0 && card.ImageHeight > 0 {
+			label = fmt.Sprintf("%s %dx%d", label, card.ImageWidth, card.ImageHeight)
+		}
+		image = append(image, cardLine{label, faint}, cardLine{clip(card.Image, previewWidth), faint})
+	}
+
+	var lines []cardLine
+	if card.LargeImage {
+		lines = append(lines, image...)
+		lines = append(lines, cardLine{"", nil})
+	}
+	lines = append(lines, cardLine{clip(card.SiteName, previewWidth), faint})
+	for _, text := range wrapText(card.Title, previewWidth) {
+		lines = append(lines, cardLine{text, color.New(color.Bold)})
+	}
+	for _, text := range wrapText(card.Description, previewWidth) {
+		lines = append(lines, cardLine{text, nil})
+	}
+	if !card.LargeImage {
+		lines = append(lines, cardLine{"", nil})
+		lines = append(lines, image...)
+	}
+
+	_, _ = color.New(color.Bold).Fprintf(w, "\n%s preview\n", card.Platform)
+	border := strings.Repeat("─", previewWidth+2)
+	_, _ = fmt.Fprintf(w, "┌%s┐\n", border)
+	for _, line := range lines {
+		padded := line.text + strings.Repeat(" ", previewWidth-utf8.RuneCountInString(line.text))
+		_, _ = fmt.Fprint(w, "│ ")
+		if line.style != nil {
+			_, _ = line.style.Fprint(w, padded)
+		} else {
+			_, _ = fmt.Fprint(w, padded)
+		}
+		_, _ = fmt.Fprintln(w, " │")
+	}
+	_, _ = fmt.Fprintf(w, "└%s┘\n", border)
+}
+
+// wrapText breaks text into lines of at most width runes at spaces,
+// splitting words longer than a line
+func wrapText(text string, width int) []string {
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		runes := []rune(word)
+		if len(line) > 0 && len(line)+1+len(runes) > width {
+			lines = append(lines, string(line))
+			line = nil
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		for len(line)+len(runes) > width {
+			cut := width - len(line)
+			lines = append(lines, string(append(line, runes[:cut]...)))
+			line, runes = nil, runes[cut:]
+		}
+		line = append(line, runes...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// clip shortens text to width runes, ending it with an ellipsis when cut
+func clip(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().String("platform", "twitter", "Platform whose card to draw: twitter, facebook, slack or discord")
+	previewCmd.Flags().String("format", "text", "Output format: text, json or yaml")
+	previewCmd.Flags().Duration("timeout", 30*time.Second, "Give up after this long (0 for no limit)")
+	addFetchFlags(previewCmd)
+}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+const previewPage = `<head>
+<meta property="og:title" content="OG Title">
+<meta property="og:description" content="A description long enough that it has to wrap onto a second line inside the drawn card">
+<meta property="og:image" content="https://example.com/image.png">
+<meta property="og:image:width" content="1200">
+<meta property="og:image:height" content="630">
+<meta property="og:site_name" content="Example">
+<meta name="twitter:title" content="Twitter Title">
+</head>`
+
+func TestRunPreview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(previewPage))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		platform string
+		expected []string
+	}{
+		{platform: "twitter", expected: []string{"twitter preview", "Twitter Title", "[thumbnail] 1200x630"}},
+		{platform: "facebook", expected: []string{"facebook preview", "OG Title", "[image] 1200x630"}},
+		{platform: "slack", expected: []string{"slack preview", "Example", "OG Title"}},
+	}
+
+	defer func() { _ = previewCmd.Flags().Set("platform", "twitter") }()
+
+	for _, tt := range tests {
+		t.Run(tt.platform, func(t *testing.T) {
+			var buf bytes.Buffer
+			previewCmd.SetOut(&buf)
+			defer previewCmd.SetOut(nil)
+			_ = previewCmd.Flags().Set("platform", tt.platform)
+
+			if err := runPreview(previewCmd, []string{server.URL}); err != nil {
+				t.Fatalf("runPreview() failed: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("Output = %q, want it to contain %q", buf.String(), expected)
+				}
+			}
+		})
+	}
+}
+
+func TestRunPreview_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(previewPage))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	previewCmd.SetOut(&buf)
+	defer previewCmd.SetOut(nil)
+	_ = previewCmd.Flags().Set("format", "json")
+	defer func() { _ = previewCmd.Flags().Set("format", "text") }()
+
+	if err := runPreview(previewCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runPreview() failed: %v", err)
+	}
+
+	var card metadata.PlatformCard
+	if err := json.Unmarshal(buf.Bytes(), &card); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if card.Platform != metadata.PlatformTwitter || card.Title != "Twitter Title" || card.ImageWidth != 1200 {
+		t.Errorf("Card = %+v, want the Twitter card with image dimensions", card)
+	}
+}
+
+func TestRunPreview_InvalidPlatform(t *testing.T) {
+	_ = previewCmd.Flags().Set("platform", "myspace")
+	defer func() { _ = previewCmd.Flags().Set("platform", "twitter") }()
+
+	if err := runPreview(previewCmd, []string{"https://example.com"}); err == nil {
+		t.Error("runPreview() expected an error for an unknown platform")
+	}
+}
+
+func TestDrawCard(t *testing.T) {
+	card := &metadata.PlatformCard{
+		Card:       metadata.Card{Title: "Title", Description: "Description", SiteName: "example.com"},
+		Platform:   metadata.PlatformDiscord,
+		LargeImage: false,
+	}
+
+	var buf bytes.Buffer
+	drawCard(&buf, card)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.Contains(buf.String(), "[no image]") {
+		t.Errorf("Output = %q, want a missing image placeholder", buf.String())
+	}
+	for _, line := range lines[1:] {
+		if length := utf8.RuneCountInString(line); length != previewWidth+4 {
+			t.Errorf("Line %q is %v wide, want %v", line, length, previewWidth+4)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected []string
+	}{
+		{name: "fits", text: "one two", width: 10, expected: []string{"one two"}},
+		{name: "wraps at spaces", text: "one two three", width: 8, expected: []string{"one two", "three"}},
+		{name: "splits long words", text: "abcdefghij", width: 4, expected: []string{"abcd", "efgh", "ij"}},
+		{name: "splits after a short word", text: "ab cdefgh", width: 4, expected: []string{"ab", "cdef", "gh"}},
+		{name: "empty", text: "  ", width: 4, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.width); strings.Join(got, "|") != strings.Join(tt.expected, "|") || len(got) != len(tt.expected) {
+				t.Errorf("wrapText() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClip(t *testing.T) {
+	tests := []struct {
+		text     string
+		width    int
+		expected string
+	}{
+		{text: "short", width: 10, expected: "short"},
+		{text: "https://example.com/long", width: 10, expected: "https://e…"},
+	}
+
+	for _, tt := range tests {
+		if got := clip(tt.text, tt.width); got != tt.expected {
+			t.Errorf("clip(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.expected)
+		}
+	}
+}
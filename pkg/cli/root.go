@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"context"
 	"os"
+	"os/signal"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -14,13 +17,17 @@ var rootCmd = &cobra.Command{
 
 It extracts metadata including titles, descriptions, images, Open Graph data,
 Twitter Cards, and RSS/Atom feeds from web pages.`,
-	Version: "0.1.0",
+	Version:          "0.1.0",
+	PersistentPreRun: applyOutputFlags,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Interrupting the process cancels the command's context.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
 		os.Exit(1)
 	}
@@ -33,7 +40,21 @@ func init() {
 
 	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.glypto.yaml)")
 
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output (also set by the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Print only results and errors, without progress messages")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Print debug detail to stderr, such as each HTTP request made")
+	rootCmd.MarkFlagsMutuallyExclusive("quiet", "verbose")
+
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
+
+// applyOutputFlags applies the global output flags before a command runs
+func applyOutputFlags(cmd *cobra.Command, args []string) {
+	quiet, _ = cmd.Flags().GetBool("quiet")
+	verbose, _ = cmd.Flags().GetBool("verbose")
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor || os.Getenv("NO_COLOR") != "" {
+		color.NoColor = true
+	}
+}
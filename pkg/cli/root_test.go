@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
@@ -81,3 +82,74 @@ func TestRootCmdProperties(t *testing.T) {
 		t.Errorf("Expected Version to be '0.1.0', got '%s'", rootCmd.Version)
 	}
 }
+
+// resetOutputFlags unsets the global output flags, so flag groups see them
+// as not given
+func resetOutputFlags() {
+	for _, name := range []string{"quiet", "verbose", "no-color"} {
+		flag := rootCmd.PersistentFlags().Lookup(name)
+		_ = flag.Value.Set("false")
+		flag.Changed = false
+	}
+}
+
+func TestApplyOutputFlags(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		env             string
+		expectedQuiet   bool
+		expectedVerbose bool
+		expectedNoColor bool
+	}{
+		{name: "defaults", args: nil},
+		{name: "quiet", args: []string{"--quiet"}, expectedQuiet: true},
+		{name: "verbose shorthand", args: []string{"-v"}, expectedVerbose: true},
+		{name: "no color", args: []string{"--no-color"}, expectedNoColor: true},
+		{name: "NO_COLOR", env: "1", expectedNoColor: true},
+	}
+
+	noColor := color.NoColor
+	defer func() { color.NoColor, quiet, verbose = noColor, false, false }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			color.NoColor = false
+			t.Setenv("NO_COLOR", tt.env)
+			defer resetOutputFlags()
+			defer func() { _ = previewCmd.Flags().Set("platform", "twitter") }()
+
+			// An unknown platform fails after the flags have been applied,
+			// without fetching anything
+			buf := new(bytes.Buffer)
+			rootCmd.SetOut(buf)
+			rootCmd.SetErr(buf)
+			rootCmd.SetArgs(append([]string{"preview", "--platform", "none"}, tt.args...))
+			defer rootCmd.SetArgs(nil)
+			defer rootCmd.SetOut(nil)
+			defer rootCmd.SetErr(nil)
+
+			if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unsupported platform") {
+				t.Fatalf("Execute() error = %v, want the unsupported platform", err)
+			}
+			if quiet != tt.expectedQuiet || verbose != tt.expectedVerbose || color.NoColor != tt.expectedNoColor {
+				t.Errorf("quiet, verbose, NoColor = %v, %v, %v, want %v, %v, %v", quiet, verbose, color.NoColor, tt.expectedQuiet, tt.expectedVerbose, tt.expectedNoColor)
+			}
+		})
+	}
+}
+
+func TestRootCmd_QuietAndVerbose(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"preview", "--quiet", "--verbose", "https://example.com"})
+	defer rootCmd.SetArgs(nil)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+	defer resetOutputFlags()
+
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "none of the others can be") {
+		t.Errorf("Execute() error = %v, want --quiet and --verbose to be mutually exclusive", err)
+	}
+}
@@ -2,32 +2,51 @@ package cli
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
 
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/providers"
 	"github.com/alvincrespo/glypto-go/pkg/scraper"
 )
 
 // scrapeCmd represents the scrape command
 var scrapeCmd = &cobra.Command{
-	Use:   "scrape [URL]",
+	Use:   "scrape [URL...]",
 	Short: "Scrape metadata from a webpage",
 	Long: `Scrape metadata from a webpage including Open Graph tags, Twitter Cards, 
 standard meta tags, and other HTML elements.
 
 You can provide a URL as an argument or you will be prompted to enter one.
+Several URLs, an --input-file or a list piped to stdin are scraped as a
+batch: each URL gets its own result or error, and a failure does not stop
+the rest.
 
 Examples:
   glypto scrape https://example.com
+  glypto scrape --preview-only https://example.com
+  glypto scrape --providers twitter,openGraph,meta https://example.com
+  glypto scrape --respect-robots https://example.com
+  glypto scrape --follow-refresh 3 https://example.com
+  glypto scrape --format json -o results/example.json https://example.com
+  glypto scrape --format json --input-file urls.txt --concurrency 8
   glypto scrape`,
-	Args: cobra.MaximumNArgs(1),
 	RunE: runScrape,
 }
 
@@ -53,37 +72,127 @@ func getURLFromInput(args []string) (string, error) {
 	return url, nil
 }
 
-func fetchWebpage(url string) (*http.Response, error) {
-	color.Yellow("Fetching metadata from: %s", url)
+func fetchWebpage(ctx context.Context, client *fetcher.Fetcher, url string) (*http.Response, error) {
+	notice("Fetching metadata from: %s", url)
+	return client.Fetch(ctx, url)
+}
+
+func parseHTML(resp *http.Response) (*html.Node, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
 
-	resp, err := http.Get(url)
+	doc, err := html.Parse(bytes.NewReader(body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		_ = resp.Body.Close()
-		return nil, fmt.Errorf("HTTP error! status: %d", resp.StatusCode)
+	// Documents in a non-UTF-8 charset are transcoded and parsed again
+	if decoded, ok := transcodeToUTF8(body, bodyCharset(body, resp.Header.Get("Content-Type"), doc)); ok {
+		doc, err = html.Parse(bytes.NewReader(decoded))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		}
 	}
 
-	return resp, nil
+	return doc, nil
 }
 
-func parseHTML(resp *http.Response) (*html.Node, error) {
-	doc, err := html.Parse(resp.Body)
+// bodyCharset names the charset of body: a byte order mark or the
+// Content-Type header's charset wins, then one declared by meta tags
+func bodyCharset(body []byte, contentType string, doc *html.Node) string {
+	if _, name, certain := charset.DetermineEncoding(body, contentType); certain {
+		return name
+	}
+	return declaredCharset(doc)
+}
+
+// declaredCharset returns the charset declared by the document's meta tags
+func declaredCharset(doc *html.Node) string {
+	metadata, err := scraper.ScrapeMetadataWithProviderNames(doc, []string{"meta"})
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		return ""
 	}
-	return doc, nil
+
+	if charset := metadata.Charset(); charset != nil {
+		return *charset
+	}
+	return ""
+}
+
+// transcodeToUTF8 converts body from the named charset to UTF-8, reporting
+// false when no conversion is needed or the charset is unknown
+func transcodeToUTF8(body []byte, label string) ([]byte, bool) {
+	if label == "" {
+		return nil, false
+	}
+
+	encoding, name := charset.Lookup(label)
+	if encoding == nil || name == "utf-8" {
+		return nil, false
+	}
+
+	decoded, err := encoding.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, false
+	}
+	return decoded, true
+}
+
+// scrapeMetadata scrapes doc, described by document, with scraperInstance,
+// or with the built-in providers and options when it is nil
+func scrapeMetadata(ctx context.Context, doc *html.Node, document *metadata.Document, scraperInstance *scraper.Scraper, options scraper.ScrapeOptions) (*metadata.Metadata, error) {
+	if scraperInstance == nil {
+		created, err := scraper.CreateScraper()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scraper: %w", err)
+		}
+		scraperInstance = created.WithOptions(options)
+	}
+
+	metadata, err := scraperInstance.ScrapeContext(ctx, doc, document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape metadata: %w", err)
+	}
+
+	return metadata, nil
 }
 
-func scrapeMetadata(doc *html.Node) (*metadata.Metadata, error) {
-	scraperInstance, err := scraper.CreateScraper()
+// providerSelection chooses the providers full scrapes run with: the
+// built-in ones, only the named ones, or the plugins in a directory
+type providerSelection struct {
+	names     []string
+	pluginDir string
+}
+
+// newScraper creates a scraper with the selected providers. Named providers
+// resolve values in the listed order.
+func (p providerSelection) newScraper() (*scraper.Scraper, error) {
+	if p.pluginDir != "" {
+		return scraper.CreateScraperFromDirectory(p.pluginDir)
+	}
+	if len(p.names) == 0 {
+		return scraper.CreateScraper()
+	}
+
+	scraperInstance, err := scraper.CreateScraperWithProviderOrder(p.names)
+	if err != nil {
+		available := providers.NewLoader().GetAvailableProviders()
+		return nil, fmt.Errorf("%w (available: %s)", err, strings.Join(available, ", "))
+	}
+	return scraperInstance, nil
+}
+
+// scrapePreview runs the head-only fast path, transcoding the body from the
+// charset declared in the Content-Type header or an early meta tag
+func scrapePreview(resp *http.Response, options scraper.ScrapeOptions) (*metadata.Metadata, error) {
+	body, err := charset.NewReader(resp.Body, resp.Header.Get("Content-Type"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create scraper: %w", err)
+		return nil, fmt.Errorf("failed to decode response body: %w", err)
 	}
 
-	metadata, err := scraperInstance.Scrape(doc)
+	metadata, err := scraper.ScrapePreviewWithOptions(body, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scrape metadata: %w", err)
 	}
@@ -91,79 +200,523 @@ func scrapeMetadata(doc *html.Node) (*metadata.Metadata, error) {
 	return metadata, nil
 }
 
-func displayResults(metadata *metadata.Metadata) {
-	color.Green("\n✓ Metadata scraped successfully:\n")
+func displayResults(w io.Writer, metadata *metadata.Metadata) {
+	_, _ = color.New(color.FgGreen).Fprint(w, "\n✓ Metadata scraped successfully:\n")
+	if metadata.Archive != nil {
+		_, _ = color.New(color.FgYellow).Fprintf(w, "Archived copy from %s: %s\n", metadata.Archive.Timestamp.Format(time.DateOnly), metadata.Archive.URL)
+	}
 
-	printField("Title", metadata.Title())
-	printField("Description", metadata.Description())
-	printField("Image", metadata.Image())
-	printField("URL", metadata.URL())
-	printField("Site Name", metadata.SiteName())
+	printField(w, "Title", metadata.Title())
+	printField(w, "Description", metadata.Description())
+	printField(w, "Image", metadata.Image())
+	printField(w, "URL", metadata.URL())
+	printField(w, "Site Name", metadata.SiteName())
 
 	favicon := metadata.Favicon()
-	printField("Favicon", &favicon)
+	printField(w, "Favicon", &favicon)
 
 	if len(metadata.Feeds) > 0 {
-		_, _ = color.New(color.Bold).Println("\nFeeds:")
+		_, _ = color.New(color.Bold).Fprintln(w, "\nFeeds:")
 		for i, feed := range metadata.Feeds {
 			title := "Untitled"
 			if feed.Title != nil {
 				title = *feed.Title
 			}
-			fmt.Printf("  %d. %s (%s) - %s\n", i+1, title, feed.Type, feed.Href)
+			_, _ = fmt.Fprintf(w, "  %d. %s (%s) - %s\n", i+1, title, feed.Type, metadata.ResolveURL(feed.Href))
 		}
 	}
 
-	printProviderData("Open Graph Tags", metadata.OpenGraph())
-	printProviderData("Twitter Card Tags", metadata.TwitterCard())
+	if len(metadata.ProbedImages) > 0 {
+		_, _ = color.New(color.Bold).Fprintln(w, "\nImages:")
+		for i, image := range metadata.ProbedImages {
+			size := "unknown size"
+			if image.Width > 0 && image.Height > 0 {
+				size = fmt.Sprintf("%dx%d", image.Width, image.Height)
+			}
+			_, _ = fmt.Fprintf(w, "  %d. %s (%s, %s)\n", i+1, image.URL, image.Type, size)
+		}
+	}
+
+	if len(metadata.Redirects) > 0 {
+		_, _ = color.New(color.Bold).Fprintln(w, "\nRedirects:")
+		for i, redirect := range metadata.Redirects {
+			_, _ = fmt.Fprintf(w, "  %d. %s\n", i+1, redirect)
+		}
+		printField(w, "Final URL", metadata.FinalURL())
+	}
+
+	printProviderData(w, "Open Graph Tags", metadata.OpenGraph())
+	printProviderData(w, "Twitter Card Tags", metadata.TwitterCard())
 }
 
 func runScrape(cmd *cobra.Command, args []string) error {
-	url, err := getURLFromInput(args)
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unsupported format %q: use text, json or yaml", format)
+	}
+
+	urls, batch, err := scrapeInputs(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	resp, err := fetchWebpage(url)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	maxValueLength, _ := cmd.Flags().GetInt("max-value-length")
+	page := &pageScraper{
+		options: scraper.ScrapeOptions{MaxValueLength: maxValueLength},
+		timeout: timeout,
+	}
+	page.previewOnly, _ = cmd.Flags().GetBool("preview-only")
+	var selection providerSelection
+	selection.names, _ = cmd.Flags().GetStringSlice("providers")
+	selection.pluginDir, _ = cmd.Flags().GetString("plugin-dir")
+	if selection.pluginDir == "" && len(selection.names) == 0 {
+		selection.pluginDir = os.Getenv("GLYPTO_PLUGIN_DIR")
+	}
+	if !page.previewOnly {
+		// Built once and shared by every page, so plugins are loaded once
+		scraperInstance, err := selection.newScraper()
+		if err != nil {
+			return err
+		}
+		page.scraper = scraperInstance.WithOptions(page.options)
+	}
+	page.maxHops, _ = cmd.Flags().GetInt("follow-refresh")
+	page.respectRobots, _ = cmd.Flags().GetBool("respect-robots")
+	if probe, _ := cmd.Flags().GetBool("probe-images"); probe {
+		page.minImageSize, _ = cmd.Flags().GetInt("min-image-size")
+	}
+
+	client, done, err := newFetcher(cmd)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = resp.Body.Close() }()
+	defer done()
+	client.WaybackFallback, _ = cmd.Flags().GetBool("wayback")
+	if client.WaybackFallback && timeout > 0 {
+		// Leave half the time for the archive when the live page hangs
+		client.Client.Timeout = timeout / 2
+	}
+
+	page.client, page.prober = client, client
+	if render, _ := cmd.Flags().GetBool("render"); render {
+		renderer, err := fetcher.NewRenderer()
+		if err != nil {
+			return err
+		}
+		client.Renderer = renderer
+
+		// Images are fetched as they are, not rendered as pages
+		page.prober = &fetcher.Fetcher{
+			Client:               client.Client,
+			Limiter:              client.Limiter,
+			Cache:                client.Cache,
+			MaxBodySize:          client.MaxBodySize,
+			MaxRedirects:         client.MaxRedirects,
+			BlockPrivateNetworks: client.BlockPrivateNetworks,
+			Resolve:              client.Resolve,
+			Network:              client.Network,
+			Hooks:                client.Hooks,
+			Clock:                client.Clock,
+		}
+	}
+	if renderBelow, _ := cmd.Flags().GetInt("render-below"); renderBelow > 0 {
+		renderer, err := fetcher.NewRenderer()
+		if err != nil {
+			return err
+		}
+		page.renderBelow = renderBelow
+		page.renderer = &fetcher.Fetcher{
+			Renderer:             renderer,
+			Limiter:              client.Limiter,
+			BlockPrivateNetworks: client.BlockPrivateNetworks,
+			Hooks:                client.Hooks,
+			Clock:                client.Clock,
+		}
+	}
+
+	// Failed pages are a result, not a misuse of the command
+	cmd.SilenceUsage = true
+
+	outputPath, _ := cmd.Flags().GetString("output")
+	if !batch {
+		result, err := page.scrape(ctx, urls[0])
+		if err != nil {
+			return err
+		}
+		return writeOutput(cmd.OutOrStdout(), outputPath, func(w io.Writer) error {
+			return writeResults(w, result, format)
+		})
+	}
+
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	var aliases batchAliases
+	if dedupe, _ := cmd.Flags().GetBool("dedupe"); dedupe {
+		aliases = make(batchAliases)
+	}
+	return writeOutput(cmd.OutOrStdout(), outputPath, func(w io.Writer) error {
+		failed, err := page.scrapeBatch(ctx, urls, concurrency, func(url string, result *metadata.Metadata, err error) error {
+			if original := aliases.of(url, result); original != "" {
+				return writeBatchAlias(w, format, url, original)
+			}
+			return writeBatchResult(w, format, url, result, err)
+		})
+		if err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d URLs failed", failed, len(urls))
+		}
+		return nil
+	})
+}
+
+// pageScraper scrapes single pages as the scrape command's flags ask
+type pageScraper struct {
+	client *fetcher.Fetcher
+
+	// prober fetches images for probing; client unless it renders pages
+	prober *fetcher.Fetcher
+
+	options scraper.ScrapeOptions
+
+	// scraper runs full scrapes with the selected providers; nil uses the
+	// built-in ones
+	scraper *scraper.Scraper
+
+	timeout       time.Duration
+	previewOnly   bool
+	respectRobots bool
+	maxHops       int
+
+	// minImageSize enables image probing when positive
+	minImageSize int
 
-	doc, err := parseHTML(resp)
+	// renderer scrapes a page again in a browser when a plain fetch finds
+	// fewer than renderBelow fields
+	renderer    *fetcher.Fetcher
+	renderBelow int
+}
+
+// scrape fetches and scrapes url within the timeout, following meta
+// refreshes and probing images when enabled
+func (p *pageScraper) scrape(ctx context.Context, url string) (*metadata.Metadata, error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	result, err := p.scrapePage(ctx, url)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// Interstitial pages that soft-redirect via meta refresh carry little
+	// metadata of their own, so follow them when asked to
+	for hops := 0; hops < p.maxHops; hops++ {
+		target := result.RefreshURL()
+		if target == nil || *target == url {
+			break
+		}
+
+		notice("Following meta refresh to: %s", *target)
+		url = *target
+		if result, err = p.scrapePage(ctx, url); err != nil {
+			return nil, err
+		}
+	}
+
+	result.RespectRobots = p.respectRobots
+
+	if p.minImageSize > 0 {
+		if err := p.prober.ProbeImages(ctx, result, p.minImageSize); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// scrapePage fetches and scrapes url once, rendering it in a browser when
+// the plain fetch finds too few fields. A failed render keeps the plain
+// result.
+func (p *pageScraper) scrapePage(ctx context.Context, url string) (*metadata.Metadata, error) {
+	result, err := scrapeURL(ctx, p.client, url, p.previewOnly, p.scraper, p.options)
+	if err != nil || p.renderer == nil || result.Archive != nil || len(result.Fields()) >= p.renderBelow {
+		return result, err
 	}
 
-	metadata, err := scrapeMetadata(doc)
+	notice("Found %d fields; rendering: %s", len(result.Fields()), url)
+	rendered, err := scrapeURL(ctx, p.renderer, url, p.previewOnly, p.scraper, p.options)
 	if err != nil {
-		return err
+		notice("Warning: failed to render %s: %v", url, err)
+		return result, nil
+	}
+	return rendered, nil
+}
+
+// scrapeBatch scrapes urls with up to concurrency pages in flight, all
+// through the same fetcher so its limiter and cache are shared. Outcomes
+// are passed to write in input order as soon as the ones before them are
+// done; an error from write cancels the rest. It returns how many URLs
+// failed.
+func (p *pageScraper) scrapeBatch(ctx context.Context, urls []string, concurrency int, write func(url string, result *metadata.Metadata, err error) error) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type outcome struct {
+		result *metadata.Metadata
+		err    error
+	}
+	outcomes := make([]chan outcome, len(urls))
+	for i := range outcomes {
+		outcomes[i] = make(chan outcome, 1)
+	}
+
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range urls {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range max(concurrency, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := p.scrape(ctx, urls[i])
+				outcomes[i] <- outcome{result: result, err: err}
+			}
+		}()
+	}
+	defer wg.Wait()
+
+	failed := 0
+	for i, url := range urls {
+		done := <-outcomes[i]
+		if done.err != nil {
+			failed++
+		}
+		if err := write(url, done.result, done.err); err != nil {
+			cancel()
+			return failed, err
+		}
+	}
+	return failed, nil
+}
+
+// batchAliases maps each canonical URL of a batch to the first input that
+// was scraped as it
+type batchAliases map[string]string
+
+// of returns the earlier input whose page had the same canonical URL as
+// result, or "" when result is the first, failed or has no canonical URL.
+// A nil batchAliases never reports an alias.
+func (a batchAliases) of(input string, result *metadata.Metadata) string {
+	if a == nil || result == nil {
+		return ""
+	}
+	canonical := result.URL()
+	if canonical == nil {
+		return ""
+	}
+
+	if original, ok := a[*canonical]; ok {
+		return original
+	}
+	a[*canonical] = input
+	return ""
+}
+
+// scrapeInputs returns the URLs to scrape and whether they form a batch:
+// several arguments, an --input-file ("-" for stdin), or a list piped to
+// stdin. A single argument, or a URL typed at the prompt, is scraped on its
+// own.
+func scrapeInputs(cmd *cobra.Command, args []string) ([]string, bool, error) {
+	inputFile, _ := cmd.Flags().GetString("input-file")
+	switch {
+	case inputFile != "":
+		urls := slices.Clone(args)
+		listed, err := readInputFile(cmd.InOrStdin(), inputFile)
+		if err != nil {
+			return nil, false, err
+		}
+		return append(urls, listed...), true, nil
+	case len(args) > 1:
+		return args, true, nil
+	case len(args) == 0 && piped(cmd.InOrStdin()):
+		urls, err := readURLs(cmd.InOrStdin())
+		if err != nil {
+			return nil, false, fmt.Errorf("error reading input: %w", err)
+		}
+		if len(urls) == 0 {
+			return nil, false, fmt.Errorf("no URLs on stdin")
+		}
+		return urls, true, nil
+	}
+
+	url, err := getURLFromInput(args)
+	if err != nil {
+		return nil, false, err
+	}
+	return []string{url}, false, nil
+}
+
+// readInputFile reads the URLs listed in path, or stdin for "-"
+func readInputFile(stdin io.Reader, path string) ([]string, error) {
+	if path == "-" {
+		return readURLs(stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	urls, err := readURLs(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input file: %w", err)
 	}
+	return urls, nil
+}
+
+// readURLs reads one URL per line, skipping blank lines and # comments
+func readURLs(r io.Reader) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	return urls, scanner.Err()
+}
+
+// piped reports whether r is redirected input rather than a terminal
+func piped(r io.Reader) bool {
+	file, ok := r.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+// scrapeURL fetches url and scrapes the response, recording its headers and
+// final URL on the result. Full scrapes run with scraperInstance, as for
+// scrapeMetadata.
+func scrapeURL(ctx context.Context, client *fetcher.Fetcher, url string, previewOnly bool, scraperInstance *scraper.Scraper, options scraper.ScrapeOptions) (*metadata.Metadata, error) {
+	resp, err := fetchWebpage(ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
 
-	displayResults(metadata)
-	return nil
+	return scrapeResponse(ctx, resp, previewOnly, scraperInstance, options)
 }
 
-func printField(name string, value *string) {
+// scrapeResponse scrapes a fetched page as scrapeURL does, leaving resp for
+// the caller to close
+func scrapeResponse(ctx context.Context, resp *http.Response, previewOnly bool, scraperInstance *scraper.Scraper, options scraper.ScrapeOptions) (*metadata.Metadata, error) {
+	// Archived pages keep their original links, so resolve against the
+	// original URL rather than the archive's
+	var baseURL *neturl.URL
+	if resp.Request != nil {
+		baseURL = resp.Request.URL
+	}
+	snapshot, archived := fetcher.SnapshotOf(resp)
+	if archived {
+		if original, err := neturl.Parse(snapshot.Original); err == nil {
+			baseURL = original
+		}
+	}
+
+	var (
+		result *metadata.Metadata
+		err    error
+	)
+	if previewOnly {
+		result, err = scrapePreview(resp, options)
+		if err != nil {
+			return nil, err
+		}
+
+		// The preview scrape has no page URL, so icons are resolved here
+		result.BaseURL = baseURL
+		for _, icon := range result.Icons {
+			icon.Href = result.ResolveURL(icon.Href)
+		}
+	} else {
+		doc, err := parseHTML(resp)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = scrapeMetadata(ctx, doc, &metadata.Document{URL: baseURL}, scraperInstance, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Headers = resp.Header
+	result.BaseURL = baseURL
+	if chain := fetcher.RedirectChain(resp); len(chain) > 1 {
+		result.Redirects = chain[:len(chain)-1]
+	}
+	if archived {
+		result.Archive = &metadata.Archive{URL: snapshot.URL, Timestamp: snapshot.Timestamp}
+		result.Redirects = nil
+	}
+	return result, nil
+}
+
+func printField(w io.Writer, name string, value *string) {
 	bold := color.New(color.Bold)
 	if value != nil {
-		_, _ = bold.Printf("%s: ", name)
-		fmt.Println(*value)
+		_, _ = bold.Fprintf(w, "%s: ", name)
+		_, _ = fmt.Fprintln(w, *value)
 	} else {
-		_, _ = bold.Printf("%s: ", name)
-		fmt.Println("Not found")
+		_, _ = bold.Fprintf(w, "%s: ", name)
+		_, _ = fmt.Fprintln(w, "Not found")
 	}
 }
 
-func printProviderData(title string, data map[string][]string) {
+func printProviderData(w io.Writer, title string, data map[string][]string) {
 	if len(data) > 0 {
-		_, _ = color.New(color.Bold).Printf("\n%s:\n", title)
-		for key, values := range data {
-			fmt.Printf("  %s: %s\n", key, strings.Join(values, ", "))
+		_, _ = color.New(color.Bold).Fprintf(w, "\n%s:\n", title)
+		for _, key := range sortedKeys(data) {
+			_, _ = fmt.Fprintf(w, "  %s: %s\n", key, strings.Join(data[key], ", "))
 		}
 	}
 }
 
+// sortedKeys returns the keys of provider data in a stable order so repeated
+// runs print byte-identical output
+func sortedKeys(data map[string][]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func init() {
 	rootCmd.AddCommand(scrapeCmd)
 
@@ -176,4 +729,26 @@ func init() {
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// scrapeCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	scrapeCmd.Flags().String("format", "text", "Output format: text, json or yaml; batches print one JSON object or YAML document per URL")
+	scrapeCmd.Flags().String("input-file", "", "Scrape the URLs listed in this file, one per line (- for stdin)")
+	scrapeCmd.Flags().Int("concurrency", 1, "Scrape up to this many batch URLs at once; results keep the input order")
+	scrapeCmd.Flags().Bool("dedupe", false, "In batches, report a URL whose canonical URL matches an earlier one's as its duplicate instead of repeating the metadata")
+	scrapeCmd.Flags().StringP("output", "o", "", "Write the results to this file, creating its directories (- for stdout)")
+	scrapeCmd.Flags().StringSlice("providers", nil, "Only run these providers, resolving values in the listed order: openGraph, twitter, meta, other, citation, news, appLinks, microformats, jsonLd")
+	scrapeCmd.Flags().String("plugin-dir", "", "Scrape with the provider plugins (.so files) in this directory instead of the built-in providers (default $GLYPTO_PLUGIN_DIR)")
+	scrapeCmd.Flags().Bool("preview-only", false, "Only read og:, twitter:, title and icon tags from the head (fastest)")
+	scrapeCmd.Flags().Bool("respect-robots", false, "Omit images when the page opts out of image previews via robots directives")
+	scrapeCmd.Flags().Bool("probe-images", false, "Fetch each image to confirm it exists and read its type and size, dropping broken and tiny ones")
+	scrapeCmd.Flags().Int("min-image-size", 32, "With --probe-images, drop images narrower or shorter than this many pixels")
+	scrapeCmd.Flags().Bool("render", false, "Load the page in headless Chrome so script-injected tags are seen (needs a build with -tags chromedp)")
+	scrapeCmd.Flags().Int("render-below", 0, "Scrape a page again in headless Chrome when a plain fetch finds fewer than this many fields (0 to never; needs a build with -tags chromedp)")
+	scrapeCmd.MarkFlagsMutuallyExclusive("render", "render-below")
+	scrapeCmd.MarkFlagsMutuallyExclusive("providers", "plugin-dir", "preview-only")
+	scrapeCmd.Flags().Bool("wayback", false, "Scrape the latest Internet Archive snapshot when the page is gone (404/410) or times out")
+	scrapeCmd.Flags().Duration("timeout", 30*time.Second, "Give up on fetching and scraping after this long (0 for no limit)")
+	addFetchFlags(scrapeCmd)
+	scrapeCmd.Flags().Lookup("max-body-size").Usage += "; --preview-only stops after the head"
+	scrapeCmd.Flags().Lookup("rate-limit").Usage = "Send at most this many requests per second to each host, across all batch workers (0 for no limit)"
+	scrapeCmd.Flags().Int("max-value-length", 0, "Truncate each scraped value to this many bytes (0 for no limit)")
+	scrapeCmd.Flags().Int("follow-refresh", 0, "Follow up to this many <meta http-equiv=\"refresh\"> redirects")
 }
@@ -2,12 +2,25 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
 	"golang.org/x/net/html"
 )
 
@@ -76,7 +89,7 @@ func TestFetchWebpage(t *testing.T) {
 	defer server.Close()
 
 	// Test successful fetch
-	resp, err := fetchWebpage(server.URL)
+	resp, err := fetchWebpage(context.Background(), &fetcher.Fetcher{}, server.URL)
 	if err != nil {
 		t.Errorf("fetchWebpage() failed: %v", err)
 	}
@@ -94,7 +107,7 @@ func TestFetchWebpage_HTTPError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	resp, err := fetchWebpage(server.URL)
+	resp, err := fetchWebpage(context.Background(), &fetcher.Fetcher{}, server.URL)
 
 	if err == nil {
 		if resp != nil {
@@ -108,8 +121,26 @@ func TestFetchWebpage_HTTPError(t *testing.T) {
 	}
 }
 
+func TestFetchWebpage_BotBlocked(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "cloudflare")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<html><head><title>Just a moment...</title></head></html>"))
+	}))
+	defer server.Close()
+
+	resp, err := fetchWebpage(context.Background(), &fetcher.Fetcher{}, server.URL)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	if !errors.Is(err, fetcher.ErrBotBlocked) {
+		t.Errorf("Expected ErrBotBlocked, got: %v", err)
+	}
+}
+
 func TestFetchWebpage_InvalidURL(t *testing.T) {
-	resp, err := fetchWebpage("invalid-url")
+	resp, err := fetchWebpage(context.Background(), &fetcher.Fetcher{}, "invalid-url")
 
 	if err == nil {
 		if resp != nil {
@@ -176,6 +207,986 @@ func TestParseHTML_InvalidHTML(t *testing.T) {
 	}
 }
 
+func TestParseHTML_TranscodesDeclaredCharset(t *testing.T) {
+	// "Привет" encoded as windows-1251
+	title := []byte{0xCF, 0xF0, 0xE8, 0xE2, 0xE5, 0xF2}
+	body := append([]byte(`<html><head><meta charset="windows-1251"><title>`), title...)
+	body = append(body, []byte("</title></head></html>")...)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Without a Content-Type, net/http would sniff one claiming UTF-8
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to get test response: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	doc, err := parseHTML(resp)
+	if err != nil {
+		t.Fatalf("parseHTML() failed: %v", err)
+	}
+
+	result, err := scrapeMetadata(context.Background(), doc, nil, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		t.Fatalf("scrapeMetadata() failed: %v", err)
+	}
+
+	if result.Title() == nil || *result.Title() != "Привет" {
+		t.Errorf("Title() = %v, want %q", result.Title(), "Привет")
+	}
+}
+
+func TestParseHTML_ContentTypeCharset(t *testing.T) {
+	// "Привет" encoded as windows-1251
+	title := []byte{0xCF, 0xF0, 0xE8, 0xE2, 0xE5, 0xF2}
+
+	tests := []struct {
+		name        string
+		contentType string
+		head        string
+	}{
+		{name: "header only", contentType: "text/html; charset=windows-1251"},
+		{name: "header overrides meta", contentType: "text/html; charset=windows-1251", head: `<meta charset="iso-8859-2">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := append([]byte(`<html><head>`+tt.head+`<title>`), title...)
+			body = append(body, []byte("</title></head></html>")...)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			resp, err := http.Get(server.URL)
+			if err != nil {
+				t.Fatalf("Failed to get test response: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			doc, err := parseHTML(resp)
+			if err != nil {
+				t.Fatalf("parseHTML() failed: %v", err)
+			}
+
+			result, err := scrapeMetadata(context.Background(), doc, nil, nil, scraper.ScrapeOptions{})
+			if err != nil {
+				t.Fatalf("scrapeMetadata() failed: %v", err)
+			}
+
+			if result.Title() == nil || *result.Title() != "Привет" {
+				t.Errorf("Title() = %v, want %q", result.Title(), "Привет")
+			}
+		})
+	}
+}
+
+func TestTranscodeToUTF8(t *testing.T) {
+	tests := []struct {
+		name     string
+		label    string
+		expectOK bool
+	}{
+		{name: "no declaration", label: "", expectOK: false},
+		{name: "utf-8 declaration", label: "UTF-8", expectOK: false},
+		{name: "unknown charset", label: "not-a-charset", expectOK: false},
+		{name: "shift_jis declaration", label: "Shift_JIS", expectOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := transcodeToUTF8([]byte("<html></html>"), tt.label)
+			if ok != tt.expectOK {
+				t.Errorf("transcodeToUTF8() ok = %v, want %v", ok, tt.expectOK)
+			}
+		})
+	}
+}
+
+func TestScrapePreview(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Preview Title"></head></html>`))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to get test response: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result, err := scrapePreview(resp, scraper.ScrapeOptions{})
+	if err != nil {
+		t.Fatalf("scrapePreview() failed: %v", err)
+	}
+
+	if title := result.Title(); title == nil || *title != "Preview Title" {
+		t.Errorf("Title() = %v, want %q", title, "Preview Title")
+	}
+}
+
+func TestScrapeURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(`<html><head><meta http-equiv="refresh" content="0; url=/landing"></head></html>`))
+	}))
+	defer server.Close()
+
+	for _, previewOnly := range []bool{false, true} {
+		result, err := scrapeURL(context.Background(), &fetcher.Fetcher{}, server.URL+"/start", previewOnly, nil, scraper.ScrapeOptions{})
+		if err != nil {
+			t.Fatalf("scrapeURL() failed: %v", err)
+		}
+
+		if target := result.RefreshURL(); target == nil || *target != server.URL+"/landing" {
+			t.Errorf("RefreshURL() = %v, want %q", target, server.URL+"/landing")
+		}
+
+		if result.Headers.Get("Content-Type") == "" {
+			t.Error("Expected response headers on the result")
+		}
+	}
+}
+
+func TestScrapeURL_Redirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		_, _ = w.Write([]byte(`<title>New</title>`))
+	}))
+	defer server.Close()
+
+	result, err := scrapeURL(context.Background(), &fetcher.Fetcher{}, server.URL+"/old", false, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		t.Fatalf("scrapeURL() failed: %v", err)
+	}
+
+	if finalURL := result.FinalURL(); finalURL == nil || *finalURL != server.URL+"/new" {
+		t.Errorf("FinalURL() = %v, want %q", finalURL, server.URL+"/new")
+	}
+	if len(result.Redirects) != 1 || result.Redirects[0] != server.URL+"/old" {
+		t.Errorf("Redirects = %v, want [%s/old]", result.Redirects, server.URL)
+	}
+
+	if _, err := scrapeURL(context.Background(), &fetcher.Fetcher{NoFollow: true}, server.URL+"/old", false, nil, scraper.ScrapeOptions{}); !errors.Is(err, fetcher.ErrRedirectNotFollowed) {
+		t.Errorf("scrapeURL() with NoFollow error = %v, want %v", err, fetcher.ErrRedirectNotFollowed)
+	}
+}
+
+func TestRunScrape_FollowRefresh(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxHops  string
+		expected []string
+	}{
+		{name: "disabled", maxHops: "0", expected: []string{"/start"}},
+		{name: "hop limit", maxHops: "1", expected: []string{"/start", "/middle"}},
+		{name: "stops at self refresh", maxHops: "5", expected: []string{"/start", "/middle", "/end"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = append(requested, r.URL.Path)
+				next := map[string]string{"/start": "/middle", "/middle": "/end", "/end": "/end"}[r.URL.Path]
+				_, _ = w.Write([]byte(`<html><head><meta http-equiv="refresh" content="0; url=` + next + `"></head></html>`))
+			}))
+			defer server.Close()
+
+			if err := scrapeCmd.Flags().Set("follow-refresh", tt.maxHops); err != nil {
+				t.Fatalf("Failed to set flag: %v", err)
+			}
+			defer func() { _ = scrapeCmd.Flags().Set("follow-refresh", "0") }()
+
+			if err := runScrape(scrapeCmd, []string{server.URL + "/start"}); err != nil {
+				t.Fatalf("runScrape() failed: %v", err)
+			}
+
+			if strings.Join(requested, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("requested = %v, want %v", requested, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunScrape_CacheDir(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_, _ = w.Write([]byte(`<title>Cached</title>`))
+	}))
+	defer server.Close()
+
+	if err := scrapeCmd.Flags().Set("cache-dir", t.TempDir()); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("cache-dir", "") }()
+
+	for range 2 {
+		if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+			t.Fatalf("runScrape() failed: %v", err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestRunScrape_CookieJar(t *testing.T) {
+	redirects := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/consent" {
+			http.SetCookie(w, &http.Cookie{Name: "consent", Value: "yes", Path: "/", MaxAge: 3600})
+			http.Redirect(w, r, "/", http.StatusFound)
+			return
+		}
+		if _, err := r.Cookie("consent"); err != nil {
+			redirects++
+			http.Redirect(w, r, "/consent", http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte(`<title>Consented</title>`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	if err := scrapeCmd.Flags().Set("cookie-jar", path); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("cookie-jar", "") }()
+
+	for range 2 {
+		if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+			t.Fatalf("runScrape() failed: %v", err)
+		}
+	}
+
+	if redirects != 1 {
+		t.Errorf("consent redirects = %d, want 1", redirects)
+	}
+}
+
+func TestRunScrape_MaxBodySize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><title>Large</title></head><body>` + strings.Repeat("x", 4096) + `</body></html>`))
+	}))
+	defer server.Close()
+
+	if err := scrapeCmd.Flags().Set("max-body-size", "1024"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("max-body-size", scrapeCmd.Flags().Lookup("max-body-size").DefValue) }()
+
+	if err := runScrape(scrapeCmd, []string{server.URL}); !errors.Is(err, fetcher.ErrBodyTooLarge) {
+		t.Errorf("runScrape() error = %v, want %v", err, fetcher.ErrBodyTooLarge)
+	}
+
+	if err := scrapeCmd.Flags().Set("preview-only", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("preview-only", "false") }()
+
+	if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+		t.Errorf("runScrape() with --preview-only failed: %v", err)
+	}
+}
+
+func TestRunScrape_BlockPrivate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<title>Internal</title>`))
+	}))
+	defer server.Close()
+
+	if err := scrapeCmd.Flags().Set("block-private", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("block-private", "false") }()
+
+	if err := runScrape(scrapeCmd, []string{server.URL}); !errors.Is(err, fetcher.ErrBlockedAddress) {
+		t.Errorf("runScrape() error = %v, want %v", err, fetcher.ErrBlockedAddress)
+	}
+}
+
+func TestRunScrape_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<title>Intranet</title>`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatalf("Failed to write CA bundle: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		flag        string
+		value       string
+		expectError bool
+	}{
+		{name: "untrusted certificate", expectError: true},
+		{name: "private CA", flag: "ca-cert", value: caFile},
+		{name: "insecure", flag: "insecure", value: "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.flag != "" {
+				if err := scrapeCmd.Flags().Set(tt.flag, tt.value); err != nil {
+					t.Fatalf("Failed to set flag: %v", err)
+				}
+				defer func() { _ = scrapeCmd.Flags().Set(tt.flag, scrapeCmd.Flags().Lookup(tt.flag).DefValue) }()
+			}
+
+			if err := runScrape(scrapeCmd, []string{server.URL}); (err != nil) != tt.expectError {
+				t.Errorf("runScrape() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+func TestRunScrape_RenderUnavailable(t *testing.T) {
+	if _, err := fetcher.NewRenderer(); err == nil {
+		t.Skip("built with a rendering backend")
+	}
+
+	for flag, value := range map[string]string{"render": "true", "render-below": "3"} {
+		t.Run(flag, func(t *testing.T) {
+			if err := scrapeCmd.Flags().Set(flag, value); err != nil {
+				t.Fatalf("Failed to set flag: %v", err)
+			}
+			// Unset it too, or the render flag group fails later Execute calls
+			defer func() {
+				lookup := scrapeCmd.Flags().Lookup(flag)
+				_ = lookup.Value.Set(lookup.DefValue)
+				lookup.Changed = false
+			}()
+
+			if err := runScrape(scrapeCmd, []string{"https://example.com"}); !errors.Is(err, fetcher.ErrRendererUnavailable) {
+				t.Errorf("runScrape() error = %v, want %v", err, fetcher.ErrRendererUnavailable)
+			}
+		})
+	}
+}
+
+func TestRunScrape_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<title>Staging</title>`))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	if err := scrapeCmd.Flags().Set("resolve", "www.example.test:"+serverURL.Port()+":"+serverURL.Hostname()); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() {
+		_ = scrapeCmd.Flags().Lookup("resolve").Value.(interface{ Replace([]string) error }).Replace(nil)
+	}()
+
+	if err := runScrape(scrapeCmd, []string{"http://www.example.test:" + serverURL.Port() + "/"}); err != nil {
+		t.Errorf("runScrape() failed: %v", err)
+	}
+}
+
+func TestRunScrape_ProbeImages(t *testing.T) {
+	var probed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`<head><meta property="og:image" content="/missing.png"></head>`))
+		default:
+			probed = append(probed, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	if err := scrapeCmd.Flags().Set("probe-images", "true"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("probe-images", "false") }()
+
+	if err := runScrape(scrapeCmd, []string{server.URL + "/"}); err != nil {
+		t.Fatalf("runScrape() failed: %v", err)
+	}
+	if len(probed) != 1 || probed[0] != "/missing.png" {
+		t.Errorf("Probed %v, want [/missing.png]", probed)
+	}
+}
+
+func TestRunScrape_Format(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<title>Formatted</title>`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	scrapeCmd.SetOut(&buf)
+	defer scrapeCmd.SetOut(nil)
+
+	if err := scrapeCmd.Flags().Set("format", "json"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("format", "text") }()
+
+	if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runScrape() failed: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not JSON: %v\n%s", err, buf.String())
+	}
+	if decoded["title"] != "Formatted" {
+		t.Errorf("title = %v, want Formatted", decoded["title"])
+	}
+
+	path := filepath.Join(t.TempDir(), "out", "result.json")
+	if err := scrapeCmd.Flags().Set("output", path); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("output", "") }()
+
+	buf.Reset()
+	if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runScrape() with --output failed: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || !json.Valid(data) {
+		t.Errorf("Output file = %s (%v), want JSON", data, err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("stdout = %q, want the results only in the file", buf.String())
+	}
+
+	_ = scrapeCmd.Flags().Set("format", "xml")
+	if err := runScrape(scrapeCmd, []string{server.URL}); err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("runScrape() error = %v, want an unsupported format error", err)
+	}
+}
+
+func TestRunScrape_FormatResolvesLinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<head><link rel="alternate" type="application/rss+xml" href="/feed.xml"><link rel="icon" href="icon.png"></head>`))
+	}))
+	defer server.Close()
+
+	if err := scrapeCmd.Flags().Set("format", "json"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("format", "text") }()
+
+	for _, previewOnly := range []string{"false", "true"} {
+		var buf bytes.Buffer
+		scrapeCmd.SetOut(&buf)
+		defer scrapeCmd.SetOut(nil)
+		if err := scrapeCmd.Flags().Set("preview-only", previewOnly); err != nil {
+			t.Fatalf("Failed to set flag: %v", err)
+		}
+		defer func() { _ = scrapeCmd.Flags().Set("preview-only", "false") }()
+
+		if err := runScrape(scrapeCmd, []string{server.URL + "/blog/"}); err != nil {
+			t.Fatalf("runScrape() with --preview-only=%s failed: %v", previewOnly, err)
+		}
+
+		var decoded struct {
+			Feeds []struct{ Href string } `json:"feeds"`
+			Icons []struct{ Href string } `json:"icons"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("Output is not JSON: %v\n%s", err, buf.String())
+		}
+
+		// The preview scrape does not read feeds
+		if previewOnly == "false" && (len(decoded.Feeds) != 1 || decoded.Feeds[0].Href != server.URL+"/feed.xml") {
+			t.Errorf("feeds = %+v, want %s/feed.xml", decoded.Feeds, server.URL)
+		}
+		if len(decoded.Icons) != 1 || decoded.Icons[0].Href != server.URL+"/blog/icon.png" {
+			t.Errorf("icons with --preview-only=%s = %+v, want %s/blog/icon.png", previewOnly, decoded.Icons, server.URL)
+		}
+	}
+}
+
+func TestRunScrape_Batch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("<title>" + strings.TrimPrefix(r.URL.Path, "/") + "</title>"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	scrapeCmd.SetOut(&buf)
+	defer scrapeCmd.SetOut(nil)
+	scrapeCmd.SetIn(strings.NewReader("# listed\n" + server.URL + "/b\n\n" + server.URL + "/missing\n"))
+	defer scrapeCmd.SetIn(nil)
+
+	if err := scrapeCmd.Flags().Set("format", "json"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("format", "text") }()
+	if err := scrapeCmd.Flags().Set("input-file", "-"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("input-file", "") }()
+
+	err := runScrape(scrapeCmd, []string{server.URL + "/a"})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3 URLs failed") {
+		t.Errorf("runScrape() error = %v, want 1 of 3 URLs failed", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Output = %q, want one line per URL", buf.String())
+	}
+
+	expected := []struct{ input, title, error string }{
+		{input: server.URL + "/a", title: "a"},
+		{input: server.URL + "/b", title: "b"},
+		{input: server.URL + "/missing", error: "HTTP error! status: 404"},
+	}
+	for i, line := range lines {
+		var decoded struct {
+			Input    string         `json:"input"`
+			Metadata map[string]any `json:"metadata"`
+			Error    string         `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("Line %d is not JSON: %v", i, err)
+		}
+
+		want := expected[i]
+		if decoded.Input != want.input || decoded.Error != want.error {
+			t.Errorf("Line %d = %+v, want %+v", i, decoded, want)
+		}
+		if want.title != "" && decoded.Metadata["title"] != want.title {
+			t.Errorf("Line %d title = %v, want %v", i, decoded.Metadata["title"], want.title)
+		}
+	}
+}
+
+func TestRunScrape_FailedURLsOmitUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("<title>Page</title>"))
+	}))
+	defer server.Close()
+
+	// Results go to a file; cobra prints usage with OutOrStderr, which is
+	// stderr unless an output writer is set
+	var stderr bytes.Buffer
+	rootCmd.SetOut(&stderr)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs([]string{"scrape", "--quiet", "-o", filepath.Join(t.TempDir(), "out.txt"), server.URL + "/a", server.URL + "/missing"})
+	defer rootCmd.SetArgs(nil)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+	defer resetOutputFlags()
+	defer func() { _ = scrapeCmd.Flags().Set("output", "") }()
+
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "1 of 2 URLs failed") {
+		t.Fatalf("Execute() error = %v, want 1 of 2 URLs failed", err)
+	}
+	if strings.Contains(stderr.String(), "Usage:") {
+		t.Errorf("Stderr = %q, want no usage for failed URLs", stderr.String())
+	}
+}
+
+func TestPageScraper_ScrapeBatch(t *testing.T) {
+	const concurrency = 3
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	var arrived atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		// Hold the first pages until the pool is full, so they overlap
+		arrived.Add(1)
+		for arrived.Load() < concurrency {
+			time.Sleep(time.Millisecond)
+		}
+
+		// Finish later URLs first to prove results keep the input order
+		if r.URL.Path == "/0" {
+			time.Sleep(20 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("<title>" + r.URL.Path + "</title>"))
+	}))
+	defer server.Close()
+
+	var urls []string
+	for i := range 6 {
+		urls = append(urls, fmt.Sprintf("%s/%d", server.URL, i))
+	}
+
+	page := &pageScraper{client: &fetcher.Fetcher{}, timeout: 5 * time.Second}
+	var written []string
+	failed, err := page.scrapeBatch(context.Background(), urls, concurrency, func(url string, result *metadata.Metadata, err error) error {
+		if err != nil {
+			t.Errorf("scrape(%s) returned error: %v", url, err)
+			return nil
+		}
+		written = append(written, url)
+		return nil
+	})
+	if err != nil || failed != 0 {
+		t.Fatalf("scrapeBatch() = %d, %v, want no failures", failed, err)
+	}
+
+	if strings.Join(written, ",") != strings.Join(urls, ",") {
+		t.Errorf("scrapeBatch() wrote %v, want the input order %v", written, urls)
+	}
+	if peak != concurrency {
+		t.Errorf("peak concurrent requests = %d, want %d", peak, concurrency)
+	}
+}
+
+func TestPageScraper_ScrapeBatch_SharedScraper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Page title</title>"))
+	}))
+	defer server.Close()
+
+	shared, err := scraper.CreateScraper()
+	if err != nil {
+		t.Fatalf("CreateScraper() failed: %v", err)
+	}
+	page := &pageScraper{client: &fetcher.Fetcher{}, scraper: shared.WithOptions(scraper.ScrapeOptions{MaxValueLength: 4})}
+
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c"}
+	_, err = page.scrapeBatch(context.Background(), urls, 2, func(url string, result *metadata.Metadata, err error) error {
+		if err != nil {
+			t.Errorf("scrape(%s) returned error: %v", url, err)
+			return nil
+		}
+		if title := result.Title(); title == nil || *title != "Page" {
+			t.Errorf("scrape(%s) Title() = %v, want the shared scraper's truncated %q", url, title, "Page")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("scrapeBatch() returned error: %v", err)
+	}
+}
+
+func TestPageScraper_ScrapeBatch_WriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Page</title>"))
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c"}
+	errFull := errors.New("disk full")
+	page := &pageScraper{client: &fetcher.Fetcher{}}
+
+	calls := 0
+	_, err := page.scrapeBatch(context.Background(), urls, 2, func(string, *metadata.Metadata, error) error {
+		calls++
+		return errFull
+	})
+	if !errors.Is(err, errFull) || calls != 1 {
+		t.Errorf("scrapeBatch() error = %v after %d writes, want %v after 1", err, calls, errFull)
+	}
+}
+
+// scriptedRenderer renders every page as the same HTML, counting renders
+type scriptedRenderer struct {
+	html    string
+	err     error
+	renders int
+}
+
+func (r *scriptedRenderer) Render(ctx context.Context, url string) (*fetcher.RenderedPage, error) {
+	r.renders++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &fetcher.RenderedPage{URL: url, HTML: r.html}, nil
+}
+
+func TestPageScraper_RenderBelow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app" {
+			_, _ = w.Write([]byte(`<html><head><script src="app.js"></script></head><body></body></html>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<html><head><title>Static</title><meta name="description" content="Served"></head></html>`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		path          string
+		renderErr     error
+		expectedTitle string
+		expectRender  bool
+	}{
+		{name: "sparse page is rendered", path: "/app", expectedTitle: "Rendered", expectRender: true},
+		{name: "rich page is not", path: "/static", expectedTitle: "Static"},
+		{name: "failed render keeps the plain result", path: "/app", renderErr: errors.New("browser crashed"), expectRender: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := &scriptedRenderer{html: `<html><head><title>Rendered</title></head></html>`, err: tt.renderErr}
+			page := &pageScraper{
+				client:      &fetcher.Fetcher{},
+				renderer:    &fetcher.Fetcher{Renderer: renderer},
+				renderBelow: 2,
+			}
+
+			result, err := page.scrape(context.Background(), server.URL+tt.path)
+			if err != nil {
+				t.Fatalf("scrape() returned error: %v", err)
+			}
+			if (renderer.renders > 0) != tt.expectRender {
+				t.Errorf("rendered %d times, expectRender %v", renderer.renders, tt.expectRender)
+			}
+
+			title := ""
+			if result.Title() != nil {
+				title = *result.Title()
+			}
+			if title != tt.expectedTitle {
+				t.Errorf("Title() = %q, want %q", title, tt.expectedTitle)
+			}
+		})
+	}
+}
+
+func TestBatchAliases(t *testing.T) {
+	page := func(canonical string) *metadata.Metadata {
+		html := "<title>Page</title>"
+		if canonical != "" {
+			html += `<link rel="canonical" href="` + canonical + `">`
+		}
+		result, err := scraper.ScrapeString(html)
+		if err != nil {
+			t.Fatalf("ScrapeString() returned error: %v", err)
+		}
+		return result
+	}
+
+	aliases := make(batchAliases)
+	steps := []struct {
+		input    string
+		result   *metadata.Metadata
+		expected string
+	}{
+		{input: "https://example.com/a?utm_source=x", result: page("https://example.com/a"), expected: ""},
+		{input: "https://example.com/a?utm_source=y", result: page("https://example.com/a"), expected: "https://example.com/a?utm_source=x"},
+		{input: "https://example.com/b", result: page("https://example.com/b"), expected: ""},
+		{input: "https://example.com/c", result: page(""), expected: ""},
+		{input: "https://example.com/c?ref=1", result: page(""), expected: ""},
+		{input: "https://example.com/missing", result: nil, expected: ""},
+	}
+	for _, step := range steps {
+		if got := aliases.of(step.input, step.result); got != step.expected {
+			t.Errorf("of(%s) = %q, want %q", step.input, got, step.expected)
+		}
+	}
+
+	var disabled batchAliases
+	if got := disabled.of(steps[1].input, steps[1].result); got != "" {
+		t.Errorf("nil batchAliases of() = %q, want none", got)
+	}
+}
+
+func TestRunScrape_Dedupe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<title>Post</title><link rel="canonical" href="/post">`))
+	}))
+	defer server.Close()
+
+	output := filepath.Join(t.TempDir(), "out.jsonl")
+	for name, value := range map[string]string{"dedupe": "true", "format": "json", "output": output} {
+		if err := scrapeCmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("Failed to set --%s: %v", name, err)
+		}
+	}
+	defer func() {
+		_ = scrapeCmd.Flags().Set("dedupe", "false")
+		_ = scrapeCmd.Flags().Set("format", "text")
+		_ = scrapeCmd.Flags().Set("output", "")
+	}()
+
+	first, second := server.URL+"/post?utm_source=a", server.URL+"/post?utm_source=b"
+	if err := runScrape(scrapeCmd, []string{first, second}); err != nil {
+		t.Fatalf("runScrape() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"metadata":`) {
+		t.Fatalf("output = %q, want the first page's metadata and an alias", data)
+	}
+	if expected := `{"input":"` + second + `","duplicateOf":"` + first + `"}`; lines[1] != expected {
+		t.Errorf("alias = %s, want %s", lines[1], expected)
+	}
+}
+
+func TestScrapeInputs(t *testing.T) {
+	listPath := filepath.Join(t.TempDir(), "urls.txt")
+	if err := os.WriteFile(listPath, []byte("https://b.example\n  # comment\n\nhttps://c.example\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write list: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		inputFile     string
+		stdin         string
+		expected      []string
+		expectedBatch bool
+		expectError   bool
+	}{
+		{name: "single argument", args: []string{"https://a.example"}, expected: []string{"https://a.example"}},
+		{name: "several arguments", args: []string{"https://a.example", "https://b.example"}, expected: []string{"https://a.example", "https://b.example"}, expectedBatch: true},
+		{name: "input file", args: []string{"https://a.example"}, inputFile: listPath, expected: []string{"https://a.example", "https://b.example", "https://c.example"}, expectedBatch: true},
+		{name: "piped stdin", stdin: "https://a.example\n", expected: []string{"https://a.example"}, expectedBatch: true},
+		{name: "empty stdin", stdin: "\n", expectError: true},
+		{name: "missing input file", inputFile: filepath.Join(t.TempDir(), "missing.txt"), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scrapeCmd.SetIn(strings.NewReader(tt.stdin))
+			defer scrapeCmd.SetIn(nil)
+			_ = scrapeCmd.Flags().Set("input-file", tt.inputFile)
+			defer func() { _ = scrapeCmd.Flags().Set("input-file", "") }()
+
+			urls, batch, err := scrapeInputs(scrapeCmd, tt.args)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("scrapeInputs() error = %v, expectError %v", err, tt.expectError)
+			}
+			if strings.Join(urls, ",") != strings.Join(tt.expected, ",") || batch != tt.expectedBatch {
+				t.Errorf("scrapeInputs() = %v, %v, want %v, %v", urls, batch, tt.expected, tt.expectedBatch)
+			}
+		})
+	}
+}
+
+func TestRunScrape_Providers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<head><meta property="og:title" content="OG Title"><meta name="twitter:title" content="Twitter Title"></head>`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	scrapeCmd.SetOut(&buf)
+	defer scrapeCmd.SetOut(nil)
+	_ = scrapeCmd.Flags().Set("format", "json")
+	defer func() { _ = scrapeCmd.Flags().Set("format", "text") }()
+
+	providersFlag := scrapeCmd.Flags().Lookup("providers").Value.(interface{ Replace([]string) error })
+	defer func() { _ = providersFlag.Replace(nil) }()
+
+	_ = providersFlag.Replace([]string{"twitter", "openGraph"})
+	if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runScrape() failed: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if decoded["title"] != "Twitter Title" {
+		t.Errorf("title = %v, want the first listed provider's", decoded["title"])
+	}
+
+	_ = providersFlag.Replace([]string{"opengraph"})
+	if err := runScrape(scrapeCmd, []string{server.URL}); err == nil || !strings.Contains(err.Error(), "available: openGraph") {
+		t.Errorf("runScrape() error = %v, want the unknown provider and the available names", err)
+	}
+}
+
+func TestRunScrape_PluginDir(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<head><meta property="og:title" content="OG Title"></head>`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	scrapeCmd.SetOut(&buf)
+	defer scrapeCmd.SetOut(nil)
+	defer func() { _ = scrapeCmd.Flags().Set("plugin-dir", "") }()
+
+	if err := scrapeCmd.Flags().Set("plugin-dir", t.TempDir()); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	if err := runScrape(scrapeCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runScrape() failed without plugins: %v", err)
+	}
+	if !strings.Contains(buf.String(), "OG Title") {
+		t.Errorf("Output = %q, want the default providers' title", buf.String())
+	}
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	_ = scrapeCmd.Flags().Set("plugin-dir", missing)
+	if err := runScrape(scrapeCmd, []string{server.URL}); err == nil {
+		t.Error("runScrape() expected an error for a missing plugin directory")
+	}
+
+	_ = scrapeCmd.Flags().Set("plugin-dir", "")
+	t.Setenv("GLYPTO_PLUGIN_DIR", missing)
+	if err := runScrape(scrapeCmd, []string{server.URL}); err == nil {
+		t.Error("runScrape() expected GLYPTO_PLUGIN_DIR to be used")
+	}
+}
+
+func TestRunScrape_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	if err := scrapeCmd.Flags().Set("timeout", "50ms"); err != nil {
+		t.Fatalf("Failed to set flag: %v", err)
+	}
+	defer func() { _ = scrapeCmd.Flags().Set("timeout", "30s") }()
+
+	if err := runScrape(scrapeCmd, []string{server.URL}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("runScrape() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
 func TestScrapeMetadata(t *testing.T) {
 	// Create a simple HTML document
 	doc := &html.Node{
@@ -198,7 +1209,7 @@ func TestScrapeMetadata(t *testing.T) {
 		},
 	}
 
-	result, err := scrapeMetadata(doc)
+	result, err := scrapeMetadata(context.Background(), doc, nil, nil, scraper.ScrapeOptions{})
 	if err != nil {
 		t.Errorf("scrapeMetadata() failed: %v", err)
 	}
@@ -208,16 +1219,31 @@ func TestScrapeMetadata(t *testing.T) {
 	}
 }
 
+func TestScrapeMetadata_MaxValueLength(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<title>A very long page title</title>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result, err := scrapeMetadata(context.Background(), doc, nil, nil, scraper.ScrapeOptions{MaxValueLength: 6})
+	if err != nil {
+		t.Fatalf("scrapeMetadata() failed: %v", err)
+	}
+
+	if title := result.Title(); title == nil || *title != "A very" {
+		t.Errorf("Title() = %v, want %q", title, "A very")
+	}
+}
+
 func TestDisplayResults(t *testing.T) {
-	// Create test metadata
 	testMetadata := &metadata.Metadata{}
 
-	// Capture output by redirecting stdout (this is a basic test)
-	// In a real scenario, you might want to use dependency injection
-	// to make the display function more testable
+	var buf bytes.Buffer
+	displayResults(&buf, testMetadata)
 
-	// This test mainly ensures the function doesn't panic
-	displayResults(testMetadata)
+	if !strings.Contains(buf.String(), "Title: Not found") {
+		t.Errorf("displayResults() = %q, want the missing title reported", buf.String())
+	}
 }
 
 func TestPrintField(t *testing.T) {
@@ -244,7 +1270,7 @@ func TestPrintField(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// This test mainly ensures the function doesn't panic
-			printField(tt.field, tt.value)
+			printField(old, tt.field, tt.value)
 
 			// Reset buffer
 			old.Reset()
@@ -281,14 +1307,34 @@ func TestPrintProviderData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// This test mainly ensures the function doesn't panic
-			printProviderData(tt.title, tt.data)
+			printProviderData(&bytes.Buffer{}, tt.title, tt.data)
 		})
 	}
 }
 
+func TestSortedKeys(t *testing.T) {
+	data := map[string][]string{
+		"title":       {"Test Title"},
+		"description": {"Test Description"},
+		"image":       {"https://example.com/image.jpg"},
+	}
+
+	expected := []string{"description", "image", "title"}
+	for i := 0; i < 10; i++ {
+		keys := sortedKeys(data)
+		if strings.Join(keys, ",") != strings.Join(expected, ",") {
+			t.Fatalf("sortedKeys() = %v, want %v", keys, expected)
+		}
+	}
+
+	if keys := sortedKeys(nil); len(keys) != 0 {
+		t.Errorf("sortedKeys(nil) = %v, want empty", keys)
+	}
+}
+
 func TestScrapeCmd(t *testing.T) {
-	if scrapeCmd.Use != "scrape [URL]" {
-		t.Errorf("Expected Use to be 'scrape [URL]', got '%s'", scrapeCmd.Use)
+	if scrapeCmd.Use != "scrape [URL...]" {
+		t.Errorf("Expected Use to be 'scrape [URL...]', got '%s'", scrapeCmd.Use)
 	}
 
 	if scrapeCmd.Short == "" {
@@ -302,6 +1348,74 @@ func TestScrapeCmd(t *testing.T) {
 	if scrapeCmd.RunE == nil {
 		t.Error("Expected RunE to be set")
 	}
+
+	if scrapeCmd.Flags().Lookup("preview-only") == nil {
+		t.Error("Expected --preview-only flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("respect-robots") == nil {
+		t.Error("Expected --respect-robots flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("input-file") == nil {
+		t.Error("Expected --input-file flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("cache-dir") == nil {
+		t.Error("Expected --cache-dir flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("cookie-jar") == nil {
+		t.Error("Expected --cookie-jar flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("max-body-size") == nil {
+		t.Error("Expected --max-body-size flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("max-redirects") == nil {
+		t.Error("Expected --max-redirects flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("no-follow") == nil {
+		t.Error("Expected --no-follow flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("block-private") == nil {
+		t.Error("Expected --block-private flag to be registered")
+	}
+
+	for _, name := range []string{"ca-cert", "cert", "key", "insecure"} {
+		if scrapeCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag to be registered", name)
+		}
+	}
+
+	if scrapeCmd.Flags().Lookup("render") == nil {
+		t.Error("Expected --render flag to be registered")
+	}
+
+	for _, name := range []string{"resolve", "ipv4", "ipv6"} {
+		if scrapeCmd.Flags().Lookup(name) == nil {
+			t.Errorf("Expected --%s flag to be registered", name)
+		}
+	}
+
+	if scrapeCmd.Flags().Lookup("wayback") == nil {
+		t.Error("Expected --wayback flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("timeout") == nil {
+		t.Error("Expected --timeout flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("max-value-length") == nil {
+		t.Error("Expected --max-value-length flag to be registered")
+	}
+
+	if scrapeCmd.Flags().Lookup("follow-refresh") == nil {
+		t.Error("Expected --follow-refresh flag to be registered")
+	}
 }
 
 // Helper function for tests
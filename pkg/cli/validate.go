@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// failOnLevels are the values accepted by --fail-on
+var failOnLevels = []string{"error", "warning", "never"}
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate [URL]",
+	Short: "Lint a webpage's social and SEO tags",
+	Long: `Check a webpage's Open Graph and Twitter Card tags against their specs,
+and its title, description, image and canonical URLs against common limits,
+printing the errors and warnings found.
+
+The command exits non-zero when the page has errors, or with --fail-on
+warning any issue at all, so it can gate a deploy.
+
+Examples:
+  glypto validate https://example.com
+  glypto validate --fail-on warning --format json https://example.com`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runValidate,
+}
+
+// validationResult is the json and yaml output of validate
+type validationResult struct {
+	URL    string           `json:"url"`
+	Valid  bool             `json:"valid"`
+	Issues []metadata.Issue `json:"issues"`
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unsupported format %q: use text, json or yaml", format)
+	}
+	failOn, _ := cmd.Flags().GetString("fail-on")
+	if !slices.Contains(failOnLevels, failOn) {
+		return fmt.Errorf("unsupported --fail-on %q: use error, warning or never", failOn)
+	}
+
+	url, err := getURLFromInput(args)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if timeout, _ := cmd.Flags().GetDuration("timeout"); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	client, done, err := newFetcher(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	result, err := scrapeURL(ctx, client, url, false, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		return err
+	}
+
+	report := result.Validate()
+	if err := writeReport(cmd.OutOrStdout(), url, report, format); err != nil {
+		return err
+	}
+
+	// Failing validation is a result, not a misuse of the command
+	cmd.SilenceUsage = true
+	return failure(report, failOn)
+}
+
+// writeReport writes report to w as colorized text, or as a
+// validationResult for json and yaml
+func writeReport(w io.Writer, url string, report *metadata.ValidationReport, format string) error {
+	entry := validationResult{URL: url, Valid: report.Valid(), Issues: report.Issues}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		return writeYAML(w, entry)
+	default:
+		displayReport(w, report)
+		return nil
+	}
+}
+
+// displayReport prints each issue, errors first, and a summary line
+func displayReport(w io.Writer, report *metadata.ValidationReport) {
+	if len(report.Issues) == 0 {
+		_, _ = color.New(color.FgGreen).Fprint(w, "\n✓ No issues found\n")
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	for _, issue := range report.Errors() {
+		_, _ = color.New(color.FgRed).Fprint(w, "✗ error   ")
+		_, _ = fmt.Fprintf(w, "%s: %s\n", issue.Field, issue.Message)
+	}
+	for _, issue := range report.Warnings() {
+		_, _ = color.New(color.FgYellow).Fprint(w, "! warning ")
+		_, _ = fmt.Fprintf(w, "%s: %s\n", issue.Field, issue.Message)
+	}
+
+	_, _ = color.New(color.Bold).Fprintf(w, "\n%d errors, %d warnings\n", len(report.Errors()), len(report.Warnings()))
+}
+
+// failure returns an error when report has issues at or above the failOn
+// severity
+func failure(report *metadata.ValidationReport, failOn string) error {
+	errorCount, warnings := len(report.Errors()), len(report.Warnings())
+	switch {
+	case failOn == "never":
+		return nil
+	case errorCount > 0:
+		return fmt.Errorf("validation failed with %d errors", errorCount)
+	case failOn == "warning" && warnings > 0:
+		return fmt.Errorf("validation failed with %d warnings", warnings)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().String("format", "text", "Output format: text, json or yaml")
+	validateCmd.Flags().String("fail-on", "error", "Exit non-zero on issues of this severity or worse: error, warning or never")
+	validateCmd.Flags().Duration("timeout", 30*time.Second, "Give up after this long (0 for no limit)")
+	addFetchFlags(validateCmd)
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+const validPage = `<head>
+<meta property="og:title" content="Title">
+<meta property="og:type" content="website">
+<meta property="og:image" content="https://example.com/image.png">
+<meta property="og:url" content="https://example.com/">
+<meta name="twitter:card" content="summary">
+</head>`
+
+func TestRunValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        string
+		failOn      string
+		expectError bool
+		expected    string
+	}{
+		{name: "valid page", page: validPage, failOn: "error", expected: "No issues found"},
+		{name: "missing og:image", page: strings.Replace(validPage, "og:image", "og:other", 1), failOn: "error", expectError: true, expected: "og:image: missing required Open Graph property og:image"},
+		{name: "errors ignored with never", page: strings.Replace(validPage, "og:image", "og:other", 1), failOn: "never", expected: "1 errors, 0 warnings"},
+		{name: "warnings pass by default", page: strings.Replace(validPage, `<meta name="twitter:card" content="summary">`, "", 1), failOn: "error", expected: "twitter:card: missing twitter:card"},
+		{name: "warnings fail with warning", page: strings.Replace(validPage, `<meta name="twitter:card" content="summary">`, "", 1), failOn: "warning", expectError: true, expected: "0 errors, 1 warnings"},
+	}
+
+	defer func() { _ = validateCmd.Flags().Set("fail-on", "error") }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.page))
+			}))
+			defer server.Close()
+
+			var buf bytes.Buffer
+			validateCmd.SetOut(&buf)
+			defer validateCmd.SetOut(nil)
+			_ = validateCmd.Flags().Set("fail-on", tt.failOn)
+
+			err := runValidate(validateCmd, []string{server.URL})
+			if (err != nil) != tt.expectError {
+				t.Errorf("runValidate() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Output = %q, want it to contain %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunValidate_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Replace(validPage, "</head>", `<link rel="canonical" href="/relative"></head>`, 1)))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	validateCmd.SetOut(&buf)
+	defer validateCmd.SetOut(nil)
+	_ = validateCmd.Flags().Set("format", "json")
+	defer func() { _ = validateCmd.Flags().Set("format", "text") }()
+
+	if err := runValidate(validateCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runValidate() failed: %v", err)
+	}
+
+	var decoded validationResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not JSON: %v", err)
+	}
+	if decoded.URL != server.URL || !decoded.Valid {
+		t.Errorf("Result = %+v, want a valid report for %v", decoded, server.URL)
+	}
+	if len(decoded.Issues) != 1 || decoded.Issues[0].Severity != metadata.SeverityWarning || decoded.Issues[0].Field != "canonical" {
+		t.Errorf("Issues = %+v, want one canonical warning", decoded.Issues)
+	}
+}
+
+func TestRunValidate_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flag  string
+		value string
+		reset string
+	}{
+		{name: "format", flag: "format", value: "xml", reset: "text"},
+		{name: "fail-on", flag: "fail-on", value: "info", reset: "error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = validateCmd.Flags().Set(tt.flag, tt.value)
+			defer func() { _ = validateCmd.Flags().Set(tt.flag, tt.reset) }()
+
+			if err := runValidate(validateCmd, []string{"https://example.com"}); err == nil {
+				t.Errorf("runValidate() expected an error for --%s %s", tt.flag, tt.value)
+			}
+		})
+	}
+}
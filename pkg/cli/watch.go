@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/alvincrespo/glypto-go/pkg/fetcher"
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch URL...",
+	Short: "Re-scrape webpages on an interval and report metadata changes",
+	Long: `Scrape each URL, then check it again every --interval, printing the
+fields and provider tags that changed since the previous check. Pages that
+send an ETag or Last-Modified header are re-fetched with a conditional
+request, so unchanged pages are not downloaded again.
+
+With --exec, the command is also run through sh for each change, with the
+URL in $GLYPTO_URL and the change (in the JSON schema of "glypto diff
+--format json") on its standard input. Failed checks and hooks are reported
+and watching continues until interrupted or --count checks have run.
+
+Examples:
+  glypto watch --interval 10m https://example.com
+  glypto watch --exec 'notify-send "OG tags changed on $GLYPTO_URL"' https://example.com`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runWatch,
+}
+
+// watchClock timestamps the changes watch reports
+var watchClock metadata.Clock = metadata.SystemClock
+
+// watchedPage is what a page looked like at its last check
+type watchedPage struct {
+	url        string
+	validators fetcher.Validators
+	last       *metadata.Metadata
+}
+
+// watchEvent is the json and yaml output for one change
+type watchEvent struct {
+	URL  string                 `json:"url"`
+	Time time.Time              `json:"time"`
+	Diff *metadata.MetadataDiff `json:"diff"`
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unsupported format %q: use text, json or yaml", format)
+	}
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", interval)
+	}
+	count, _ := cmd.Flags().GetInt("count")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	hook, _ := cmd.Flags().GetString("exec")
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	client, done, err := newFetcher(cmd)
+	if err != nil {
+		return err
+	}
+	defer done()
+	pages := make([]*watchedPage, len(args))
+	for i, url := range args {
+		pages[i] = &watchedPage{url: url}
+	}
+
+	w := cmd.OutOrStdout()
+	checkAll := func() error {
+		for _, page := range pages {
+			diff, err := page.check(ctx, client, timeout)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				notice("✗ %s: %v", page.url, err)
+				continue
+			}
+			if diff == nil {
+				continue
+			}
+
+			if err := writeChange(w, page.url, watchClock.Now(), diff, format); err != nil {
+				return err
+			}
+			if hook != "" {
+				if err := runHook(ctx, cmd.ErrOrStderr(), hook, page.url, diff); err != nil {
+					notice("✗ --exec for %s: %v", page.url, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := checkAll(); err != nil {
+		return err
+	}
+	notice("Watching %d URLs every %s", len(pages), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for checks := 1; count <= 0 || checks < count; checks++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if err := checkAll(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check fetches the page, conditionally once validators are known, and
+// returns how its metadata changed since the last check; nil when it did
+// not or this is the first check
+func (p *watchedPage) check(ctx context.Context, client *fetcher.Fetcher, timeout time.Duration) (*metadata.MetadataDiff, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	resp, err := client.FetchIfModified(ctx, p.url, p.validators)
+	if errors.Is(err, fetcher.ErrNotModified) {
+		debug("%s not modified", p.url)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	result, err := scrapeResponse(ctx, resp, false, nil, scraper.ScrapeOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	previous := p.last
+	p.last = result
+	p.validators = fetcher.ValidatorsOf(resp.Header)
+	if previous == nil {
+		return nil, nil
+	}
+	if diff := metadata.Diff(previous, result); !diff.Empty() {
+		return diff, nil
+	}
+	return nil, nil
+}
+
+// writeChange writes a change to url as a diff under a timestamped header,
+// or as a watchEvent per JSON line or YAML document
+func writeChange(w io.Writer, url string, at time.Time, diff *metadata.MetadataDiff, format string) error {
+	event := watchEvent{URL: url, Time: at, Diff: diff}
+
+	switch format {
+	case "json":
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case "yaml":
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		return writeYAML(w, event)
+	default:
+		_, _ = color.New(color.Bold).Fprintf(w, "\n==> %s changed at %s\n", url, at.Format(time.DateTime))
+		displayDiff(w, "previous", "current", diff)
+		return nil
+	}
+}
+
+// runHook runs command through sh with url in $GLYPTO_URL and diff as JSON
+// on its standard input, sending its output to w
+func runHook(ctx context.Context, w io.Writer, command, url string, diff *metadata.MetadataDiff) error {
+	data, err := json.Marshal(diff)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	hook := exec.CommandContext(ctx, "sh", "-c", command)
+	hook.Env = append(os.Environ(), "GLYPTO_URL="+url)
+	hook.Stdin = bytes.NewReader(data)
+	hook.Stdout, hook.Stderr = w, w
+	return hook.Run()
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().Duration("interval", 5*time.Minute, "How long to wait between checks")
+	watchCmd.Flags().Int("count", 0, "Stop after this many checks, including the first (0 to watch until interrupted)")
+	watchCmd.Flags().String("exec", "", "Run this shell command for each change, with $GLYPTO_URL set and the change as JSON on stdin")
+	watchCmd.Flags().String("format", "text", "Output format: text, json or yaml; one JSON object or YAML document per change")
+	watchCmd.Flags().Duration("timeout", 30*time.Second, "Give up on a check after this long (0 for no limit)")
+	addFetchFlags(watchCmd)
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+// newVersionedServer serves a page whose og:title is the version returned
+// for the nth request, with the version as its ETag, answering If-None-Match
+// with 304 and counting the full responses
+func newVersionedServer(version func(n int32) int, served *atomic.Int32) *httptest.Server {
+	var requests atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := version(requests.Add(1))
+		etag := fmt.Sprintf(`"v%d"`, current)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		served.Add(1)
+		w.Header().Set("ETag", etag)
+		_, _ = fmt.Fprintf(w, `<head><meta property="og:title" content="Version %d"></head>`, current)
+	}))
+}
+
+// setWatchFlags sets the watch flags for a test, resetting them afterwards
+func setWatchFlags(t *testing.T, values map[string]string) {
+	defaults := map[string]string{"interval": "5m", "count": "0", "exec": "", "format": "text", "timeout": "30s"}
+	for name, value := range values {
+		if err := watchCmd.Flags().Set(name, value); err != nil {
+			t.Fatalf("Failed to set --%s: %v", name, err)
+		}
+	}
+	t.Cleanup(func() {
+		for name := range values {
+			_ = watchCmd.Flags().Set(name, defaults[name])
+		}
+	})
+}
+
+func TestRunWatch(t *testing.T) {
+	var served atomic.Int32
+	server := newVersionedServer(func(n int32) int {
+		// The page changes from the third check on
+		if n >= 3 {
+			return 2
+		}
+		return 1
+	}, &served)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	watchCmd.SetOut(&buf)
+	defer watchCmd.SetOut(nil)
+	setWatchFlags(t, map[string]string{"interval": "10ms", "count": "4"})
+
+	if err := runWatch(watchCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runWatch() failed: %v", err)
+	}
+
+	if changes := strings.Count(buf.String(), "changed at"); changes != 1 {
+		t.Errorf("Output has %d changes, want 1:\n%s", changes, buf.String())
+	}
+	if !strings.Contains(buf.String(), `~ title: "Version 1" → "Version 2"`) {
+		t.Errorf("Output = %q, want the title change", buf.String())
+	}
+	if got := served.Load(); got != 2 {
+		t.Errorf("Full responses = %d, want 2 with unchanged checks answered by 304", got)
+	}
+}
+
+func TestRunWatch_Clock(t *testing.T) {
+	var served atomic.Int32
+	server := newVersionedServer(func(n int32) int { return int(n) }, &served)
+	defer server.Close()
+
+	fixed := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	watchClock = metadata.FixedClock(fixed)
+	defer func() { watchClock = metadata.SystemClock }()
+
+	var buf bytes.Buffer
+	watchCmd.SetOut(&buf)
+	defer watchCmd.SetOut(nil)
+	setWatchFlags(t, map[string]string{"interval": "10ms", "count": "2", "format": "json"})
+
+	if err := runWatch(watchCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runWatch() failed: %v", err)
+	}
+
+	var event watchEvent
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("Output %q is not a JSON event: %v", buf.String(), err)
+	}
+	if !event.Time.Equal(fixed) {
+		t.Errorf("Time = %v, want %v", event.Time, fixed)
+	}
+}
+
+func TestRunWatch_Exec(t *testing.T) {
+	var served atomic.Int32
+	server := newVersionedServer(func(n int32) int { return int(n) }, &served)
+	defer server.Close()
+
+	output := filepath.Join(t.TempDir(), "hook.json")
+	var buf bytes.Buffer
+	watchCmd.SetOut(&buf)
+	defer watchCmd.SetOut(nil)
+	watchCmd.SetErr(&buf)
+	defer watchCmd.SetErr(nil)
+	setWatchFlags(t, map[string]string{
+		"interval": "10ms",
+		"count":    "2",
+		"format":   "json",
+		"exec":     fmt.Sprintf(`echo "$GLYPTO_URL" > %q && cat >> %q`, output, output),
+	})
+
+	if err := runWatch(watchCmd, []string{server.URL}); err != nil {
+		t.Fatalf("runWatch() failed: %v", err)
+	}
+
+	var event watchEvent
+	if err := json.Unmarshal(buf.Bytes(), &event); err != nil {
+		t.Fatalf("Output is not a JSON event: %v\n%s", err, buf.String())
+	}
+	if event.URL != server.URL || len(event.Diff.Fields) == 0 {
+		t.Errorf("Event = %+v, want the change to %v", event, server.URL)
+	}
+
+	written, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Hook did not run: %v", err)
+	}
+	url, change, _ := strings.Cut(string(written), "\n")
+	if url != server.URL {
+		t.Errorf("$GLYPTO_URL = %q, want %q", url, server.URL)
+	}
+	var diff metadata.MetadataDiff
+	if err := json.Unmarshal([]byte(change), &diff); err != nil || diff.Empty() {
+		t.Errorf("Hook stdin = %q, want the change as JSON", change)
+	}
+}
+
+func TestRunWatch_FailedCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	setWatchFlags(t, map[string]string{"interval": "10ms", "count": "2"})
+
+	if err := runWatch(watchCmd, []string{server.URL}); err != nil {
+		t.Errorf("runWatch() error = %v, want failed checks reported and skipped", err)
+	}
+}
+
+func TestRunWatch_Interrupted(t *testing.T) {
+	var served atomic.Int32
+	server := newVersionedServer(func(int32) int { return 1 }, &served)
+	defer server.Close()
+
+	setWatchFlags(t, map[string]string{"interval": "10ms"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	watchCmd.SetContext(ctx)
+	defer watchCmd.SetContext(context.Background())
+
+	if err := runWatch(watchCmd, []string{server.URL}); err != nil {
+		t.Errorf("runWatch() error = %v, want nil when interrupted", err)
+	}
+}
+
+func TestRunWatch_InvalidInterval(t *testing.T) {
+	setWatchFlags(t, map[string]string{"interval": "0s"})
+
+	if err := runWatch(watchCmd, []string{"https://example.com"}); err == nil {
+		t.Error("runWatch() expected an error for a zero interval")
+	}
+}
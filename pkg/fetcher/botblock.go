@@ -0,0 +1,118 @@
+// Package fetcher contains helpers for retrieving web pages prior to scraping.
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrBotBlocked is returned when a response looks like a bot-protection
+// challenge or denial rather than the requested page
+var ErrBotBlocked = errors.New("request blocked by bot protection")
+
+// BotBlockedError describes a detected bot-block response
+type BotBlockedError struct {
+	Vendor     string
+	StatusCode int
+}
+
+// Error returns the error message along with guidance for getting through
+func (e *BotBlockedError) Error() string {
+	return fmt.Sprintf("%s (%s, status %d): try rendering the page in a headless browser or routing through a proxy",
+		ErrBotBlocked, e.Vendor, e.StatusCode)
+}
+
+// Unwrap allows errors.Is(err, ErrBotBlocked)
+func (e *BotBlockedError) Unwrap() error {
+	return ErrBotBlocked
+}
+
+// botBlockSignature identifies a bot-protection vendor by its body markers
+type botBlockSignature struct {
+	vendor  string
+	markers []string
+}
+
+// botBlockSignatures lists body markers of well-known challenge and denial
+// pages. Markers are only trusted on blocking statuses, since vendors also
+// inject some of these scripts into pages they let through.
+var botBlockSignatures = []botBlockSignature{
+	{
+		vendor: "Cloudflare",
+		markers: []string{
+			"cf-browser-verification",
+			"cf_chl_opt",
+			"challenge-platform",
+			"attention required! | cloudflare",
+		},
+	},
+	{
+		vendor:  "Akamai",
+		markers: []string{"errors.edgesuite.net"},
+	},
+	{
+		vendor:  "PerimeterX",
+		markers: []string{"px-captcha"},
+	},
+	{
+		vendor:  "DataDome",
+		markers: []string{"captcha-delivery.com"},
+	},
+	{
+		vendor: "unknown",
+		markers: []string{
+			"just a moment...",
+			"verify you are human",
+			"are you a robot",
+			"captcha",
+		},
+	},
+}
+
+// DetectBotBlock inspects a response and its body for signs of a
+// bot-protection challenge, returning a *BotBlockedError when found
+func DetectBotBlock(resp *http.Response, body []byte) error {
+	if resp == nil {
+		return nil
+	}
+
+	if strings.EqualFold(resp.Header.Get("cf-mitigated"), "challenge") {
+		return &BotBlockedError{Vendor: "Cloudflare", StatusCode: resp.StatusCode}
+	}
+
+	if !isBlockingStatus(resp.StatusCode) {
+		return nil
+	}
+
+	server := strings.ToLower(resp.Header.Get("Server"))
+	if strings.HasPrefix(server, "akamaighost") {
+		return &BotBlockedError{Vendor: "Akamai", StatusCode: resp.StatusCode}
+	}
+
+	content := strings.ToLower(string(body))
+	for _, signature := range botBlockSignatures {
+		for _, marker := range signature.markers {
+			if !strings.Contains(content, marker) {
+				continue
+			}
+
+			vendor := signature.vendor
+			if vendor == "unknown" && server == "cloudflare" {
+				vendor = "Cloudflare"
+			}
+			return &BotBlockedError{Vendor: vendor, StatusCode: resp.StatusCode}
+		}
+	}
+
+	return nil
+}
+
+// isBlockingStatus reports whether a status code is one bot protection
+// typically answers with
+func isBlockingStatus(statusCode int) bool {
+	return statusCode == http.StatusForbidden ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusServiceUnavailable
+}
@@ -0,0 +1,138 @@
+package fetcher
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDetectBotBlock(t *testing.T) {
+	tests := []struct {
+		name           string
+		statusCode     int
+		headers        map[string]string
+		body           string
+		expectedVendor string
+	}{
+		{
+			name:           "cloudflare mitigation header",
+			statusCode:     http.StatusOK,
+			headers:        map[string]string{"cf-mitigated": "challenge"},
+			expectedVendor: "Cloudflare",
+		},
+		{
+			name:           "cloudflare challenge page",
+			statusCode:     http.StatusForbidden,
+			body:           `<script>window._cf_chl_opt={};</script>`,
+			expectedVendor: "Cloudflare",
+		},
+		{
+			name:           "generic challenge served by cloudflare",
+			statusCode:     http.StatusServiceUnavailable,
+			headers:        map[string]string{"Server": "cloudflare"},
+			body:           "<title>Just a moment...</title>",
+			expectedVendor: "Cloudflare",
+		},
+		{
+			name:           "akamai denial by server header",
+			statusCode:     http.StatusForbidden,
+			headers:        map[string]string{"Server": "AkamaiGHost"},
+			body:           "<h1>Access Denied</h1>",
+			expectedVendor: "Akamai",
+		},
+		{
+			name:           "akamai denial by reference link",
+			statusCode:     http.StatusForbidden,
+			body:           `Reference #18.1234 https://errors.edgesuite.net/18.1234`,
+			expectedVendor: "Akamai",
+		},
+		{
+			name:           "datadome captcha",
+			statusCode:     http.StatusForbidden,
+			body:           `<script src="https://ct.captcha-delivery.com/c.js"></script>`,
+			expectedVendor: "DataDome",
+		},
+		{
+			name:           "generic captcha on 429",
+			statusCode:     http.StatusTooManyRequests,
+			body:           "Please verify you are human",
+			expectedVendor: "unknown",
+		},
+		{
+			name:       "challenge script on successful page",
+			statusCode: http.StatusOK,
+			body:       `<script src="/cdn-cgi/challenge-platform/scripts/jsd/main.js"></script>`,
+		},
+		{
+			name:       "plain forbidden page",
+			statusCode: http.StatusForbidden,
+			body:       "<h1>Forbidden</h1>",
+		},
+		{
+			name:       "not found page",
+			statusCode: http.StatusNotFound,
+			body:       "captcha",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Header:     make(http.Header),
+			}
+			for key, value := range tt.headers {
+				resp.Header.Set(key, value)
+			}
+
+			err := DetectBotBlock(resp, []byte(tt.body))
+
+			if tt.expectedVendor == "" {
+				if err != nil {
+					t.Errorf("DetectBotBlock() = %v, want nil", err)
+				}
+				return
+			}
+
+			var blocked *BotBlockedError
+			if !errors.As(err, &blocked) {
+				t.Fatalf("DetectBotBlock() = %v, want *BotBlockedError", err)
+			}
+
+			if blocked.Vendor != tt.expectedVendor {
+				t.Errorf("Vendor = %v, want %v", blocked.Vendor, tt.expectedVendor)
+			}
+
+			if blocked.StatusCode != tt.statusCode {
+				t.Errorf("StatusCode = %v, want %v", blocked.StatusCode, tt.statusCode)
+			}
+
+			if !errors.Is(err, ErrBotBlocked) {
+				t.Error("Expected error to wrap ErrBotBlocked")
+			}
+		})
+	}
+}
+
+func TestDetectBotBlock_NilResponse(t *testing.T) {
+	if err := DetectBotBlock(nil, nil); err != nil {
+		t.Errorf("DetectBotBlock(nil) = %v, want nil", err)
+	}
+}
+
+func TestBotBlockedError_Error(t *testing.T) {
+	err := &BotBlockedError{Vendor: "Cloudflare", StatusCode: http.StatusForbidden}
+
+	message := err.Error()
+	for _, expected := range []string{"Cloudflare", "403", "headless browser", "proxy"} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected error message to contain %q, got %q", expected, message)
+		}
+	}
+
+	// There is no option to change the User-Agent, so it is not suggested
+	if strings.Contains(message, "User-Agent") {
+		t.Errorf("Expected error message not to suggest a User-Agent, got %q", message)
+	}
+}
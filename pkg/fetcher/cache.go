@@ -0,0 +1,216 @@
+package fetcher
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a stored 200 OK response
+type CachedResponse struct {
+	// URL is the final URL the response was served from, after redirects
+	URL      string      `json:"url"`
+	Header   http.Header `json:"header"`
+	Body     []byte      `json:"body"`
+	StoredAt time.Time   `json:"storedAt"`
+}
+
+// Cache stores responses by request URL. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the response stored for key, if any
+	Get(key string) (*CachedResponse, bool)
+
+	// Set stores response under key
+	Set(key string, response *CachedResponse) error
+}
+
+// MemoryCache is a Cache held in memory for the life of the process
+type MemoryCache struct {
+	mu        sync.Mutex
+	responses map[string]*CachedResponse
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{responses: make(map[string]*CachedResponse)}
+}
+
+// Get returns the response stored for key, if any
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	response, found := c.responses[key]
+	return response, found
+}
+
+// Set stores response under key
+func (c *MemoryCache) Set(key string, response *CachedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.responses[key] = response
+	return nil
+}
+
+// DiskCache is a Cache persisted as one JSON file per URL in a directory,
+// so it survives between runs
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a cache stored in dir, which is created on first
+// write
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{dir: dir}
+}
+
+// Get returns the response stored for key, if any. Unreadable entries are
+// treated as missing.
+func (c *DiskCache) Get(key string) (*CachedResponse, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var response CachedResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, false
+	}
+	return &response, true
+}
+
+// Set stores response under key, replacing the file atomically so
+// concurrent readers never see a partial entry
+func (c *DiskCache) Set(key string, response *CachedResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// path returns the file an entry is stored in
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cacheDirectives holds the Cache-Control directives the fetcher honors
+type cacheDirectives struct {
+	noStore bool
+	noCache bool
+	maxAge  time.Duration
+}
+
+// parseCacheControl reads the directives relevant to a client cache
+func parseCacheControl(header http.Header) cacheDirectives {
+	var directives cacheDirectives
+	for _, field := range strings.Split(header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch strings.ToLower(name) {
+		case "no-store":
+			directives.noStore = true
+		case "no-cache":
+			directives.noCache = true
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil && seconds > 0 {
+				directives.maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return directives
+}
+
+// fresh reports whether a cached response may be used without revalidation
+func (r *CachedResponse) fresh(now time.Time) bool {
+	directives := parseCacheControl(r.Header)
+	return !directives.noCache && directives.maxAge > 0 && now.Sub(r.StoredAt) < directives.maxAge
+}
+
+// validators reports whether the response can be revalidated
+func (r *CachedResponse) validators() bool {
+	return r.Header.Get("ETag") != "" || r.Header.Get("Last-Modified") != ""
+}
+
+// cacheable reports whether a 200 OK response with header may be stored
+func cacheable(header http.Header) bool {
+	directives := parseCacheControl(header)
+	if directives.noStore {
+		return false
+	}
+	return directives.maxAge > 0 || header.Get("ETag") != "" || header.Get("Last-Modified") != ""
+}
+
+// response rebuilds an *http.Response for req from the cached entry
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	if finalURL, err := req.URL.Parse(r.URL); err == nil && r.URL != "" {
+		req = req.Clone(req.Context())
+		req.URL = finalURL
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// storeResponse copies a cacheable resp into the cache as stored at
+// storedAt, replacing its body with an in-memory copy so the caller can
+// still read it. Only a failure to read the body is returned; caching
+// itself is best-effort.
+func storeResponse(cache Cache, key string, resp *http.Response, storedAt time.Time) error {
+	if !cacheable(resp.Header) {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_ = cache.Set(key, &CachedResponse{
+		URL:      resp.Request.URL.String(),
+		Header:   resp.Header.Clone(),
+		Body:     body,
+		StoredAt: storedAt,
+	})
+	return nil
+}
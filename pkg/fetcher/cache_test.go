@@ -0,0 +1,168 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+func TestParseCacheControl(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected cacheDirectives
+	}{
+		{value: "", expected: cacheDirectives{}},
+		{value: "max-age=60", expected: cacheDirectives{maxAge: time.Minute}},
+		{value: `public, MAX-AGE="120"`, expected: cacheDirectives{maxAge: 2 * time.Minute}},
+		{value: "no-cache, max-age=60", expected: cacheDirectives{noCache: true, maxAge: time.Minute}},
+		{value: "no-store", expected: cacheDirectives{noStore: true}},
+		{value: "max-age=oops", expected: cacheDirectives{}},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{"Cache-Control": {tt.value}}
+		if result := parseCacheControl(header); result != tt.expected {
+			t.Errorf("parseCacheControl(%q) = %+v, want %+v", tt.value, result, tt.expected)
+		}
+	}
+}
+
+func TestCaches(t *testing.T) {
+	caches := map[string]Cache{
+		"memory": NewMemoryCache(),
+		"disk":   NewDiskCache(t.TempDir() + "/cache"),
+	}
+
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			if _, found := cache.Get("https://example.com/"); found {
+				t.Fatal("Get() on an empty cache found an entry")
+			}
+
+			entry := &CachedResponse{
+				URL:      "https://example.com/final",
+				Header:   http.Header{"Etag": {`"v1"`}},
+				Body:     []byte("<title>Cached</title>"),
+				StoredAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			}
+			if err := cache.Set("https://example.com/", entry); err != nil {
+				t.Fatalf("Set() returned error: %v", err)
+			}
+
+			result, found := cache.Get("https://example.com/")
+			if !found {
+				t.Fatal("Get() did not find the stored entry")
+			}
+			if result.URL != entry.URL || string(result.Body) != string(entry.Body) || result.Header.Get("ETag") != `"v1"` || !result.StoredAt.Equal(entry.StoredAt) {
+				t.Errorf("Get() = %+v, want %+v", result, entry)
+			}
+		})
+	}
+}
+
+// fakeClock is a metadata.Clock that tests move forward by hand
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// cacheServer serves a page with the given headers, answering conditional
+// requests for its ETag with 304, and counts full and conditional requests
+type cacheServer struct {
+	header      http.Header
+	full        int
+	conditional int
+}
+
+func (s *cacheServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if etag := s.header.Get("ETag"); etag != "" && r.Header.Get("If-None-Match") == etag {
+		s.conditional++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	s.full++
+	for key, values := range s.header {
+		w.Header()[key] = values
+	}
+	_, _ = w.Write([]byte("<title>Page</title>"))
+}
+
+func TestFetcher_Cache(t *testing.T) {
+	tests := []struct {
+		name                string
+		header              http.Header
+		advance             time.Duration
+		expectedFull        int
+		expectedConditional int
+	}{
+		{name: "fresh", header: http.Header{"Cache-Control": {"max-age=60"}}, advance: 20 * time.Second, expectedFull: 1},
+		{name: "expired", header: http.Header{"Cache-Control": {"max-age=60"}}, advance: time.Minute, expectedFull: 3},
+		{name: "expired revalidates", header: http.Header{"Cache-Control": {"max-age=60"}, "Etag": {`"v1"`}}, advance: time.Minute, expectedFull: 1, expectedConditional: 2},
+		{name: "revalidated", header: http.Header{"Etag": {`"v1"`}}, expectedFull: 1, expectedConditional: 2},
+		{name: "no-cache revalidates", header: http.Header{"Cache-Control": {"no-cache, max-age=3600"}, "Etag": {`"v1"`}}, expectedFull: 1, expectedConditional: 2},
+		{name: "no-store", header: http.Header{"Cache-Control": {"no-store"}, "Etag": {`"v1"`}}, expectedFull: 3},
+		{name: "no validators", header: http.Header{}, expectedFull: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &cacheServer{header: tt.header}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			clock := &fakeClock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+			fetcher := &Fetcher{Cache: NewMemoryCache(), Clock: clock}
+			for range 3 {
+				resp, err := fetcher.Fetch(context.Background(), server.URL)
+				if err != nil {
+					t.Fatalf("Fetch() returned error: %v", err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+
+				if string(body) != "<title>Page</title>" {
+					t.Errorf("Fetch() body = %q, want the page", body)
+				}
+				if resp.Request == nil || resp.Request.URL.String() != server.URL {
+					t.Errorf("Fetch() request URL = %v, want %v", resp.Request, server.URL)
+				}
+				clock.now = clock.now.Add(tt.advance)
+			}
+
+			if handler.full != tt.expectedFull || handler.conditional != tt.expectedConditional {
+				t.Errorf("requests = %d full, %d conditional, want %d, %d", handler.full, handler.conditional, tt.expectedFull, tt.expectedConditional)
+			}
+		})
+	}
+}
+
+func TestFetcher_DiskCachePersists(t *testing.T) {
+	handler := &cacheServer{header: http.Header{"Cache-Control": {"max-age=3600"}}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	dir := t.TempDir()
+	clock := metadata.FixedClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	for range 2 {
+		// A new fetcher per run, as separate CLI invocations would use
+		fetcher := &Fetcher{Cache: NewDiskCache(dir), Clock: clock}
+		resp, err := fetcher.Fetch(context.Background(), server.URL)
+		if err != nil {
+			t.Fatalf("Fetch() returned error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if handler.full != 1 {
+		t.Errorf("requests = %d, want 1", handler.full)
+	}
+}
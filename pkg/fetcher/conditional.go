@@ -0,0 +1,59 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrNotModified is returned by FetchIfModified when the page has not
+// changed since its validators were recorded
+var ErrNotModified = errors.New("not modified")
+
+// Validators identify a version of a page, as recorded from a previous
+// response
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// ValidatorsOf returns the validators of a response with header, for
+// storing and passing to a later FetchIfModified
+func ValidatorsOf(header http.Header) Validators {
+	return Validators{ETag: header.Get("ETag"), LastModified: header.Get("Last-Modified")}
+}
+
+// IsZero reports whether there are no validators to send
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// set makes req conditional on the validators
+func (v Validators) set(req *http.Request) {
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+}
+
+// matches reports whether a response with header is the version the
+// validators identify
+func (v Validators) matches(header http.Header) bool {
+	if v.ETag != "" {
+		return v.ETag == header.Get("ETag")
+	}
+	return v.LastModified != "" && v.LastModified == header.Get("Last-Modified")
+}
+
+// FetchIfModified retrieves url as Fetch does, but returns ErrNotModified
+// when the page is unchanged since validators were recorded. If the Cache
+// holds that version, its response is returned alongside ErrNotModified, so
+// it can still be scraped; a fresh entry answers without any request.
+func (f *Fetcher) FetchIfModified(ctx context.Context, url string, validators Validators) (*http.Response, error) {
+	if validators.IsZero() {
+		return f.Fetch(ctx, url)
+	}
+	return f.fetch(ctx, url, fetchOptions{since: &validators})
+}
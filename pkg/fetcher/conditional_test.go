@@ -0,0 +1,98 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+func TestValidators_Matches(t *testing.T) {
+	header := http.Header{"Etag": {`"v1"`}, "Last-Modified": {"Mon, 01 Jan 2024 00:00:00 GMT"}}
+
+	tests := []struct {
+		name       string
+		validators Validators
+		expected   bool
+	}{
+		{name: "same etag", validators: Validators{ETag: `"v1"`}, expected: true},
+		{name: "different etag", validators: Validators{ETag: `"v0"`, LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"}, expected: false},
+		{name: "same last modified", validators: Validators{LastModified: "Mon, 01 Jan 2024 00:00:00 GMT"}, expected: true},
+		{name: "none", validators: Validators{}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.validators.matches(header); result != tt.expected {
+				t.Errorf("matches() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+
+	if validators := ValidatorsOf(header); validators.ETag != `"v1"` || validators.LastModified != "Mon, 01 Jan 2024 00:00:00 GMT" {
+		t.Errorf("ValidatorsOf() = %+v, want the ETag and Last-Modified", validators)
+	}
+}
+
+func TestFetcher_FetchIfModified(t *testing.T) {
+	tests := []struct {
+		name                string
+		header              http.Header
+		cache               bool
+		validators          Validators
+		expectedErr         error
+		expectResponse      bool
+		expectedFull        int
+		expectedConditional int
+	}{
+		{name: "unchanged", header: http.Header{"Etag": {`"v1"`}}, validators: Validators{ETag: `"v1"`}, expectedErr: ErrNotModified, expectedConditional: 1},
+		{name: "changed", header: http.Header{"Etag": {`"v2"`}}, validators: Validators{ETag: `"v1"`}, expectResponse: true, expectedFull: 1},
+		{name: "no validators", header: http.Header{"Etag": {`"v1"`}}, expectResponse: true, expectedFull: 1},
+		{name: "unchanged with a cached copy", header: http.Header{"Etag": {`"v1"`}}, cache: true, validators: Validators{ETag: `"v1"`}, expectedErr: ErrNotModified, expectResponse: true, expectedFull: 1, expectedConditional: 1},
+		{name: "fresh cached copy", header: http.Header{"Etag": {`"v1"`}, "Cache-Control": {"max-age=3600"}}, cache: true, validators: Validators{ETag: `"v1"`}, expectedErr: ErrNotModified, expectResponse: true, expectedFull: 1},
+		{name: "cached copy of another version", header: http.Header{"Etag": {`"v2"`}}, cache: true, validators: Validators{ETag: `"v1"`}, expectResponse: true, expectedFull: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &cacheServer{header: tt.header}
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			fetcher := &Fetcher{Clock: metadata.FixedClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))}
+			if tt.cache {
+				fetcher.Cache = NewMemoryCache()
+				resp, err := fetcher.Fetch(context.Background(), server.URL)
+				if err != nil {
+					t.Fatalf("Fetch() returned error: %v", err)
+				}
+				_ = resp.Body.Close()
+			}
+
+			resp, err := fetcher.FetchIfModified(context.Background(), server.URL, tt.validators)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("FetchIfModified() error = %v, want %v", err, tt.expectedErr)
+			}
+			if (resp != nil) != tt.expectResponse {
+				t.Fatalf("FetchIfModified() response = %v, expectResponse %v", resp, tt.expectResponse)
+			}
+			if resp != nil {
+				body, _ := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if string(body) != "<title>Page</title>" {
+					t.Errorf("Body = %q, want the page", body)
+				}
+			}
+
+			if handler.full != tt.expectedFull || handler.conditional != tt.expectedConditional {
+				t.Errorf("requests = %d full, %d conditional, want %d and %d",
+					handler.full, handler.conditional, tt.expectedFull, tt.expectedConditional)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package fetcher
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CookieJar is an http.CookieJar that can be saved to and loaded from a
+// file, so session and consent cookies set on one run are sent on the next.
+// It is safe for concurrent use.
+type CookieJar struct {
+	jar  *cookiejar.Jar
+	path string
+
+	mu      sync.Mutex
+	entries []cookieEntry
+}
+
+// cookieEntry records a cookie along with the URL that set it, so it can be
+// replayed into a fresh jar
+type cookieEntry struct {
+	URL    string       `json:"url"`
+	Cookie *http.Cookie `json:"cookie"`
+}
+
+// replaces reports whether e is superseded by a cookie set from host: the
+// later one wins, as it does in the jar
+func (e cookieEntry) replaces(host string, cookie *http.Cookie) bool {
+	u, err := url.Parse(e.URL)
+	return err == nil && u.Host == host && e.Cookie.Name == cookie.Name &&
+		e.Cookie.Domain == cookie.Domain && e.Cookie.Path == cookie.Path
+}
+
+// NewCookieJar creates a jar persisted at path, loading any cookies already
+// saved there. With an empty path the jar is kept in memory only.
+func NewCookieJar(path string) (*CookieJar, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &CookieJar{jar: jar, path: path}
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []cookieEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		u, err := url.Parse(entry.URL)
+		if err != nil || entry.Cookie == nil || entry.Cookie.MaxAge < 0 ||
+			(!entry.Cookie.Expires.IsZero() && entry.Cookie.Expires.Before(now)) {
+			continue
+		}
+		c.SetCookies(u, []*http.Cookie{entry.Cookie})
+	}
+	return c, nil
+}
+
+// SetCookies stores cookies received in a response from u
+func (c *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	c.jar.SetCookies(u, cookies)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cookie := range cookies {
+		c.entries = slices.DeleteFunc(c.entries, func(e cookieEntry) bool {
+			return e.replaces(u.Host, cookie)
+		})
+		c.entries = append(c.entries, cookieEntry{URL: u.String(), Cookie: persistable(cookie)})
+	}
+}
+
+// persistable copies cookie with a relative Max-Age turned into an absolute
+// expiry, so it does not restart when the cookie is loaded again
+func persistable(cookie *http.Cookie) *http.Cookie {
+	stored := *cookie
+	if stored.MaxAge > 0 {
+		stored.Expires = time.Now().Add(time.Duration(stored.MaxAge) * time.Second)
+		stored.MaxAge = 0
+	}
+	return &stored
+}
+
+// Cookies returns the cookies to send in a request to u
+func (c *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	return c.jar.Cookies(u)
+}
+
+// Save writes the jar's cookies to its path; cookies that have expired by
+// the next load are skipped then
+func (c *CookieJar) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.Marshal(c.entries)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o600)
+}
@@ -0,0 +1,123 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// consentServer redirects to /consent, which sets a cookie and redirects
+// back; the page itself is only served to requests carrying the cookie
+func consentServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/consent":
+			http.SetCookie(w, &http.Cookie{Name: "consent", Value: "yes", Path: "/", MaxAge: 3600})
+			http.Redirect(w, r, "/page", http.StatusFound)
+		case r.URL.Path == "/page":
+			if cookie, err := r.Cookie("consent"); err != nil || cookie.Value != "yes" {
+				http.Redirect(w, r, "/consent", http.StatusFound)
+				return
+			}
+			_, _ = w.Write([]byte("<title>Page</title>"))
+		}
+	}))
+}
+
+func TestCookieJar_Persists(t *testing.T) {
+	server := consentServer()
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "state", "cookies.json")
+	jar, err := NewCookieJar(path)
+	if err != nil {
+		t.Fatalf("NewCookieJar() returned error: %v", err)
+	}
+
+	var redirects int
+	client := &http.Client{Jar: jar, CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		redirects++
+		return nil
+	}}
+
+	resp, err := (&Fetcher{Client: client}).Fetch(context.Background(), server.URL+"/page")
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if err := jar.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := NewCookieJar(path)
+	if err != nil {
+		t.Fatalf("NewCookieJar() returned error: %v", err)
+	}
+
+	redirects = 0
+	client.Jar = loaded
+	resp, err = (&Fetcher{Client: client}).Fetch(context.Background(), server.URL+"/page")
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if redirects != 0 {
+		t.Errorf("redirects = %d with a loaded jar, want 0", redirects)
+	}
+}
+
+func TestCookieJar_Entries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	jar, err := NewCookieJar(path)
+	if err != nil {
+		t.Fatalf("NewCookieJar() returned error: %v", err)
+	}
+
+	u, _ := url.Parse("https://example.com/")
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "old"}})
+	jar.SetCookies(u, []*http.Cookie{
+		{Name: "session", Value: "new"},
+		{Name: "stale", Value: "x", Expires: time.Now().Add(-time.Hour)},
+		{Name: "short", Value: "x", MaxAge: 60},
+	})
+
+	if len(jar.entries) != 3 {
+		t.Fatalf("entries = %+v, want the replaced cookie dropped", jar.entries)
+	}
+	if short := jar.entries[2].Cookie; short.MaxAge != 0 || short.Expires.IsZero() {
+		t.Errorf("stored Max-Age cookie = %+v, want an absolute expiry", short)
+	}
+
+	if err := jar.Save(); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	loaded, err := NewCookieJar(path)
+	if err != nil {
+		t.Fatalf("NewCookieJar() returned error: %v", err)
+	}
+
+	cookies := map[string]string{}
+	for _, cookie := range loaded.Cookies(u) {
+		cookies[cookie.Name] = cookie.Value
+	}
+	if len(cookies) != 2 || cookies["session"] != "new" || cookies["short"] != "x" {
+		t.Errorf("Cookies() = %v, want session=new and short=x", cookies)
+	}
+}
+
+func TestCookieJar_InMemory(t *testing.T) {
+	jar, err := NewCookieJar("")
+	if err != nil {
+		t.Fatalf("NewCookieJar() returned error: %v", err)
+	}
+	if err := jar.Save(); err != nil {
+		t.Errorf("Save() on an in-memory jar = %v, want nil", err)
+	}
+}
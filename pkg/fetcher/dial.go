@@ -0,0 +1,84 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// dialOptions reports whether any option needs a custom dialer
+func (f *Fetcher) dialOptions() bool {
+	return f.BlockPrivateNetworks || len(f.Resolve) > 0 || f.Network != ""
+}
+
+// dialClient returns a copy of client whose transport dials with the
+// Fetcher's BlockPrivateNetworks, Resolve and Network options. Dialing is
+// where the final address is known, so the options cover every redirect hop
+// and DNS answers that change between lookups. Copies are kept per client
+// so their connection pools are reused.
+func (f *Fetcher) dialClient(client *http.Client) (*http.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if dialing, ok := f.dialClients[client]; ok {
+		return dialing, nil
+	}
+
+	switch f.Network {
+	case "", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("unsupported network %q: use tcp4 or tcp6", f.Network)
+	}
+
+	var transport *http.Transport
+	switch base := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = base.Clone()
+	default:
+		if f.BlockPrivateNetworks {
+			return nil, fmt.Errorf("%w: cannot guard a custom %T transport", ErrBlockedAddress, base)
+		}
+		return nil, fmt.Errorf("cannot apply dial options to a custom %T transport", base)
+	}
+
+	dialer := &net.Dialer{}
+	if f.BlockPrivateNetworks {
+		dialer.Control = guardConnection
+	}
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if f.Network != "" {
+			network = f.Network
+		}
+		return dialer.DialContext(ctx, network, f.resolve(address))
+	}
+	transport.DialTLSContext = nil
+	transport.Proxy = nil
+
+	dialing := *client
+	dialing.Transport = transport
+	if f.dialClients == nil {
+		f.dialClients = make(map[*http.Client]*http.Client)
+	}
+	f.dialClients[client] = &dialing
+	return &dialing, nil
+}
+
+// resolve applies any Resolve override to a host:port dial address
+func (f *Fetcher) resolve(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	override, ok := f.Resolve[address]
+	if !ok {
+		override, ok = f.Resolve[host]
+	}
+	if !ok {
+		return address
+	}
+	return net.JoinHostPort(override, port)
+}
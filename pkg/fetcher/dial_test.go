@@ -0,0 +1,86 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestFetcher_Resolve(t *testing.T) {
+	f := &Fetcher{Resolve: map[string]string{
+		"example.com:443":   "192.0.2.10",
+		"staging.test":      "192.0.2.20",
+		"v6.example.com:80": "2001:db8::1",
+	}}
+
+	tests := []struct {
+		address  string
+		expected string
+	}{
+		{address: "example.com:443", expected: "192.0.2.10:443"},
+		{address: "example.com:80", expected: "example.com:80"},
+		{address: "staging.test:8443", expected: "192.0.2.20:8443"},
+		{address: "v6.example.com:80", expected: "[2001:db8::1]:80"},
+		{address: "other.test:443", expected: "other.test:443"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if result := f.resolve(tt.address); result != tt.expected {
+				t.Errorf("resolve(%q) = %v, want %v", tt.address, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFetcher_ResolveFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>" + r.Host + "</title>"))
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	fetcher := &Fetcher{
+		Resolve: map[string]string{"www.example.test": serverURL.Hostname()},
+		Network: "tcp4",
+	}
+
+	resp, err := fetcher.Fetch(context.Background(), "http://www.example.test:"+serverURL.Port()+"/")
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if host := resp.Request.URL.Hostname(); host != "www.example.test" {
+		t.Errorf("Request host = %v, want %v", host, "www.example.test")
+	}
+}
+
+func TestFetcher_Network(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	tests := []struct {
+		network     string
+		expectError bool
+	}{
+		{network: "tcp4"},
+		{network: "tcp6", expectError: true},
+		{network: "udp", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			// The test server only listens on IPv4 loopback
+			resp, err := (&Fetcher{Network: tt.network}).Fetch(context.Background(), server.URL)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Fetch() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+}
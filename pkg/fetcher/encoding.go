@@ -0,0 +1,64 @@
+package fetcher
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// acceptEncoding lists the content codings requests advertise; responses in
+// any of them are decoded before they are returned
+const acceptEncoding = "br, zstd, gzip"
+
+// decodedBody reads a decoder's output, closing the decoder and the encoded
+// body together
+type decodedBody struct {
+	io.Reader
+	closeDecoder func()
+	body         io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	if b.closeDecoder != nil {
+		b.closeDecoder()
+	}
+	return b.body.Close()
+}
+
+// decodeBody replaces resp.Body with its content decoded per the
+// Content-Encoding header, which is then removed along with the now stale
+// Content-Length. Unknown codings are left as they are.
+func decodeBody(resp *http.Response) error {
+	body := &decodedBody{body: resp.Body}
+
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "br":
+		body.Reader = brotli.NewReader(resp.Body)
+	case "zstd":
+		decoder, err := zstd.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode response body: %w", err)
+		}
+		body.Reader, body.closeDecoder = decoder, decoder.Close
+	case "gzip", "x-gzip":
+		decoder, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode response body: %w", err)
+		}
+		body.Reader, body.closeDecoder = decoder, func() { _ = decoder.Close() }
+	default:
+		return nil
+	}
+
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
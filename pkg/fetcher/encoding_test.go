@@ -0,0 +1,111 @@
+package fetcher
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// encode compresses page with the named content coding
+func encode(t *testing.T, coding, page string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch coding {
+	case "br":
+		writer = brotli.NewWriter(&buf)
+	case "zstd":
+		encoder, err := zstd.NewWriter(&buf)
+		if err != nil {
+			t.Fatalf("Failed to create zstd encoder: %v", err)
+		}
+		writer = encoder
+	case "gzip":
+		writer = gzip.NewWriter(&buf)
+	default:
+		return []byte(page)
+	}
+
+	if _, err := writer.Write([]byte(page)); err != nil {
+		t.Fatalf("Failed to encode page: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to encode page: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestFetcher_Decompression(t *testing.T) {
+	const page = "<html><head><title>Compressed</title></head></html>"
+
+	for _, coding := range []string{"br", "zstd", "gzip", ""} {
+		t.Run("coding "+coding, func(t *testing.T) {
+			var accepted string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				accepted = r.Header.Get("Accept-Encoding")
+				if coding != "" {
+					w.Header().Set("Content-Encoding", coding)
+				}
+				_, _ = w.Write(encode(t, coding, page))
+			}))
+			defer server.Close()
+
+			resp, err := (&Fetcher{}).Fetch(context.Background(), server.URL)
+			if err != nil {
+				t.Fatalf("Fetch() returned error: %v", err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if accepted != acceptEncoding {
+				t.Errorf("Accept-Encoding = %q, want %q", accepted, acceptEncoding)
+			}
+			if body, _ := io.ReadAll(resp.Body); string(body) != page {
+				t.Errorf("Body = %q, want %q", body, page)
+			}
+			if encoding := resp.Header.Get("Content-Encoding"); encoding != "" {
+				t.Errorf("Content-Encoding = %q, want it removed once decoded", encoding)
+			}
+		})
+	}
+}
+
+func TestFetcher_Decompression_Errors(t *testing.T) {
+	page := "<html><body>" + strings.Repeat("x", 4096) + "</body></html>"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/corrupt" {
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write([]byte("not gzip"))
+			return
+		}
+		w.Header().Set("Content-Encoding", "br")
+		_, _ = w.Write(encode(t, "br", page))
+	}))
+	defer server.Close()
+
+	if _, err := (&Fetcher{}).Fetch(context.Background(), server.URL+"/corrupt"); err == nil {
+		t.Error("Fetch() of a corrupt body returned no error")
+	}
+
+	// The limit applies to the decoded size, so small compressed bodies
+	// cannot expand without bound
+	resp, err := (&Fetcher{MaxBodySize: 1024}).Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("ReadAll() error = %v, want %v", err, ErrBodyTooLarge)
+	}
+}
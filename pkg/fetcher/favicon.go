@@ -0,0 +1,191 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register decoders for image.DecodeConfig
+	_ "image/jpeg" // register decoders for image.DecodeConfig
+	_ "image/png"  // register decoders for image.DecodeConfig
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+// maxIconBytes caps icon downloads; real favicons are a few kilobytes
+const maxIconBytes = 1 << 20
+
+// ErrInvalidIcon is returned when a downloaded icon is not a usable image
+var ErrInvalidIcon = errors.New("invalid icon")
+
+// IconFile is a downloaded and verified icon
+type IconFile struct {
+	URL         string
+	ContentType string
+
+	// Width and Height are the icon's pixel dimensions, the largest image
+	// for multi-size ICO files; zero for SVG and formats that are not
+	// decoded
+	Width  int
+	Height int
+
+	Data []byte
+}
+
+// Extension returns a file extension for the icon's content type
+func (i *IconFile) Extension() string {
+	switch i.ContentType {
+	case "image/png":
+		return ".png"
+	case "image/x-icon", "image/vnd.microsoft.icon":
+		return ".ico"
+	case "image/svg+xml":
+		return ".svg"
+	case "image/gif":
+		return ".gif"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	}
+	return ""
+}
+
+// DownloadFavicon downloads the icon of m closest to preferredSize pixels
+// (see Metadata.BestFavicon), falling back to /favicon.ico when the chosen
+// icon is missing or invalid
+func (f *Fetcher) DownloadFavicon(ctx context.Context, m *metadata.Metadata, preferredSize int) (*IconFile, error) {
+	candidates := []string{m.BestFavicon(preferredSize).Href}
+	if fallback := m.ResolveURL("/favicon.ico"); fallback != candidates[0] {
+		candidates = append(candidates, fallback)
+	}
+
+	var errs []error
+	for _, candidate := range candidates {
+		icon, err := f.DownloadIcon(ctx, candidate)
+		if err == nil {
+			return icon, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// DownloadIcon downloads the icon at iconURL, verifying it is an image
+// whose dimensions can be read
+func (f *Fetcher) DownloadIcon(ctx context.Context, iconURL string) (*IconFile, error) {
+	if strings.HasPrefix(iconURL, "data:") {
+		return nil, fmt.Errorf("%w: %s: data URLs are not downloaded", ErrInvalidIcon, iconURL)
+	}
+
+	resp, err := f.Fetch(ctx, iconURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIconBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read icon: %w", err)
+	}
+	if len(data) > maxIconBytes {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrInvalidIcon, iconURL, maxIconBytes)
+	}
+
+	icon := &IconFile{URL: resp.Request.URL.String(), Data: data}
+	if err := icon.verify(resp.Header.Get("Content-Type")); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidIcon, iconURL, err)
+	}
+	return icon, nil
+}
+
+// verify sets the content type and dimensions from the icon's bytes (see
+// sniffImage)
+func (i *IconFile) verify(declared string) error {
+	var err error
+	i.ContentType, i.Width, i.Height, err = sniffImage(i.Data, declared)
+	return err
+}
+
+// sniffImage returns the content type and dimensions read from an image's
+// leading bytes rather than the declared type, as servers often label
+// images wrongly or answer with an HTML error page. An SVG must still be
+// declared as one or at least not sniff as HTML. Dimensions are zero for
+// SVG and WebP, which are not decoded.
+func sniffImage(data []byte, declared string) (string, int, int, error) {
+	if len(data) == 0 {
+		return "", 0, 0, errors.New("empty response")
+	}
+
+	declared, _, _ = mime.ParseMediaType(declared)
+	sniffed := http.DetectContentType(data)
+
+	var width, height int
+	switch {
+	case sniffed == "image/x-icon":
+		width, height = icoSize(data)
+	case isSVG(data) && (declared == "image/svg+xml" || !strings.HasPrefix(sniffed, "text/html")):
+		return "image/svg+xml", 0, 0, nil
+	case sniffed == "image/webp":
+		return sniffed, 0, 0, nil
+	case strings.HasPrefix(sniffed, "image/"):
+		config, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return "", 0, 0, fmt.Errorf("undecodable %s", sniffed)
+		}
+		width, height = config.Width, config.Height
+	default:
+		return "", 0, 0, fmt.Errorf("not an image (%s)", sniffed)
+	}
+
+	if width == 0 || height == 0 {
+		return "", 0, 0, fmt.Errorf("unreadable %s dimensions", sniffed)
+	}
+	return sniffed, width, height, nil
+}
+
+// isSVG reports whether data looks like an SVG document
+func isSVG(data []byte) bool {
+	head := bytes.ToLower(data[:min(len(data), 512)])
+	return bytes.Contains(head, []byte("<svg"))
+}
+
+// icoSize returns the dimensions of the largest image in an ICO file, whose
+// directory entries store 0 for 256 pixels
+func icoSize(data []byte) (int, int) {
+	const headerSize, entrySize = 6, 16
+	if len(data) < headerSize {
+		return 0, 0
+	}
+
+	count := int(binary.LittleEndian.Uint16(data[4:6]))
+	width, height := 0, 0
+	for n := range count {
+		start := headerSize + n*entrySize
+		if start+entrySize > len(data) {
+			break
+		}
+		entry := data[start:]
+
+		w, h := int(entry[0]), int(entry[1])
+		if w == 0 {
+			w = 256
+		}
+		if h == 0 {
+			h = 256
+		}
+		if w*h > width*height {
+			width, height = w, h
+		}
+	}
+	return width, height
+}
@@ -0,0 +1,150 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+// testPNG encodes a blank PNG of the given size
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// testICO builds an ICO directory with 16x16 and 256x256 entries
+func testICO() []byte {
+	ico := []byte{0, 0, 1, 0, 2, 0}
+	ico = append(ico, 16, 16, 0, 0, 1, 0, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	ico = append(ico, 0, 0, 0, 0, 1, 0, 32, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	return ico
+}
+
+func TestIconFile_Verify(t *testing.T) {
+	tests := []struct {
+		name           string
+		declared       string
+		data           []byte
+		expectedType   string
+		expectedWidth  int
+		expectedHeight int
+		expectError    bool
+	}{
+		{name: "png", declared: "image/png", data: testPNG(t, 32, 24), expectedType: "image/png", expectedWidth: 32, expectedHeight: 24},
+		{name: "mislabeled png", declared: "application/octet-stream", data: testPNG(t, 16, 16), expectedType: "image/png", expectedWidth: 16, expectedHeight: 16},
+		{name: "ico", declared: "image/vnd.microsoft.icon", data: testICO(), expectedType: "image/x-icon", expectedWidth: 256, expectedHeight: 256},
+		{name: "svg", declared: "image/svg+xml", data: []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), expectedType: "image/svg+xml"},
+		{name: "html error page", declared: "image/png", data: []byte("<!DOCTYPE html><html><body>Not found</body></html>"), expectError: true},
+		{name: "truncated png", declared: "image/png", data: testPNG(t, 16, 16)[:20], expectError: true},
+		{name: "ico without entries", declared: "image/x-icon", data: []byte{0, 0, 1, 0, 0, 0}, expectError: true},
+		{name: "empty", declared: "image/png", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			icon := &IconFile{Data: tt.data}
+			err := icon.verify(tt.declared)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("verify() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err != nil {
+				return
+			}
+
+			if icon.ContentType != tt.expectedType || icon.Width != tt.expectedWidth || icon.Height != tt.expectedHeight {
+				t.Errorf("verify() = %s %dx%d, want %s %dx%d", icon.ContentType, icon.Width, icon.Height,
+					tt.expectedType, tt.expectedWidth, tt.expectedHeight)
+			}
+		})
+	}
+}
+
+func TestIconFile_Extension(t *testing.T) {
+	tests := map[string]string{
+		"image/png":     ".png",
+		"image/x-icon":  ".ico",
+		"image/svg+xml": ".svg",
+		"text/html":     "",
+	}
+
+	for contentType, expected := range tests {
+		if ext := (&IconFile{ContentType: contentType}).Extension(); ext != expected {
+			t.Errorf("Extension() for %s = %q, want %q", contentType, ext, expected)
+		}
+	}
+}
+
+func TestFetcher_DownloadFavicon(t *testing.T) {
+	icon := testPNG(t, 64, 64)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/icon.png":
+			_, _ = w.Write(icon)
+		case "/favicon.ico":
+			_, _ = w.Write(testICO())
+		case "/broken.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("<html><body>Oops</body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		head         string
+		expectedURL  string
+		expectedSize int
+		expectError  bool
+	}{
+		{name: "declared icon", head: `<link rel="icon" sizes="64x64" href="/icon.png">`, expectedURL: "/icon.png", expectedSize: 64},
+		{name: "broken icon falls back", head: `<link rel="icon" href="/broken.png">`, expectedURL: "/favicon.ico", expectedSize: 256},
+		{name: "no icons", expectedURL: "/favicon.ico", expectedSize: 256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := scraper.ScrapePreview(strings.NewReader("<html><head>" + tt.head + "</head></html>"))
+			if err != nil {
+				t.Fatalf("ScrapePreview() returned error: %v", err)
+			}
+			result.BaseURL, _ = url.Parse(server.URL + "/page")
+
+			downloaded, err := (&Fetcher{}).DownloadFavicon(context.Background(), result, 0)
+			if err != nil {
+				t.Fatalf("DownloadFavicon() returned error: %v", err)
+			}
+			if downloaded.URL != server.URL+tt.expectedURL || downloaded.Width != tt.expectedSize {
+				t.Errorf("DownloadFavicon() = %s at %dpx, want %s at %dpx", downloaded.URL, downloaded.Width, tt.expectedURL, tt.expectedSize)
+			}
+		})
+	}
+}
+
+func TestFetcher_DownloadIcon_Invalid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html><body>Not an icon</body></html>"))
+	}))
+	defer server.Close()
+
+	if _, err := (&Fetcher{}).DownloadIcon(context.Background(), server.URL+"/favicon.ico"); !errors.Is(err, ErrInvalidIcon) {
+		t.Errorf("DownloadIcon() error = %v, want %v", err, ErrInvalidIcon)
+	}
+	if _, err := (&Fetcher{}).DownloadIcon(context.Background(), "data:image/png;base64,AAAA"); !errors.Is(err, ErrInvalidIcon) {
+		t.Errorf("DownloadIcon() of a data URL error = %v, want %v", err, ErrInvalidIcon)
+	}
+}
@@ -0,0 +1,281 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+// botBlockSniffLimit caps how much of an error response is inspected for
+// bot-protection markers
+const botBlockSniffLimit = 64 * 1024
+
+// StatusError is returned when a page is served with a status other than
+// 200 OK
+type StatusError struct {
+	StatusCode int
+}
+
+// Error returns the error message with the status code
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP error! status: %d", e.StatusCode)
+}
+
+// Fetcher retrieves pages, optionally pacing requests per host. A Fetcher
+// is safe for concurrent use.
+//
+// BlockPrivateNetworks, Resolve and Network apply when dialing: they bypass
+// proxies, and a Client whose Transport is not an *http.Transport is
+// rejected since its dialing cannot be changed. They are read on the first
+// fetch with each Client.
+type Fetcher struct {
+	// Client sends the requests; http.DefaultClient when nil
+	Client *http.Client
+
+	// Limiter, when set, delays each request until its host may be
+	// contacted again
+	Limiter *HostLimiter
+
+	// Cache, when set, stores successful responses. Fresh entries (per
+	// Cache-Control max-age) are served without a request; stale ones are
+	// revalidated with If-None-Match and If-Modified-Since.
+	Cache Cache
+
+	// MaxBodySize, when positive, caps the bytes read from a response body
+	// after decompression; reading past it returns ErrBodyTooLarge. The
+	// check happens on read, not on Content-Length, so a streaming scrape
+	// that stops after the head still succeeds on an oversized page.
+	MaxBodySize int64
+
+	// MaxRedirects, when positive, fails fetches redirected more than this
+	// many times with ErrTooManyRedirects; otherwise the Client's policy
+	// applies (net/http stops after 10)
+	MaxRedirects int
+
+	// NoFollow stops at the first redirect, returning a *RedirectError
+	NoFollow bool
+
+	// BlockPrivateNetworks refuses connections to private, loopback and
+	// link-local addresses, including those reached through redirects, with
+	// ErrBlockedAddress. Set it when fetching URLs supplied by untrusted
+	// users.
+	BlockPrivateNetworks bool
+
+	// Resolve pins hosts to addresses, like curl's --resolve, e.g. to
+	// preview a page on a staging server before a DNS cutover. Keys are
+	// "host:port", or a bare host for any port; values are addresses to
+	// connect to instead. TLS still verifies the original host name.
+	Resolve map[string]string
+
+	// Network forces "tcp4" (IPv4) or "tcp6" (IPv6) connections; either
+	// family is used when empty
+	Network string
+
+	// WaybackFallback, when set, replaces pages that are gone (404, 410)
+	// or time out with the Internet Archive's latest snapshot, if any. Use
+	// SnapshotOf to tell an archived response from a live one. A timeout
+	// needs a Client.Timeout shorter than ctx's deadline to be recovered.
+	WaybackFallback bool
+
+	// Renderer, when set, loads pages in a browser instead of fetching
+	// them, for sites whose metadata is injected by scripts. The Cache,
+	// Client and redirect and body-size options do not apply; the Limiter
+	// does.
+	Renderer Renderer
+
+	// Hooks observe each request sent, e.g. for metrics or tracing
+	Hooks Hooks
+
+	// Clock supplies the time cache entries are stored at and judged fresh
+	// against; metadata.SystemClock when nil
+	Clock metadata.Clock
+
+	mu          sync.Mutex
+	dialClients map[*http.Client]*http.Client
+}
+
+// Fetch retrieves url with client, or http.DefaultClient when nil. ctx
+// bounds the whole exchange, including reading the returned body, so a hung
+// DNS lookup or slow server is abandoned once ctx is done. Responses other
+// than 200 OK and bot-protection pages are returned as errors; otherwise the
+// caller must close the body.
+func Fetch(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	return (&Fetcher{Client: client}).Fetch(ctx, url)
+}
+
+// Fetch retrieves url as the package-level Fetch does, consulting the
+// Cache and waiting for the Limiter before any request is made
+func (f *Fetcher) Fetch(ctx context.Context, url string) (*http.Response, error) {
+	resp, err := f.fetch(ctx, url, fetchOptions{})
+	if err != nil && f.WaybackFallback && deadLink(ctx, err) {
+		return f.fetchSnapshot(ctx, url, err)
+	}
+	return resp, err
+}
+
+// now returns the current time from the fetcher's clock
+func (f *Fetcher) now() time.Time {
+	if f.Clock == nil {
+		return metadata.SystemClock.Now()
+	}
+	return f.Clock.Now()
+}
+
+// fetchOptions adjust a single fetch
+type fetchOptions struct {
+	// since makes the fetch conditional on these validators when set
+	since *Validators
+
+	// noCache bypasses the Cache, which would read the whole body to store it
+	noCache bool
+
+	// maxBodySize, when positive, replaces the Fetcher's MaxBodySize
+	maxBodySize int64
+}
+
+// fetch retrieves url as options ask
+func (f *Fetcher) fetch(ctx context.Context, url string, options fetchOptions) (*http.Response, error) {
+	since := options.since
+	cache := f.Cache
+	if options.noCache {
+		cache = nil
+	}
+	maxBodySize := f.MaxBodySize
+	if options.maxBodySize > 0 {
+		maxBodySize = options.maxBodySize
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if f.dialOptions() {
+		dialing, err := f.dialClient(client)
+		if err != nil {
+			return nil, err
+		}
+		client = dialing
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if f.Renderer != nil {
+		return f.render(ctx, req)
+	}
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	// Only a cache entry for the version since identifies can stand in
+	// for that version
+	var cached *CachedResponse
+	if cache != nil {
+		if entry, found := cache.Get(url); found {
+			current := since == nil || since.matches(entry.Header)
+			if entry.fresh(f.now()) {
+				if since != nil && current {
+					return entry.response(req), ErrNotModified
+				}
+				return entry.response(req), nil
+			}
+			if entry.validators() && current {
+				cached = entry
+				ValidatorsOf(entry.Header).set(req)
+			}
+		}
+	}
+	if since != nil {
+		since.set(req)
+	}
+
+	if err := f.Limiter.Wait(ctx, url); err != nil {
+		return nil, err
+	}
+
+	resp, err := f.hookClient(f.redirectClient(client)).Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch URL: %w", err)
+	}
+
+	if f.NoFollow && isRedirect(resp.StatusCode) {
+		_ = resp.Body.Close()
+		return nil, &RedirectError{StatusCode: resp.StatusCode, Location: resp.Header.Get("Location")}
+	}
+
+	if err := decodeBody(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && (cached != nil || since != nil) {
+		_ = resp.Body.Close()
+		if cached == nil {
+			return nil, ErrNotModified
+		}
+
+		revalidated := *cached
+		revalidated.Header = cached.Header.Clone()
+		for key, values := range resp.Header {
+			revalidated.Header[key] = values
+		}
+		revalidated.StoredAt = f.now()
+		_ = cache.Set(url, &revalidated)
+		if since != nil {
+			return revalidated.response(req), ErrNotModified
+		}
+		return revalidated.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, botBlockSniffLimit))
+		if err := DetectBotBlock(resp, body); err != nil {
+			return nil, err
+		}
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	if err := DetectBotBlock(resp, nil); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	if maxBodySize > 0 {
+		resp.Body = limitBody(resp.Body, maxBodySize)
+	}
+
+	if cache != nil {
+		if err := storeResponse(cache, url, resp, f.now()); err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// render loads req's URL with the Renderer. A browser fetches subresources
+// and follows script navigation on its own, so BlockPrivateNetworks cannot
+// be enforced and is refused.
+func (f *Fetcher) render(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if f.BlockPrivateNetworks {
+		return nil, fmt.Errorf("%w: rendered pages cannot be guarded", ErrBlockedAddress)
+	}
+
+	url := req.URL.String()
+	if err := f.Limiter.Wait(ctx, url); err != nil {
+		return nil, err
+	}
+
+	page, err := f.Renderer.Render(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return page.response(req), nil
+}
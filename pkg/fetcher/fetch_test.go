@@ -0,0 +1,96 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     http.HandlerFunc
+		expectedErr string
+		botBlocked  bool
+	}{
+		{
+			name: "ok",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("<title>OK</title>"))
+			},
+		},
+		{
+			name: "http error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectedErr: "HTTP error! status: 500",
+		},
+		{
+			name: "bot blocked",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("cf-mitigated", "challenge")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			botBlocked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			resp, err := Fetch(context.Background(), nil, server.URL)
+			switch {
+			case tt.botBlocked:
+				if !errors.Is(err, ErrBotBlocked) {
+					t.Errorf("Fetch() error = %v, want %v", err, ErrBotBlocked)
+				}
+			case tt.expectedErr != "":
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Errorf("Fetch() error = %v, want %q", err, tt.expectedErr)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("Fetch() returned error: %v", err)
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				if body, _ := io.ReadAll(resp.Body); string(body) != "<title>OK</title>" {
+					t.Errorf("Fetch() body = %q, want %q", body, "<title>OK</title>")
+				}
+			}
+		})
+	}
+}
+
+func TestFetch_Context(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := Fetch(ctx, nil, server.URL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Fetch() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestFetch_InvalidURL(t *testing.T) {
+	if _, err := Fetch(context.Background(), nil, "://missing-scheme"); err == nil {
+		t.Error("Fetch() with an invalid URL should return an error")
+	}
+}
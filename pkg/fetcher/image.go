@@ -0,0 +1,81 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+// maxProbeBytes is how much of an image is read to find its type and
+// dimensions, which image formats store in their first few bytes
+const maxProbeBytes = 64 << 10
+
+// ErrInvalidImage is returned when a probed image is not a usable image
+var ErrInvalidImage = errors.New("invalid image")
+
+// ProbeImages fetches the start of each of m's images (see Metadata.Images)
+// and records those that exist as m.ProbedImages, with their sniffed
+// content type and dimensions. Images that fail to load, are not images, or
+// are narrower or shorter than minSize pixels are dropped. Only a context
+// error is returned.
+func (f *Fetcher) ProbeImages(ctx context.Context, m *metadata.Metadata, minSize int) error {
+	probed := make([]metadata.Image, 0)
+	for _, image := range m.Images() {
+		image, err := f.ProbeImage(ctx, image)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil || tooSmall(image, minSize) {
+			continue
+		}
+		probed = append(probed, image)
+	}
+
+	m.ProbedImages = probed
+	return nil
+}
+
+// ProbeImage fetches the start of image, returning it with the type and,
+// where they can be decoded, dimensions read from its bytes. Declared
+// dimensions are kept for SVG and WebP images. Only the start is read, so
+// the Cache and MaxBodySize do not apply. The Wayback fallback is not
+// used, so an archived copy cannot stand in for a dead image; a Fetcher
+// with a Renderer would render the image as a page, so probe with one
+// without.
+func (f *Fetcher) ProbeImage(ctx context.Context, image metadata.Image) (metadata.Image, error) {
+	if strings.HasPrefix(image.URL, "data:") {
+		return image, fmt.Errorf("%w: %s: data URLs are not probed", ErrInvalidImage, image.URL)
+	}
+
+	resp, err := f.fetch(ctx, image.URL, fetchOptions{noCache: true, maxBodySize: maxProbeBytes})
+	if err != nil {
+		return image, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBytes))
+	if err != nil && !errors.Is(err, ErrBodyTooLarge) {
+		return image, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	contentType, width, height, err := sniffImage(data, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return image, fmt.Errorf("%w: %s: %v", ErrInvalidImage, image.URL, err)
+	}
+
+	image.Type = contentType
+	if width > 0 {
+		image.Width, image.Height = width, height
+	}
+	return image, nil
+}
+
+// tooSmall reports whether image is known to be narrower or shorter than
+// minSize pixels
+func tooSmall(image metadata.Image, minSize int) bool {
+	return (image.Width > 0 && image.Width < minSize) || (image.Height > 0 && image.Height < minSize)
+}
@@ -0,0 +1,117 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/scraper"
+)
+
+func TestFetcher_ProbeImages(t *testing.T) {
+	large := testPNG(t, 1200, 630)
+	pixel := testPNG(t, 1, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large.png":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(large)
+		case "/pixel.png":
+			_, _ = w.Write(pixel)
+		case "/logo.svg":
+			w.Header().Set("Content-Type", "image/svg+xml")
+			_, _ = w.Write([]byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`))
+		case "/error.jpg":
+			_, _ = w.Write([]byte("<html><body>Not found</body></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	page := `<html><head>
+	<meta property="og:image" content="/missing.jpg">
+	<meta property="og:image" content="/large.png">
+	<meta property="og:image:width" content="600">
+	<meta property="og:image" content="/pixel.png">
+	<meta property="og:image" content="/error.jpg">
+	<meta property="og:image" content="/logo.svg">
+	<meta property="og:image:width" content="400">
+	</head></html>`
+	result, err := scraper.ScrapePreview(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+	result.BaseURL, _ = url.Parse(server.URL + "/page")
+
+	if err := (&Fetcher{}).ProbeImages(context.Background(), result, 32); err != nil {
+		t.Fatalf("ProbeImages() returned error: %v", err)
+	}
+
+	expected := []metadata.Image{
+		{URL: server.URL + "/large.png", Type: "image/png", Width: 1200, Height: 630},
+		{URL: server.URL + "/logo.svg", Type: "image/svg+xml", Width: 400},
+	}
+	images := result.Images()
+	if len(images) != len(expected) {
+		t.Fatalf("Images() = %+v, want %+v", images, expected)
+	}
+	for i := range expected {
+		if images[i] != expected[i] {
+			t.Errorf("Images()[%d] = %+v, want %+v", i, images[i], expected[i])
+		}
+	}
+
+	if image := result.Image(); image == nil || *image != server.URL+"/large.png" {
+		t.Errorf("Image() = %v, want %v", image, server.URL+"/large.png")
+	}
+}
+
+func TestFetcher_ProbeImages_Canceled(t *testing.T) {
+	result, _ := scraper.ScrapePreview(strings.NewReader(`<html><head><meta property="og:image" content="https://example.com/a.png"></head></html>`))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := (&Fetcher{}).ProbeImages(ctx, result, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("ProbeImages() error = %v, want %v", err, context.Canceled)
+	}
+	if result.ProbedImages != nil {
+		t.Errorf("ProbedImages = %+v, want nil after a canceled probe", result.ProbedImages)
+	}
+}
+
+func TestFetcher_ProbeImage_Invalid(t *testing.T) {
+	if _, err := (&Fetcher{}).ProbeImage(context.Background(), metadata.Image{URL: "data:image/png;base64,AAAA"}); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("ProbeImage() of a data URL error = %v, want %v", err, ErrInvalidImage)
+	}
+}
+
+func TestFetcher_ProbeImage_LargeImage(t *testing.T) {
+	// A PNG padded past the page body limit, served as cacheable
+	large := append(testPNG(t, 800, 600), make([]byte, 256<<10)...)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=3600")
+		_, _ = w.Write(large)
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache()
+	fetcher := &Fetcher{Cache: cache, MaxBodySize: 128 << 10}
+	image, err := fetcher.ProbeImage(context.Background(), metadata.Image{URL: server.URL + "/large.png"})
+	if err != nil {
+		t.Fatalf("ProbeImage() returned error: %v", err)
+	}
+	if image.Type != "image/png" || image.Width != 800 || image.Height != 600 {
+		t.Errorf("ProbeImage() = %+v, want an 800x600 PNG", image)
+	}
+
+	if _, found := cache.Get(server.URL + "/large.png"); found {
+		t.Error("ProbeImage() cached the image, want the cache bypassed")
+	}
+}
@@ -0,0 +1,46 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrBodyTooLarge is returned when a response body exceeds the Fetcher's
+// MaxBodySize
+var ErrBodyTooLarge = errors.New("response body too large")
+
+// limitedBody fails reads once more than limit bytes would be returned, so
+// callers that buffer the whole body give up instead of exhausting memory.
+// Callers that stop reading early, such as a head-only scrape, never see
+// the error.
+type limitedBody struct {
+	io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+// limitBody wraps body so reading past limit returns ErrBodyTooLarge
+func limitBody(body io.ReadCloser, limit int64) io.ReadCloser {
+	return &limitedBody{ReadCloser: body, limit: limit, remaining: limit}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Probe for a byte past the limit to tell a body of exactly limit
+		// bytes from a larger one
+		var probe [1]byte
+		n, err := b.ReadCloser.Read(probe[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: exceeds %d bytes", ErrBodyTooLarge, b.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,59 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLimitBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		limit    int64
+		expected error
+	}{
+		{name: "under the limit", body: "abc", limit: 4},
+		{name: "exactly the limit", body: "abcd", limit: 4},
+		{name: "over the limit", body: "abcde", limit: 4, expected: ErrBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := io.ReadAll(limitBody(io.NopCloser(strings.NewReader(tt.body)), tt.limit))
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("ReadAll() error = %v, want %v", err, tt.expected)
+			}
+			if tt.expected == nil && string(body) != tt.body {
+				t.Errorf("ReadAll() = %q, want %q", body, tt.body)
+			}
+		})
+	}
+}
+
+func TestFetcher_MaxBodySize(t *testing.T) {
+	page := "<html><head><title>Title</title></head><body>" + strings.Repeat("x", 1024) + "</body></html>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	fetcher := &Fetcher{MaxBodySize: 256}
+	resp, err := fetcher.Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	head := make([]byte, 32)
+	if _, err := io.ReadFull(resp.Body, head); err != nil {
+		t.Errorf("reading under the limit returned error: %v", err)
+	}
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("ReadAll() error = %v, want %v", err, ErrBodyTooLarge)
+	}
+}
@@ -0,0 +1,79 @@
+package fetcher
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HostLimiter spaces out requests to the same host. It is safe for
+// concurrent use, so one limiter can be shared by every goroutine fetching
+// in a batch or crawl.
+type HostLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// NewHostLimiter creates a limiter allowing perSecond requests per second to
+// each host, and at least crawlDelay between them. Either may be zero; with
+// both zero the limiter never waits.
+func NewHostLimiter(perSecond float64, crawlDelay time.Duration) *HostLimiter {
+	interval := crawlDelay
+	if perSecond > 0 {
+		if perRequest := time.Duration(float64(time.Second) / perSecond); perRequest > interval {
+			interval = perRequest
+		}
+	}
+
+	return &HostLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until a request to rawURL's host may be made, returning
+// ctx.Err() if ctx is done first. Hosts are compared case-insensitively,
+// including the port.
+func (l *HostLimiter) Wait(ctx context.Context, rawURL string) error {
+	if l == nil || l.interval <= 0 {
+		return nil
+	}
+
+	host := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	delay := l.reserve(strings.ToLower(host))
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve claims the host's next request slot, returning how long until it
+// starts
+func (l *HostLimiter) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	slot := l.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[host] = slot.Add(l.interval)
+	return slot.Sub(now)
+}
@@ -0,0 +1,109 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewHostLimiter(t *testing.T) {
+	tests := []struct {
+		name       string
+		perSecond  float64
+		crawlDelay time.Duration
+		expected   time.Duration
+	}{
+		{name: "unlimited", expected: 0},
+		{name: "rate only", perSecond: 4, expected: 250 * time.Millisecond},
+		{name: "crawl delay only", crawlDelay: time.Second, expected: time.Second},
+		{name: "slower of the two", perSecond: 4, crawlDelay: time.Second, expected: time.Second},
+		{name: "rate slower than delay", perSecond: 0.5, crawlDelay: time.Second, expected: 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if limiter := NewHostLimiter(tt.perSecond, tt.crawlDelay); limiter.interval != tt.expected {
+				t.Errorf("NewHostLimiter() interval = %v, want %v", limiter.interval, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHostLimiter_Wait(t *testing.T) {
+	const interval = 30 * time.Millisecond
+	limiter := NewHostLimiter(0, interval)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := limiter.Wait(context.Background(), "https://Example.com/page"); err != nil {
+				t.Errorf("Wait() returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("three requests to one host took %v, want at least %v", elapsed, 2*interval)
+	}
+
+	// Another host is not held up by the first
+	start = time.Now()
+	if err := limiter.Wait(context.Background(), "https://other.example.com/"); err != nil {
+		t.Errorf("Wait() returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= interval {
+		t.Errorf("first request to a new host took %v, want no delay", elapsed)
+	}
+}
+
+func TestHostLimiter_WaitContext(t *testing.T) {
+	limiter := NewHostLimiter(0, time.Hour)
+	if err := limiter.Wait(context.Background(), "https://example.com/"); err != nil {
+		t.Fatalf("Wait() returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx, "https://example.com/"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestHostLimiter_Nil(t *testing.T) {
+	var limiter *HostLimiter
+	if err := limiter.Wait(context.Background(), "https://example.com/"); err != nil {
+		t.Errorf("Wait() on a nil limiter = %v, want nil", err)
+	}
+}
+
+func TestFetcher_Limiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	const interval = 30 * time.Millisecond
+	fetcher := &Fetcher{Limiter: NewHostLimiter(0, interval)}
+
+	start := time.Now()
+	for range 2 {
+		resp, err := fetcher.Fetch(context.Background(), server.URL)
+		if err != nil {
+			t.Fatalf("Fetch() returned error: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < interval {
+		t.Errorf("two fetches took %v, want at least %v", elapsed, interval)
+	}
+}
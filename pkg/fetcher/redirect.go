@@ -0,0 +1,82 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrTooManyRedirects is returned when a fetch is redirected more than the
+// Fetcher's MaxRedirects times
+var ErrTooManyRedirects = errors.New("too many redirects")
+
+// ErrRedirectNotFollowed is returned when a Fetcher with NoFollow set
+// receives a redirect
+var ErrRedirectNotFollowed = errors.New("redirect not followed")
+
+// RedirectError describes a redirect response a NoFollow Fetcher stopped at
+type RedirectError struct {
+	StatusCode int
+	Location   string
+}
+
+// Error returns the error message along with where the redirect pointed
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("%s (status %d, location %q)", ErrRedirectNotFollowed, e.StatusCode, e.Location)
+}
+
+// Unwrap allows errors.Is(err, ErrRedirectNotFollowed)
+func (e *RedirectError) Unwrap() error {
+	return ErrRedirectNotFollowed
+}
+
+// redirectClient returns client with the Fetcher's redirect policy applied,
+// or client itself when the policy is the default. Any CheckRedirect the
+// client already has still runs.
+func (f *Fetcher) redirectClient(client *http.Client) *http.Client {
+	if !f.NoFollow && f.MaxRedirects <= 0 {
+		return client
+	}
+
+	policy := *client
+	policy.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if f.NoFollow {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > f.MaxRedirects {
+			return fmt.Errorf("%w: stopped after %d", ErrTooManyRedirects, f.MaxRedirects)
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		return nil
+	}
+	return &policy
+}
+
+// isRedirect reports whether status is a redirect carrying a Location
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// RedirectChain returns the URLs requested to produce resp, in order, ending
+// with the final URL. A response that was not redirected yields just its own
+// URL; one without a request yields nil. Responses served from a Cache
+// carry only the final URL.
+func RedirectChain(resp *http.Response) []string {
+	var chain []string
+	for resp != nil && resp.Request != nil {
+		chain = append(chain, resp.Request.URL.String())
+		resp = resp.Request.Response
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
@@ -0,0 +1,92 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// hopServer redirects /hop/N to /hop/N-1 until /hop/0, which serves a page
+func hopServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hop/"))
+		if hops > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/hop/%d", hops-1), http.StatusFound)
+			return
+		}
+		_, _ = w.Write([]byte("<title>Landed</title>"))
+	}))
+}
+
+func TestFetcher_MaxRedirects(t *testing.T) {
+	server := hopServer()
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		maxRedirects int
+		hops         int
+		expected     error
+	}{
+		{name: "default policy", hops: 3},
+		{name: "within the limit", maxRedirects: 2, hops: 2},
+		{name: "over the limit", maxRedirects: 2, hops: 3, expected: ErrTooManyRedirects},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &Fetcher{MaxRedirects: tt.maxRedirects}
+			resp, err := fetcher.Fetch(context.Background(), fmt.Sprintf("%s/hop/%d", server.URL, tt.hops))
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("Fetch() error = %v, want %v", err, tt.expected)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestFetcher_NoFollow(t *testing.T) {
+	server := hopServer()
+	defer server.Close()
+
+	_, err := (&Fetcher{NoFollow: true}).Fetch(context.Background(), server.URL+"/hop/1")
+
+	var redirect *RedirectError
+	if !errors.As(err, &redirect) {
+		t.Fatalf("Fetch() error = %v, want a *RedirectError", err)
+	}
+	if redirect.StatusCode != http.StatusFound || redirect.Location != "/hop/0" {
+		t.Errorf("RedirectError = %+v, want status 302 to /hop/0", redirect)
+	}
+	if !errors.Is(err, ErrRedirectNotFollowed) {
+		t.Errorf("errors.Is(err, ErrRedirectNotFollowed) = false for %v", err)
+	}
+}
+
+func TestRedirectChain(t *testing.T) {
+	server := hopServer()
+	defer server.Close()
+
+	resp, err := (&Fetcher{}).Fetch(context.Background(), server.URL+"/hop/2")
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	expected := []string{server.URL + "/hop/2", server.URL + "/hop/1", server.URL + "/hop/0"}
+	if chain := RedirectChain(resp); !slices.Equal(chain, expected) {
+		t.Errorf("RedirectChain() = %v, want %v", chain, expected)
+	}
+
+	if chain := RedirectChain(&http.Response{}); chain != nil {
+		t.Errorf("RedirectChain() without a request = %v, want nil", chain)
+	}
+}
@@ -0,0 +1,51 @@
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ErrRendererUnavailable is returned by NewRenderer when glypto was built
+// without a rendering backend
+var ErrRendererUnavailable = errors.New("no rendering backend: rebuild with -tags chromedp")
+
+// Renderer loads a page in a browser, so metadata injected by scripts is
+// present in the returned HTML
+type Renderer interface {
+	Render(ctx context.Context, url string) (*RenderedPage, error)
+}
+
+// RenderedPage is a page as a browser left it once scripts ran
+type RenderedPage struct {
+	// URL is where the browser ended up, after redirects and client-side
+	// navigation
+	URL string
+
+	// HTML is the serialized document
+	HTML string
+}
+
+// response wraps the rendered page as a 200 OK HTML response to req, so it
+// can be scraped like a fetched one
+func (p *RenderedPage) response(req *http.Request) *http.Response {
+	if finalURL, err := url.Parse(p.URL); err == nil && p.URL != "" {
+		req = req.Clone(req.Context())
+		req.URL = finalURL
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(p.HTML))),
+		ContentLength: int64(len(p.HTML)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,61 @@
+//go:build chromedp
+
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// defaultSettle is how long ChromeRenderer lets scripts run after the page
+// has loaded
+const defaultSettle = time.Second
+
+// ChromeRenderer renders pages in headless Chrome or Chromium, which must be
+// installed. Each Render starts its own browser.
+type ChromeRenderer struct {
+	// Settle is how long scripts may run after the load event before the
+	// document is read; defaults to one second
+	Settle time.Duration
+
+	// Options configure the browser process, starting from
+	// chromedp.DefaultExecAllocatorOptions when nil
+	Options []chromedp.ExecAllocatorOption
+}
+
+// NewRenderer returns a ChromeRenderer with default settings
+func NewRenderer() (Renderer, error) {
+	return &ChromeRenderer{}, nil
+}
+
+// Render loads url in a fresh headless browser and returns the document once
+// it has settled
+func (r *ChromeRenderer) Render(ctx context.Context, url string) (*RenderedPage, error) {
+	options := r.Options
+	if options == nil {
+		options = chromedp.DefaultExecAllocatorOptions[:]
+	}
+	settle := r.Settle
+	if settle <= 0 {
+		settle = defaultSettle
+	}
+
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, options...)
+	defer cancelAlloc()
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	var page RenderedPage
+	if err := chromedp.Run(browserCtx,
+		chromedp.Navigate(url),
+		chromedp.Sleep(settle),
+		chromedp.Location(&page.URL),
+		chromedp.OuterHTML("html", &page.HTML, chromedp.ByQuery),
+	); err != nil {
+		return nil, fmt.Errorf("failed to render page: %w", err)
+	}
+	return &page, nil
+}
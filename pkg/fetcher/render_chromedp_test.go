@@ -0,0 +1,46 @@
+//go:build chromedp
+
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChromeRenderer_Render(t *testing.T) {
+	found := false
+	for _, browser := range []string{"google-chrome", "chromium", "chromium-browser", "headless-shell"} {
+		if _, err := exec.LookPath(browser); err == nil {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Skip("no Chrome or Chromium installed")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><head><script>
+			const meta = document.createElement("meta");
+			meta.setAttribute("property", "og:title");
+			meta.setAttribute("content", "Injected");
+			document.head.appendChild(meta);
+		</script></head><body></body></html>`))
+	}))
+	defer server.Close()
+
+	renderer := &ChromeRenderer{Settle: 100 * time.Millisecond}
+	page, err := renderer.Render(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Render() returned error: %v", err)
+	}
+
+	if !strings.Contains(page.HTML, `content="Injected"`) {
+		t.Errorf("HTML = %s, want the injected meta tag", page.HTML)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !chromedp
+
+package fetcher
+
+// NewRenderer returns the headless-browser Renderer compiled into this
+// build. This build has none, so it always returns ErrRendererUnavailable.
+func NewRenderer() (Renderer, error) {
+	return nil, ErrRendererUnavailable
+}
@@ -0,0 +1,14 @@
+//go:build !chromedp
+
+package fetcher
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewRenderer_Unavailable(t *testing.T) {
+	if _, err := NewRenderer(); !errors.Is(err, ErrRendererUnavailable) {
+		t.Errorf("NewRenderer() error = %v, want %v", err, ErrRendererUnavailable)
+	}
+}
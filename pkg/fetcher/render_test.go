@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeRenderer returns a fixed page, recording the URLs it was asked for
+type fakeRenderer struct {
+	page     *RenderedPage
+	err      error
+	rendered []string
+}
+
+func (r *fakeRenderer) Render(ctx context.Context, url string) (*RenderedPage, error) {
+	r.rendered = append(r.rendered, url)
+	return r.page, r.err
+}
+
+func TestFetcher_Renderer(t *testing.T) {
+	renderer := &fakeRenderer{page: &RenderedPage{
+		URL:  "https://example.com/app#/home",
+		HTML: `<html><head><meta property="og:title" content="Injected"></head></html>`,
+	}}
+
+	resp, err := (&Fetcher{Renderer: renderer}).Fetch(context.Background(), "https://example.com/app")
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if len(renderer.rendered) != 1 || renderer.rendered[0] != "https://example.com/app" {
+		t.Errorf("rendered = %v, want [https://example.com/app]", renderer.rendered)
+	}
+	if finalURL := resp.Request.URL.String(); finalURL != renderer.page.URL {
+		t.Errorf("Request.URL = %v, want %v", finalURL, renderer.page.URL)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/html; charset=utf-8" {
+		t.Errorf("Content-Type = %v, want %v", contentType, "text/html; charset=utf-8")
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != renderer.page.HTML {
+		t.Errorf("Body = %s, want %s", body, renderer.page.HTML)
+	}
+}
+
+func TestFetcher_Renderer_Errors(t *testing.T) {
+	errRender := errors.New("browser crashed")
+
+	tests := []struct {
+		name     string
+		fetcher  *Fetcher
+		expected error
+	}{
+		{name: "render failure", fetcher: &Fetcher{Renderer: &fakeRenderer{err: errRender}}, expected: errRender},
+		{name: "private networks blocked", fetcher: &Fetcher{Renderer: &fakeRenderer{}, BlockPrivateNetworks: true}, expected: ErrBlockedAddress},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.fetcher.Fetch(context.Background(), "https://example.com/"); !errors.Is(err, tt.expected) {
+				t.Errorf("Fetch() error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"syscall"
+)
+
+// ErrBlockedAddress is returned when a Fetcher with BlockPrivateNetworks
+// set would connect to a private, loopback or link-local address
+var ErrBlockedAddress = errors.New("blocked address")
+
+// blockedAddr reports whether connections to addr are refused; tests swap
+// it to tell apart servers that all listen on loopback
+var blockedAddr = func(addr netip.AddrPort) bool {
+	ip := addr.Addr().Unmap()
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+// guardConnection refuses to connect to blocked addresses
+func guardConnection(network, address string, _ syscall.RawConn) error {
+	addr, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: unparseable address %q", ErrBlockedAddress, address)
+	}
+	if blockedAddr(addr) {
+		return fmt.Errorf("%w: %s is a private, loopback or link-local address", ErrBlockedAddress, addr.Addr())
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+)
+
+func TestBlockedAddr(t *testing.T) {
+	tests := []struct {
+		address  string
+		expected bool
+	}{
+		{address: "127.0.0.1:80", expected: true},
+		{address: "[::1]:80", expected: true},
+		{address: "10.1.2.3:80", expected: true},
+		{address: "172.16.0.1:443", expected: true},
+		{address: "192.168.1.1:80", expected: true},
+		{address: "169.254.169.254:80", expected: true},
+		{address: "[fe80::1]:80", expected: true},
+		{address: "[fd00::1]:80", expected: true},
+		{address: "[::ffff:127.0.0.1]:80", expected: true},
+		{address: "0.0.0.0:80", expected: true},
+		{address: "93.184.216.34:443", expected: false},
+		{address: "[2606:4700::1111]:443", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if blocked := blockedAddr(netip.MustParseAddrPort(tt.address)); blocked != tt.expected {
+				t.Errorf("blockedAddr(%s) = %v, want %v", tt.address, blocked, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFetcher_BlockPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Internal</title>"))
+	}))
+	defer server.Close()
+
+	if _, err := (&Fetcher{BlockPrivateNetworks: true}).Fetch(context.Background(), server.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Fetch() error = %v, want %v", err, ErrBlockedAddress)
+	}
+
+	custom := &http.Client{Transport: http.NewFileTransport(http.Dir("."))}
+	if _, err := (&Fetcher{Client: custom, BlockPrivateNetworks: true}).Fetch(context.Background(), server.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Fetch() with a custom transport error = %v, want %v", err, ErrBlockedAddress)
+	}
+}
+
+func TestFetcher_BlockPrivateNetworks_Redirect(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Internal</title>"))
+	}))
+	defer internal.Close()
+
+	public := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusFound)
+	}))
+	defer public.Close()
+
+	// Both servers listen on loopback, so only block the internal one
+	internalURL, _ := url.Parse(internal.URL)
+	internalAddr := netip.MustParseAddrPort(internalURL.Host)
+	original := blockedAddr
+	blockedAddr = func(addr netip.AddrPort) bool { return addr == internalAddr }
+	defer func() { blockedAddr = original }()
+
+	fetcher := &Fetcher{BlockPrivateNetworks: true}
+	if _, err := fetcher.Fetch(context.Background(), public.URL); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Fetch() error = %v, want %v", err, ErrBlockedAddress)
+	}
+
+	if len(fetcher.dialClients) != 1 {
+		t.Errorf("dial clients = %d, want 1", len(fetcher.dialClients))
+	}
+}
@@ -0,0 +1,77 @@
+package fetcher
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// TLSOptions configures how fetches verify servers and authenticate to them
+type TLSOptions struct {
+	// RootCAFile is a PEM bundle of certificate authorities trusted in
+	// addition to the system pool, for intranet pages behind a private CA
+	RootCAFile string
+
+	// CertFile and KeyFile hold a PEM client certificate and its private
+	// key, presented to servers that require mutual TLS
+	CertFile string
+	KeyFile  string
+
+	// InsecureSkipVerify accepts any server certificate, leaving fetches
+	// open to interception. It is meant for one-off debugging only; prefer
+	// RootCAFile.
+	InsecureSkipVerify bool
+}
+
+// Config builds the tls.Config described by the options
+func (o TLSOptions) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.RootCAFile != "" {
+		bundle, err := os.ReadFile(o.RootCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("failed to read CA bundle: no PEM certificates in %s", o.RootCAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if o.CertFile != "" || o.KeyFile != "" {
+		if o.CertFile == "" || o.KeyFile == "" {
+			return nil, errors.New("a client certificate needs both a certificate and a key file")
+		}
+
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// Transport returns a copy of http.DefaultTransport using the options
+func (o TLSOptions) Transport() (*http.Transport, error) {
+	config, err := o.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport, nil
+}
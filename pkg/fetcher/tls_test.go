@@ -0,0 +1,124 @@
+package fetcher
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writePEM writes a PEM block to a file in dir and returns its path
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// writeClientCert writes a self-signed client certificate and its key
+func writeClientCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "glypto client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func TestTLSOptions_Transport(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<title>Intranet</title>"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", server.Certificate().Raw)
+	certFile, keyFile := writeClientCert(t, dir)
+
+	tests := []struct {
+		name        string
+		options     TLSOptions
+		expectError bool
+	}{
+		{name: "system roots only", options: TLSOptions{CertFile: certFile, KeyFile: keyFile}, expectError: true},
+		{name: "no client certificate", options: TLSOptions{RootCAFile: caFile}, expectError: true},
+		{name: "private CA and client certificate", options: TLSOptions{RootCAFile: caFile, CertFile: certFile, KeyFile: keyFile}},
+		{name: "insecure skip verify", options: TLSOptions{CertFile: certFile, KeyFile: keyFile, InsecureSkipVerify: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, err := tt.options.Transport()
+			if err != nil {
+				t.Fatalf("Transport() returned error: %v", err)
+			}
+			defer transport.CloseIdleConnections()
+
+			resp, err := Fetch(context.Background(), &http.Client{Transport: transport}, server.URL)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Fetch() error = %v, expectError %v", err, tt.expectError)
+			}
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestTLSOptions_Config_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options TLSOptions
+	}{
+		{name: "missing CA file", options: TLSOptions{RootCAFile: filepath.Join(dir, "missing.pem")}},
+		{name: "CA file without certificates", options: TLSOptions{RootCAFile: notPEM}},
+		{name: "certificate without key", options: TLSOptions{CertFile: notPEM}},
+		{name: "unreadable key pair", options: TLSOptions{CertFile: notPEM, KeyFile: notPEM}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.options.Config(); err == nil {
+				t.Error("Config() error = nil, want an error")
+			}
+		})
+	}
+}
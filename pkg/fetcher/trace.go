@@ -0,0 +1,144 @@
+package fetcher
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestInfo describes one HTTP request made by a Fetcher
+type RequestInfo struct {
+	Method string
+	URL    string
+
+	// StatusCode is the response status; zero when no response arrived
+	StatusCode int
+
+	// Bytes counts the response body bytes read, as sent on the wire
+	// (before decompression)
+	Bytes int64
+
+	// Latency is the time until the response headers arrived; Duration
+	// until the body was read to the end or closed
+	Latency  time.Duration
+	Duration time.Duration
+
+	// Err is why the request or reading its body failed, if it did
+	Err error
+}
+
+// Hooks observe every HTTP request a Fetcher sends, each redirect hop on
+// its own, for metrics or tracing. Responses served from the Cache without
+// a request and pages loaded by a Renderer are not observed. Hooks may be
+// called from concurrent goroutines.
+type Hooks struct {
+	// RequestStart, when set, is called before a request is sent with its
+	// Method and URL. The context it returns, e.g. one carrying a tracing
+	// span, is used for the request and passed to RequestDone; nil keeps
+	// the request's own.
+	RequestStart func(ctx context.Context, info RequestInfo) context.Context
+
+	// RequestDone, when set, is called once the response body has been
+	// read to the end or closed, or when no response arrived
+	RequestDone func(ctx context.Context, info RequestInfo)
+}
+
+// enabled reports whether any hook is set
+func (h Hooks) enabled() bool {
+	return h.RequestStart != nil || h.RequestDone != nil
+}
+
+// hookClient returns a copy of client whose transport reports each request
+// to the Fetcher's Hooks, or client itself when no hook is set
+func (f *Fetcher) hookClient(client *http.Client) *http.Client {
+	if !f.Hooks.enabled() {
+		return client
+	}
+
+	hooked := *client
+	hooked.Transport = &hookTransport{base: client.Transport, hooks: f.Hooks}
+	return &hooked
+}
+
+// hookTransport wraps a RoundTripper, calling Hooks around each request
+type hookTransport struct {
+	base  http.RoundTripper
+	hooks Hooks
+}
+
+// RoundTrip sends req through the base transport, reporting it to the hooks
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	info := RequestInfo{Method: req.Method, URL: req.URL.String()}
+	ctx := req.Context()
+	if t.hooks.RequestStart != nil {
+		if started := t.hooks.RequestStart(ctx, info); started != nil {
+			ctx = started
+			req = req.WithContext(ctx)
+		}
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	info.Latency = time.Since(start)
+	if err != nil {
+		info.Err = err
+		info.Duration = info.Latency
+		t.done(ctx, info)
+		return nil, err
+	}
+
+	info.StatusCode = resp.StatusCode
+	resp.Body = &hookBody{
+		body: resp.Body,
+		finish: func(read int64, readErr error) {
+			info.Bytes, info.Err = read, readErr
+			info.Duration = time.Since(start)
+			t.done(ctx, info)
+		},
+	}
+	return resp, nil
+}
+
+// done calls RequestDone when it is set
+func (t *hookTransport) done(ctx context.Context, info RequestInfo) {
+	if t.hooks.RequestDone != nil {
+		t.hooks.RequestDone(ctx, info)
+	}
+}
+
+// hookBody counts the bytes read from a response body and calls finish
+// once, at the end of the body or when it is closed
+type hookBody struct {
+	body   io.ReadCloser
+	read   int64
+	err    error
+	once   sync.Once
+	finish func(read int64, err error)
+}
+
+// Read reads from the body, finishing at its end
+func (b *hookBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.read += int64(n)
+	switch {
+	case err == io.EOF:
+		b.once.Do(func() { b.finish(b.read, nil) })
+	case err != nil:
+		b.err = err
+	}
+	return n, err
+}
+
+// Close closes the body, finishing if it was not read to the end
+func (b *hookBody) Close() error {
+	err := b.body.Close()
+	b.once.Do(func() { b.finish(b.read, b.err) })
+	return err
+}
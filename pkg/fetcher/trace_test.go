@@ -0,0 +1,119 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type spanKey struct{}
+
+func TestFetcher_Hooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		_, _ = w.Write([]byte("<title>Hello</title>"))
+	}))
+	defer server.Close()
+
+	var (
+		mu      sync.Mutex
+		started []string
+		done    []RequestInfo
+	)
+	f := &Fetcher{Hooks: Hooks{
+		RequestStart: func(ctx context.Context, info RequestInfo) context.Context {
+			mu.Lock()
+			defer mu.Unlock()
+			started = append(started, info.URL)
+			return context.WithValue(ctx, spanKey{}, info.URL)
+		},
+		RequestDone: func(ctx context.Context, info RequestInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			if span := ctx.Value(spanKey{}); span != info.URL {
+				t.Errorf("RequestDone() context span = %v, want %v", span, info.URL)
+			}
+			done = append(done, info)
+		},
+	}}
+
+	resp, err := f.Fetch(context.Background(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(started) != 2 || started[0] != server.URL+"/old" || started[1] != server.URL+"/new" {
+		t.Errorf("RequestStart() URLs = %v, want both hops", started)
+	}
+	if len(done) != 2 {
+		t.Fatalf("RequestDone() called %d times, want 2", len(done))
+	}
+
+	if done[0].StatusCode != http.StatusMovedPermanently {
+		t.Errorf("first hop StatusCode = %d, want %d", done[0].StatusCode, http.StatusMovedPermanently)
+	}
+
+	final := done[1]
+	if final.Method != http.MethodGet || final.StatusCode != http.StatusOK || final.Bytes != int64(len("<title>Hello</title>")) || final.Err != nil {
+		t.Errorf("final hop = %+v, want a 200 with the body's bytes", final)
+	}
+	if final.Duration < final.Latency {
+		t.Errorf("final hop Duration = %v, want at least Latency %v", final.Duration, final.Latency)
+	}
+}
+
+func TestFetcher_Hooks_RequestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	var done []RequestInfo
+	f := &Fetcher{Hooks: Hooks{
+		RequestDone: func(ctx context.Context, info RequestInfo) { done = append(done, info) },
+	}}
+
+	if _, err := f.Fetch(context.Background(), serverURL); err == nil {
+		t.Fatal("Fetch() expected an error from a closed server")
+	}
+	if len(done) != 1 || done[0].Err == nil || done[0].StatusCode != 0 {
+		t.Errorf("RequestDone() infos = %+v, want one failed request", done)
+	}
+}
+
+func TestHookBody_FinishesOnce(t *testing.T) {
+	readErr := errors.New("connection reset")
+	var finished []error
+	body := &hookBody{
+		body:   io.NopCloser(&errReader{err: readErr}),
+		finish: func(read int64, err error) { finished = append(finished, err) },
+	}
+
+	_, _ = body.Read(make([]byte, 8))
+	_ = body.Close()
+	_ = body.Close()
+
+	if len(finished) != 1 || !errors.Is(finished[0], readErr) {
+		t.Errorf("finish calls = %v, want one with %v", finished, readErr)
+	}
+}
+
+// errReader fails every read with err
+type errReader struct {
+	err error
+}
+
+func (e *errReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
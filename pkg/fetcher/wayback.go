@@ -0,0 +1,131 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Internet Archive endpoints; tests point them at local servers
+var (
+	waybackAvailabilityURL = "https://archive.org/wayback/available"
+	waybackWebURL          = "https://web.archive.org"
+)
+
+// waybackTimestamp is the layout of snapshot timestamps
+const waybackTimestamp = "20060102150405"
+
+// snapshotPath matches the path of a raw snapshot URL, capturing its timestamp
+// and the original URL
+var snapshotPath = regexp.MustCompile(`^/web/(\d{14})id_/(.+)$`)
+
+// Snapshot is an Internet Archive capture of a page
+type Snapshot struct {
+	// URL is the archived copy, served as originally captured
+	URL string
+
+	// Original is the URL that was captured
+	Original string
+
+	// Timestamp is when the capture was made
+	Timestamp time.Time
+}
+
+// SnapshotOf reports the snapshot resp was served from, when a
+// WaybackFallback replaced the live page with one
+func SnapshotOf(resp *http.Response) (*Snapshot, bool) {
+	if resp == nil || resp.Request == nil {
+		return nil, false
+	}
+
+	// Match on the whole URL, since the original's query is part of it
+	snapshotURL := resp.Request.URL.String()
+	path, found := strings.CutPrefix(snapshotURL, waybackWebURL)
+	if !found {
+		return nil, false
+	}
+
+	match := snapshotPath.FindStringSubmatch(path)
+	if match == nil {
+		return nil, false
+	}
+	timestamp, err := time.Parse(waybackTimestamp, match[1])
+	if err != nil {
+		return nil, false
+	}
+	return &Snapshot{URL: snapshotURL, Original: match[2], Timestamp: timestamp}, true
+}
+
+// LatestSnapshot asks the Internet Archive for its most recent capture of
+// rawURL, returning nil when there is none
+func (f *Fetcher) LatestSnapshot(ctx context.Context, rawURL string) (*Snapshot, error) {
+	query := url.Values{"url": {rawURL}}
+	resp, err := f.fetch(ctx, waybackAvailabilityURL+"?"+query.Encode(), fetchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the Wayback Machine: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var availability struct {
+		ArchivedSnapshots struct {
+			Closest *struct {
+				Available bool   `json:"available"`
+				Timestamp string `json:"timestamp"`
+			} `json:"closest"`
+		} `json:"archived_snapshots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&availability); err != nil {
+		return nil, fmt.Errorf("failed to query the Wayback Machine: %w", err)
+	}
+
+	closest := availability.ArchivedSnapshots.Closest
+	if closest == nil || !closest.Available {
+		return nil, nil
+	}
+	timestamp, err := time.Parse(waybackTimestamp, closest.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the Wayback Machine: invalid timestamp %q", closest.Timestamp)
+	}
+
+	// The id_ modifier serves the capture without the archive's toolbar
+	// and link rewriting
+	return &Snapshot{
+		URL:       fmt.Sprintf("%s/web/%sid_/%s", waybackWebURL, closest.Timestamp, rawURL),
+		Original:  rawURL,
+		Timestamp: timestamp,
+	}, nil
+}
+
+// fetchSnapshot fetches the latest snapshot of url in place of the live
+// page, which failed with cause. cause is returned when there is no
+// snapshot.
+func (f *Fetcher) fetchSnapshot(ctx context.Context, url string, cause error) (*http.Response, error) {
+	snapshot, err := f.LatestSnapshot(ctx, url)
+	if err != nil {
+		return nil, errors.Join(cause, err)
+	}
+	if snapshot == nil {
+		return nil, cause
+	}
+	return f.fetch(ctx, snapshot.URL, fetchOptions{})
+}
+
+// deadLink reports whether err means the page is gone or did not answer in
+// time. The caller's own deadline is excluded, since it leaves no time for
+// a fallback.
+func deadLink(ctx context.Context, err error) bool {
+	var status *StatusError
+	if errors.As(err, &status) {
+		return status.StatusCode == http.StatusNotFound || status.StatusCode == http.StatusGone
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout() && ctx.Err() == nil
+}
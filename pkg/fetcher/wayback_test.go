@@ -0,0 +1,120 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waybackServer fakes the availability API and raw snapshots, archiving
+// only the URLs in snapshots, keyed to their timestamps
+func waybackServer(t *testing.T, snapshots map[string]string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/wayback/available" {
+			timestamp, ok := snapshots[r.URL.Query().Get("url")]
+			if !ok {
+				_, _ = w.Write([]byte(`{"archived_snapshots": {}}`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"archived_snapshots": {"closest": {"available": true, "status": "200", "timestamp": %q}}}`, timestamp)
+			return
+		}
+		_, _ = w.Write([]byte("<title>Archived</title>"))
+	}))
+	t.Cleanup(server.Close)
+
+	availability, web := waybackAvailabilityURL, waybackWebURL
+	waybackAvailabilityURL, waybackWebURL = server.URL+"/wayback/available", server.URL
+	t.Cleanup(func() { waybackAvailabilityURL, waybackWebURL = availability, web })
+}
+
+func TestFetcher_WaybackFallback(t *testing.T) {
+	live := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer live.Close()
+
+	waybackServer(t, map[string]string{
+		live.URL + "/gone":  "20240102030405",
+		live.URL + "/slow":  "20240102030405",
+		live.URL + "/error": "20240102030405",
+		live.URL + "/?p=1":  "20240102030405",
+	})
+
+	tests := []struct {
+		name           string
+		path           string
+		fallback       bool
+		expectSnapshot bool
+		expectedStatus int
+	}{
+		{name: "gone page", path: "/gone", fallback: true, expectSnapshot: true},
+		{name: "gone page with a query", path: "/?p=1", fallback: true, expectSnapshot: true},
+		{name: "timed out page", path: "/slow", fallback: true, expectSnapshot: true},
+		{name: "fallback disabled", path: "/gone", expectedStatus: http.StatusGone},
+		{name: "server error", path: "/error", fallback: true, expectedStatus: http.StatusInternalServerError},
+		{name: "never archived", path: "/missing", fallback: true, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &Fetcher{Client: &http.Client{Timeout: 50 * time.Millisecond}, WaybackFallback: tt.fallback}
+			resp, err := fetcher.Fetch(context.Background(), live.URL+tt.path)
+
+			if !tt.expectSnapshot {
+				var status *StatusError
+				if !errors.As(err, &status) || status.StatusCode != tt.expectedStatus {
+					t.Fatalf("Fetch() error = %v, want status %d", err, tt.expectedStatus)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Fetch() returned error: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			snapshot, ok := SnapshotOf(resp)
+			if !ok {
+				t.Fatalf("SnapshotOf() found no snapshot for %v", resp.Request.URL)
+			}
+			if snapshot.Original != live.URL+tt.path || !snapshot.Timestamp.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+				t.Errorf("SnapshotOf() = %+v, want the 2024-01-02 capture of %s", snapshot, tt.path)
+			}
+			if !strings.Contains(snapshot.URL, "/web/20240102030405id_/") {
+				t.Errorf("Snapshot URL = %v, want the raw capture", snapshot.URL)
+			}
+		})
+	}
+}
+
+func TestSnapshotOf_LiveResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	resp, err := (&Fetcher{}).Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if snapshot, ok := SnapshotOf(resp); ok {
+		t.Errorf("SnapshotOf() = %+v for a live response, want none", snapshot)
+	}
+}
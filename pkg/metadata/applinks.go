@@ -0,0 +1,109 @@
+package metadata
+
+import "strings"
+
+// appLinkPlatforms lists the App Links platforms in display order
+var appLinkPlatforms = []string{
+	"ios",
+	"iphone",
+	"ipad",
+	"android",
+	"windows_phone",
+	"windows",
+	"windows_universal",
+	"web",
+}
+
+// AppLinks represents mobile deep-link metadata declared by a page
+type AppLinks struct {
+	Banner *AppBanner `json:"banner,omitempty"`
+	Links  []AppLink  `json:"links,omitempty"`
+}
+
+// AppBanner represents an Apple Smart App Banner (apple-itunes-app) declaration
+type AppBanner struct {
+	AppID         string `json:"appId"`
+	AppArgument   string `json:"appArgument,omitempty"`
+	AffiliateData string `json:"affiliateData,omitempty"`
+}
+
+// AppLink represents the App Links (al:*) target for a single platform
+type AppLink struct {
+	Platform       string `json:"platform"`
+	URL            string `json:"url,omitempty"`
+	AppStoreID     string `json:"appStoreId,omitempty"`
+	AppName        string `json:"appName,omitempty"`
+	Package        string `json:"package,omitempty"`
+	Class          string `json:"class,omitempty"`
+	ShouldFallback string `json:"shouldFallback,omitempty"`
+}
+
+// AppLinks returns the mobile deep-link metadata, or nil when none is declared
+func (m *Metadata) AppLinks() *AppLinks {
+	data := m.RawProviderData("appLinks")
+	if len(data) == 0 {
+		return nil
+	}
+
+	links := &AppLinks{}
+
+	if values := data["apple-itunes-app"]; len(values) > 0 {
+		links.Banner = parseAppBanner(values[0])
+	}
+
+	first := func(key string) string {
+		if values := data[key]; len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	for _, platform := range appLinkPlatforms {
+		link := AppLink{
+			Platform:       platform,
+			URL:            first(platform + ":url"),
+			AppStoreID:     first(platform + ":app_store_id"),
+			AppName:        first(platform + ":app_name"),
+			Package:        first(platform + ":package"),
+			Class:          first(platform + ":class"),
+			ShouldFallback: first(platform + ":should_fallback"),
+		}
+		if link != (AppLink{Platform: platform}) {
+			links.Links = append(links.Links, link)
+		}
+	}
+
+	if links.Banner == nil && len(links.Links) == 0 {
+		return nil
+	}
+
+	return links
+}
+
+// parseAppBanner parses the comma-separated key=value content of an
+// apple-itunes-app meta tag
+func parseAppBanner(content string) *AppBanner {
+	banner := &AppBanner{}
+
+	for _, part := range strings.Split(content, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "app-id":
+			banner.AppID = strings.TrimSpace(value)
+		case "app-argument":
+			banner.AppArgument = strings.TrimSpace(value)
+		case "affiliate-data":
+			banner.AffiliateData = strings.TrimSpace(value)
+		}
+	}
+
+	if banner.AppID == "" {
+		return nil
+	}
+
+	return banner
+}
@@ -0,0 +1,109 @@
+package metadata
+
+import "testing"
+
+func TestMetadata_AppLinks(t *testing.T) {
+	m := &Metadata{
+		providerData: ProviderData{
+			"appLinks": map[string][]string{
+				"apple-itunes-app":     {"app-id=123456789, app-argument=myapp://article/1"},
+				"ios:url":              {"myapp://article/1"},
+				"ios:app_store_id":     {"123456789"},
+				"ios:app_name":         {"My App"},
+				"android:url":          {"myapp://article/1"},
+				"android:package":      {"com.example.myapp"},
+				"web:should_fallback":  {"false"},
+				"unknown_platform:url": {"ignored://"},
+			},
+		},
+	}
+
+	links := m.AppLinks()
+	if links == nil {
+		t.Fatal("AppLinks() = nil, want non-nil")
+	}
+
+	if links.Banner == nil {
+		t.Fatal("Expected Smart App Banner to be parsed")
+	}
+
+	if links.Banner.AppID != "123456789" {
+		t.Errorf("Banner.AppID = %v, want %v", links.Banner.AppID, "123456789")
+	}
+
+	if links.Banner.AppArgument != "myapp://article/1" {
+		t.Errorf("Banner.AppArgument = %v, want %v", links.Banner.AppArgument, "myapp://article/1")
+	}
+
+	if len(links.Links) != 3 {
+		t.Fatalf("Expected 3 platform links, got %d: %+v", len(links.Links), links.Links)
+	}
+
+	expectedPlatforms := []string{"ios", "android", "web"}
+	for i, platform := range expectedPlatforms {
+		if links.Links[i].Platform != platform {
+			t.Errorf("Links[%d].Platform = %v, want %v", i, links.Links[i].Platform, platform)
+		}
+	}
+
+	if links.Links[0].AppStoreID != "123456789" || links.Links[0].AppName != "My App" {
+		t.Errorf("Unexpected iOS link: %+v", links.Links[0])
+	}
+
+	if links.Links[1].Package != "com.example.myapp" {
+		t.Errorf("Links[1].Package = %v, want %v", links.Links[1].Package, "com.example.myapp")
+	}
+
+	if links.Links[2].ShouldFallback != "false" {
+		t.Errorf("Links[2].ShouldFallback = %v, want %v", links.Links[2].ShouldFallback, "false")
+	}
+}
+
+func TestMetadata_AppLinks_None(t *testing.T) {
+	m := &Metadata{providerData: make(ProviderData)}
+
+	if links := m.AppLinks(); links != nil {
+		t.Errorf("AppLinks() = %+v, want nil", links)
+	}
+}
+
+func TestParseAppBanner(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *AppBanner
+	}{
+		{
+			name:     "app id only",
+			content:  "app-id=123",
+			expected: &AppBanner{AppID: "123"},
+		},
+		{
+			name:     "all fields",
+			content:  "app-id=123, affiliate-data=at=abc, app-argument=myapp://x",
+			expected: &AppBanner{AppID: "123", AffiliateData: "at=abc", AppArgument: "myapp://x"},
+		},
+		{
+			name:     "missing app id",
+			content:  "app-argument=myapp://x",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseAppBanner(tt.content)
+
+			if tt.expected == nil {
+				if result != nil {
+					t.Errorf("parseAppBanner() = %+v, want nil", result)
+				}
+				return
+			}
+
+			if result == nil || *result != *tt.expected {
+				t.Errorf("parseAppBanner() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
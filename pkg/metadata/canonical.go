@@ -0,0 +1,95 @@
+package metadata
+
+import "github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+
+// URLPolicy decides which URL Metadata.URL returns when og:url, the
+// canonical link and the fetched URL disagree
+type URLPolicy int
+
+const (
+	// URLPolicyPriority follows provider priority, so og:url wins over the
+	// canonical link. The fetched URL is not considered.
+	URLPolicyPriority URLPolicy = iota
+
+	// URLPolicyCanonical prefers <link rel="canonical">, then og:url, then
+	// the fetched URL
+	URLPolicyCanonical
+
+	// URLPolicyOpenGraph prefers og:url, then the canonical link, then the
+	// fetched URL
+	URLPolicyOpenGraph
+
+	// URLPolicyFetched prefers the URL the page was fetched from after
+	// redirects (BaseURL), then the canonical link, then og:url
+	URLPolicyFetched
+)
+
+// URLCandidates holds every URL the page could be identified by. Each is
+// resolved against BaseURL and empty when absent.
+type URLCandidates struct {
+	OpenGraph string `json:"openGraph,omitempty"`
+	Canonical string `json:"canonical,omitempty"`
+	Fetched   string `json:"fetched,omitempty"`
+}
+
+// Conflicting reports whether the candidates that are present disagree
+func (c URLCandidates) Conflicting() bool {
+	var first string
+	for _, candidate := range []string{c.OpenGraph, c.Canonical, c.Fetched} {
+		if candidate == "" {
+			continue
+		}
+		if first == "" {
+			first = candidate
+		} else if candidate != first {
+			return true
+		}
+	}
+	return false
+}
+
+// URLCandidates returns og:url, the canonical link and the fetched URL
+func (m *Metadata) URLCandidates() URLCandidates {
+	candidates := URLCandidates{
+		OpenGraph: m.firstRawURL("openGraph"),
+		Canonical: m.firstRawURL("other"),
+	}
+	if m.BaseURL != nil {
+		candidates.Fetched = m.BaseURL.String()
+	}
+	return candidates
+}
+
+// firstRawURL returns a provider's first url value resolved with ResolveURL
+func (m *Metadata) firstRawURL(providerName string) string {
+	if values := m.RawProviderData(providerName)[keys.URL]; len(values) > 0 {
+		return m.ResolveURL(values[0])
+	}
+	return ""
+}
+
+// urlByPolicy resolves the page URL according to URLPolicy
+func (m *Metadata) urlByPolicy() *string {
+	if m.URLPolicy == URLPolicyPriority {
+		return m.resolveValue(keys.URL)
+	}
+
+	candidates := m.URLCandidates()
+
+	var order []string
+	switch m.URLPolicy {
+	case URLPolicyCanonical:
+		order = []string{candidates.Canonical, candidates.OpenGraph, candidates.Fetched}
+	case URLPolicyOpenGraph:
+		order = []string{candidates.OpenGraph, candidates.Canonical, candidates.Fetched}
+	case URLPolicyFetched:
+		order = []string{candidates.Fetched, candidates.Canonical, candidates.OpenGraph}
+	}
+
+	for _, candidate := range order {
+		if candidate != "" {
+			return &candidate
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package metadata
+
+import (
+	"net/url"
+	"testing"
+)
+
+func newCanonicalMetadata() *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "other", priority: 4},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "url", "https://example.com/og")
+	m.AddData("other", "url", "/canonical")
+	m.BaseURL, _ = url.Parse("https://example.com/fetched?utm_source=feed")
+	return m
+}
+
+func TestMetadata_URLCandidates(t *testing.T) {
+	candidates := newCanonicalMetadata().URLCandidates()
+
+	expected := URLCandidates{
+		OpenGraph: "https://example.com/og",
+		Canonical: "https://example.com/canonical",
+		Fetched:   "https://example.com/fetched?utm_source=feed",
+	}
+	if candidates != expected {
+		t.Errorf("URLCandidates() = %+v, want %+v", candidates, expected)
+	}
+
+	if !candidates.Conflicting() {
+		t.Error("Conflicting() = false, want true")
+	}
+}
+
+func TestURLCandidates_Conflicting(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates URLCandidates
+		expected   bool
+	}{
+		{name: "none", candidates: URLCandidates{}, expected: false},
+		{name: "single", candidates: URLCandidates{Canonical: "https://example.com/"}, expected: false},
+		{
+			name:       "agreeing",
+			candidates: URLCandidates{OpenGraph: "https://example.com/", Fetched: "https://example.com/"},
+			expected:   false,
+		},
+		{
+			name:       "disagreeing",
+			candidates: URLCandidates{OpenGraph: "https://example.com/a", Canonical: "https://example.com/b"},
+			expected:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.candidates.Conflicting(); result != tt.expected {
+				t.Errorf("Conflicting() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_URL_Policy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   URLPolicy
+		expected string
+	}{
+		{name: "priority", policy: URLPolicyPriority, expected: "https://example.com/og"},
+		{name: "canonical", policy: URLPolicyCanonical, expected: "https://example.com/canonical"},
+		{name: "open graph", policy: URLPolicyOpenGraph, expected: "https://example.com/og"},
+		{name: "fetched", policy: URLPolicyFetched, expected: "https://example.com/fetched?utm_source=feed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newCanonicalMetadata()
+			m.URLPolicy = tt.policy
+
+			if result := m.URL(); result == nil || *result != tt.expected {
+				t.Errorf("URL() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_URL_PolicyFallsBack(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "openGraph", priority: 1}}}
+	m := NewMetadata(registry)
+	m.BaseURL, _ = url.Parse("https://example.com/fetched")
+	m.URLPolicy = URLPolicyCanonical
+
+	if result := m.URL(); result == nil || *result != "https://example.com/fetched" {
+		t.Errorf("URL() = %v, want the fetched URL when no canonical or og:url exists", result)
+	}
+
+	m.AddData("openGraph", "url", "https://example.com/og")
+	if result := m.URL(); result == nil || *result != "https://example.com/og" {
+		t.Errorf("URL() = %v, want og:url when no canonical link exists", result)
+	}
+}
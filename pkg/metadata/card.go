@@ -0,0 +1,82 @@
+package metadata
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// CardFaviconSize is the icon size, in pixels, NewCard selects a favicon for
+const CardFaviconSize = 32
+
+// Card is the platform-agnostic shape of a link preview, as rendered by chat
+// apps when a URL is shared
+type Card struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	SiteName    string `json:"siteName,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Favicon     string `json:"favicon,omitempty"`
+	Color       string `json:"color,omitempty"`
+}
+
+// NewCard builds a preview card from m. The URL falls back to BaseURL, the
+// site name and title fall back to the URL's host, and the description is
+// truncated to MaxDescriptionLength.
+func NewCard(m *Metadata) *Card {
+	card := &Card{
+		Description: truncate(valueOrEmpty(m.Description()), MaxDescriptionLength),
+		Image:       valueOrEmpty(m.Image()),
+		SiteName:    valueOrEmpty(m.SiteName()),
+		URL:         valueOrEmpty(m.URL()),
+		Favicon:     m.BestFavicon(CardFaviconSize).Href,
+		Color:       strings.TrimSpace(m.firstValue(keys.ThemeColor)),
+	}
+
+	if card.URL == "" && m.BaseURL != nil {
+		card.URL = m.BaseURL.String()
+	}
+
+	if card.SiteName == "" {
+		card.SiteName = hostName(card.URL)
+	}
+
+	card.Title = valueOrEmpty(m.Title())
+	if card.Title == "" {
+		card.Title = card.SiteName
+	}
+
+	return card
+}
+
+// valueOrEmpty dereferences an optional value, returning "" when unset
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(*value)
+}
+
+// hostName returns the host of rawURL without a leading "www.", or "" when
+// rawURL has no host
+func hostName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(u.Hostname(), "www.")
+}
+
+// truncate shortens s to at most limit runes, ending it with an ellipsis
+// when cut
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:limit-1])) + "…"
+}
@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func newCardMetadata(data map[string]map[string]string) *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "meta", priority: 2},
+		&MockProvider{name: "other", priority: 3},
+	}}
+	m := NewMetadata(registry)
+	for provider, values := range data {
+		for key, value := range values {
+			m.AddData(provider, key, value)
+		}
+	}
+	return m
+}
+
+func TestNewCard(t *testing.T) {
+	m := newCardMetadata(map[string]map[string]string{
+		"openGraph": {
+			"title":       "Title",
+			"description": "Description",
+			"image":       "https://example.com/image.jpg",
+			"site_name":   "Example",
+			"url":         "https://example.com/page",
+		},
+		"meta": {"theme-color": " #336699 "},
+	})
+	m.Icons = []*Icon{
+		{Rel: "icon", Href: "https://example.com/16.png", Sizes: "16x16"},
+		{Rel: "icon", Href: "https://example.com/32.png", Sizes: "32x32"},
+	}
+
+	expected := Card{
+		Title:       "Title",
+		Description: "Description",
+		Image:       "https://example.com/image.jpg",
+		SiteName:    "Example",
+		URL:         "https://example.com/page",
+		Favicon:     "https://example.com/32.png",
+		Color:       "#336699",
+	}
+
+	if card := NewCard(m); *card != expected {
+		t.Errorf("NewCard() = %+v, want %+v", *card, expected)
+	}
+}
+
+func TestNewCard_Fallbacks(t *testing.T) {
+	m := newCardMetadata(nil)
+	m.BaseURL, _ = url.Parse("https://www.example.com/page")
+
+	expected := Card{
+		Title:    "example.com",
+		SiteName: "example.com",
+		URL:      "https://www.example.com/page",
+		Favicon:  "https://www.example.com/favicon.ico",
+	}
+
+	if card := NewCard(m); *card != expected {
+		t.Errorf("NewCard() = %+v, want %+v", *card, expected)
+	}
+}
+
+func TestNewCard_TruncatesDescription(t *testing.T) {
+	m := newCardMetadata(map[string]map[string]string{
+		"openGraph": {"description": strings.Repeat("é", MaxDescriptionLength+10)},
+	})
+
+	description := NewCard(m).Description
+	if length := utf8.RuneCountInString(description); length != MaxDescriptionLength {
+		t.Errorf("Description length = %d, want %d", length, MaxDescriptionLength)
+	}
+	if !strings.HasSuffix(description, "…") {
+		t.Errorf("Description = %q, want an ellipsis suffix", description)
+	}
+}
@@ -0,0 +1,25 @@
+package metadata
+
+import "time"
+
+// Clock supplies the current time wherever a timestamp is recorded, so tests
+// can substitute a fixed or simulated time
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface
+type ClockFunc func() time.Time
+
+// Now returns the function's result
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock reports the wall-clock time
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a Clock that always reports t
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
@@ -0,0 +1,24 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedClock(t *testing.T) {
+	fixed := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	clock := FixedClock(fixed)
+
+	if now := clock.Now(); !now.Equal(fixed) {
+		t.Errorf("Now() = %v, want %v", now, fixed)
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+
+	if now.Before(before) || now.After(time.Now()) {
+		t.Errorf("Now() = %v, want the current time", now)
+	}
+}
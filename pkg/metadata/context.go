@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"context"
+	"net/url"
+
+	"golang.org/x/net/html"
+)
+
+// Document describes the page being scraped
+type Document struct {
+	// URL is the address the page was retrieved from, when known
+	URL *url.URL
+
+	// Language is the document's content language (e.g. from <html lang>)
+	Language string
+}
+
+// ContextProvider is an optional extension of MetadataProvider whose scrape
+// receives a context and the document being scraped, so it can resolve
+// relative URLs and stop early on cancellation
+type ContextProvider interface {
+	MetadataProvider
+
+	// ScrapeContext extracts metadata from the given element
+	ScrapeContext(ctx context.Context, node *html.Node, document *Document) *ScrapedData
+}
+
+// ContextRegistry is an optional interface for registries that pass a
+// context and document through to their providers
+type ContextRegistry interface {
+	// ScrapeFromElementContext returns the data from the first provider that
+	// yields data for the element
+	ScrapeFromElementContext(ctx context.Context, node *html.Node, document *Document) *ScrapingResult
+
+	// ScrapeAllFromElementContext returns the data from every provider that
+	// yields data for the element, in priority order
+	ScrapeAllFromElementContext(ctx context.Context, node *html.Node, document *Document) []*ScrapingResult
+}
+
+// providerAdapter presents a v1 MetadataProvider as a ContextProvider
+type providerAdapter struct {
+	MetadataProvider
+}
+
+// ScrapeContext skips extraction once ctx is done and otherwise calls Scrape
+func (a providerAdapter) ScrapeContext(ctx context.Context, node *html.Node, document *Document) *ScrapedData {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return a.Scrape(node)
+}
+
+// AdaptProvider returns provider as a ContextProvider, wrapping providers
+// that only implement the original Scrape method
+func AdaptProvider(provider MetadataProvider) ContextProvider {
+	if contextProvider, ok := provider.(ContextProvider); ok {
+		return contextProvider
+	}
+	return providerAdapter{provider}
+}
+
+// ScrapeWithContext scrapes an element with any provider, passing the
+// context and document along to providers that accept them
+func ScrapeWithContext(ctx context.Context, provider MetadataProvider, node *html.Node, document *Document) *ScrapedData {
+	if document == nil {
+		document = &Document{}
+	}
+	return AdaptProvider(provider).ScrapeContext(ctx, node, document)
+}
@@ -0,0 +1,47 @@
+package metadata
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// MockContextProvider records the document it was scraped with
+type MockContextProvider struct {
+	MockProvider
+	document *Document
+}
+
+func (m *MockContextProvider) ScrapeContext(ctx context.Context, node *html.Node, document *Document) *ScrapedData {
+	m.document = document
+	return &ScrapedData{Key: "url", Value: document.URL.String()}
+}
+
+func TestAdaptProvider(t *testing.T) {
+	contextProvider := &MockContextProvider{MockProvider: MockProvider{name: "v2", priority: 1}}
+	if adapted := AdaptProvider(contextProvider); adapted != contextProvider {
+		t.Error("AdaptProvider() should return context providers unchanged")
+	}
+
+	v1 := &MockProvider{name: "v1", priority: 1}
+	adapted := AdaptProvider(v1)
+	if adapted.Name() != "v1" {
+		t.Errorf("AdaptProvider().Name() = %v, want v1", adapted.Name())
+	}
+}
+
+func TestScrapeWithContext(t *testing.T) {
+	node := &html.Node{Type: html.ElementNode, Data: "meta"}
+	source, _ := url.Parse("https://example.com/page")
+
+	contextProvider := &MockContextProvider{MockProvider: MockProvider{name: "v2", priority: 1}}
+	data := ScrapeWithContext(context.Background(), contextProvider, node, &Document{URL: source, Language: "en"})
+	if data == nil || data.Value != "https://example.com/page" {
+		t.Errorf("ScrapeWithContext() = %v, want source URL", data)
+	}
+	if contextProvider.document.Language != "en" {
+		t.Errorf("Document.Language = %v, want en", contextProvider.document.Language)
+	}
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"slices"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// ValueChange records a key's values before and after
+type ValueChange struct {
+	Old []string `json:"old"`
+	New []string `json:"new"`
+}
+
+// ProviderDiff lists the keys a provider gained, lost or changed
+type ProviderDiff struct {
+	Added   map[string][]string    `json:"added,omitempty"`
+	Removed map[string][]string    `json:"removed,omitempty"`
+	Changed map[string]ValueChange `json:"changed,omitempty"`
+}
+
+// FieldChange records a resolved field before and after. Old or New is nil
+// when the field was unset on that side.
+type FieldChange struct {
+	Field keys.Field `json:"field"`
+	Old   *string    `json:"old,omitempty"`
+	New   *string    `json:"new,omitempty"`
+}
+
+// MetadataDiff describes how one Metadata differs from another
+type MetadataDiff struct {
+	// Providers holds a diff for each provider whose data changed
+	Providers map[string]*ProviderDiff `json:"providers,omitempty"`
+
+	// Fields lists the resolved fields that changed, in keys.Fields order
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// Empty reports whether the diff contains no changes
+func (d *MetadataDiff) Empty() bool {
+	return len(d.Providers) == 0 && len(d.Fields) == 0
+}
+
+// Diff compares a with b, reporting the provider keys b added, removed or
+// changed relative to a along with changes to the resolved fields. A nil
+// Metadata is treated as empty.
+func Diff(a, b *Metadata) *MetadataDiff {
+	if a == nil {
+		a = &Metadata{}
+	}
+	if b == nil {
+		b = &Metadata{}
+	}
+
+	diff := &MetadataDiff{Providers: make(map[string]*ProviderDiff)}
+
+	for name := range a.providerData {
+		if providerDiff := diffProvider(a.providerData[name], b.providerData[name]); providerDiff != nil {
+			diff.Providers[name] = providerDiff
+		}
+	}
+	for name := range b.providerData {
+		if _, seen := a.providerData[name]; seen {
+			continue
+		}
+		if providerDiff := diffProvider(nil, b.providerData[name]); providerDiff != nil {
+			diff.Providers[name] = providerDiff
+		}
+	}
+
+	for _, field := range keys.Fields() {
+		oldValue, newValue := a.Get(field), b.Get(field)
+		if !equalOptional(oldValue, newValue) {
+			diff.Fields = append(diff.Fields, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	return diff
+}
+
+// diffProvider compares one provider's data, returning nil when unchanged
+func diffProvider(oldData, newData map[string][]string) *ProviderDiff {
+	diff := &ProviderDiff{
+		Added:   make(map[string][]string),
+		Removed: make(map[string][]string),
+		Changed: make(map[string]ValueChange),
+	}
+
+	for key, oldValues := range oldData {
+		newValues, exists := newData[key]
+		switch {
+		case !exists:
+			diff.Removed[key] = oldValues
+		case !slices.Equal(oldValues, newValues):
+			diff.Changed[key] = ValueChange{Old: oldValues, New: newValues}
+		}
+	}
+	for key, newValues := range newData {
+		if _, exists := oldData[key]; !exists {
+			diff.Added[key] = newValues
+		}
+	}
+
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// equalOptional reports whether two optional values are equal
+func equalOptional(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,104 @@
+package metadata
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+func newDiffMetadata(data map[string]map[string]string) *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "other", priority: 2},
+	}}
+	m := NewMetadata(registry)
+	for provider, values := range data {
+		for key, value := range values {
+			m.AddData(provider, key, value)
+		}
+	}
+	return m
+}
+
+func TestDiff(t *testing.T) {
+	a := newDiffMetadata(map[string]map[string]string{
+		"openGraph": {"title": "Old Title", "image": "https://example.com/a.jpg"},
+		"other":     {"icon": "/icon.png"},
+	})
+	b := newDiffMetadata(map[string]map[string]string{
+		"openGraph": {"title": "New Title", "description": "Added"},
+		"other":     {"icon": "/icon.png"},
+	})
+
+	diff := Diff(a, b)
+
+	if diff.Empty() {
+		t.Fatal("Empty() = true, want false")
+	}
+
+	if _, changed := diff.Providers["other"]; changed {
+		t.Errorf("Providers[other] = %+v, want no diff for unchanged provider", diff.Providers["other"])
+	}
+
+	openGraph := diff.Providers["openGraph"]
+	if openGraph == nil {
+		t.Fatal("Providers[openGraph] = nil, want a diff")
+	}
+
+	if added := openGraph.Added["description"]; !slices.Equal(added, []string{"Added"}) {
+		t.Errorf("Added[description] = %v, want [Added]", added)
+	}
+
+	if removed := openGraph.Removed["image"]; !slices.Equal(removed, []string{"https://example.com/a.jpg"}) {
+		t.Errorf("Removed[image] = %v, want [https://example.com/a.jpg]", removed)
+	}
+
+	change, ok := openGraph.Changed["title"]
+	if !ok || !slices.Equal(change.Old, []string{"Old Title"}) || !slices.Equal(change.New, []string{"New Title"}) {
+		t.Errorf("Changed[title] = %+v, want Old Title -> New Title", change)
+	}
+
+	fields := make(map[keys.Field]FieldChange)
+	for _, field := range diff.Fields {
+		fields[field.Field] = field
+	}
+
+	if title := fields[keys.FieldTitle]; title.Old == nil || *title.Old != "Old Title" || title.New == nil || *title.New != "New Title" {
+		t.Errorf("Fields[title] = %+v, want Old Title -> New Title", title)
+	}
+
+	if image, ok := fields[keys.FieldImage]; !ok || image.New != nil {
+		t.Errorf("Fields[image] = %+v, want removed image", image)
+	}
+
+	if _, ok := fields[keys.FieldFavicon]; ok {
+		t.Error("Fields contains favicon, want unchanged fields omitted")
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	data := map[string]map[string]string{"openGraph": {"title": "Title"}}
+
+	if diff := Diff(newDiffMetadata(data), newDiffMetadata(data)); !diff.Empty() {
+		t.Errorf("Diff() = %+v, want empty", diff)
+	}
+}
+
+func TestDiff_Nil(t *testing.T) {
+	b := newDiffMetadata(map[string]map[string]string{"openGraph": {"title": "Title"}})
+
+	diff := Diff(nil, b)
+	if added := diff.Providers["openGraph"].Added["title"]; !slices.Equal(added, []string{"Title"}) {
+		t.Errorf("Added[title] = %v, want [Title]", added)
+	}
+
+	diff = Diff(b, nil)
+	if removed := diff.Providers["openGraph"].Removed["title"]; !slices.Equal(removed, []string{"Title"}) {
+		t.Errorf("Removed[title] = %v, want [Title]", removed)
+	}
+
+	if diff := Diff(nil, nil); !diff.Empty() {
+		t.Errorf("Diff(nil, nil) = %+v, want empty", diff)
+	}
+}
@@ -0,0 +1,143 @@
+package metadata
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// DisplayURL pairs the ASCII (punycode) form of a URL, which should be used
+// for fetching, with a Unicode form for showing to people. Warnings flag
+// hosts whose Unicode form could impersonate another domain.
+type DisplayURL struct {
+	ASCII    string   `json:"ascii"`
+	Unicode  string   `json:"unicode"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// latinConfusables are Cyrillic and Greek letters that render like Latin ones
+const latinConfusables = "аеорсухіјѕԁһӏԛԝοντκια"
+
+// displayScripts are the scripts distinguished when checking for homographs
+var displayScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+}
+
+// NewDisplayURL builds the ASCII and Unicode forms of a URL. It returns nil
+// when the URL cannot be parsed or has no host.
+func NewDisplayURL(rawURL string) *DisplayURL {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	host := u.Hostname()
+	asciiHost, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		asciiHost = host
+	}
+
+	unicodeHost, err := idna.Display.ToUnicode(asciiHost)
+	if err != nil {
+		unicodeHost = asciiHost
+	}
+
+	ascii := *u
+	ascii.Host = asciiHost
+	if port := u.Port(); port != "" {
+		ascii.Host = asciiHost + ":" + port
+	}
+	asciiURL := ascii.String()
+
+	return &DisplayURL{
+		ASCII:    asciiURL,
+		Unicode:  strings.Replace(asciiURL, asciiHost, unicodeHost, 1),
+		Warnings: homographWarnings(unicodeHost),
+	}
+}
+
+// IsInternationalized reports whether the host has a distinct Unicode form
+func (d *DisplayURL) IsInternationalized() bool {
+	return d.ASCII != d.Unicode
+}
+
+// DisplayURL returns display helpers for the canonical URL
+func (m *Metadata) DisplayURL() *DisplayURL {
+	if pageURL := m.URL(); pageURL != nil {
+		return NewDisplayURL(*pageURL)
+	}
+	return nil
+}
+
+// homographWarnings inspects each label of a Unicode host for characters
+// that could be mistaken for another domain's
+func homographWarnings(host string) []string {
+	var warnings []string
+
+	for _, label := range strings.Split(host, ".") {
+		scripts := labelScripts(label)
+
+		if len(scripts) > 1 {
+			warnings = append(warnings, fmt.Sprintf("label %q mixes %s scripts", label, strings.Join(scripts, " and ")))
+			continue
+		}
+
+		if len(scripts) == 1 && scripts[0] != "Latin" && isWholeScriptConfusable(label) {
+			warnings = append(warnings, fmt.Sprintf("label %q is written in %s but looks like Latin", label, scripts[0]))
+		}
+	}
+
+	return warnings
+}
+
+// labelScripts returns the distinct scripts used by letters in a label, in
+// order of first appearance
+func labelScripts(label string) []string {
+	var scripts []string
+	seen := make(map[string]bool)
+
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+
+		script := "Other"
+		for _, candidate := range displayScripts {
+			if unicode.Is(candidate.table, r) {
+				script = candidate.name
+				break
+			}
+		}
+
+		if !seen[script] {
+			seen[script] = true
+			scripts = append(scripts, script)
+		}
+	}
+
+	return scripts
+}
+
+// isWholeScriptConfusable reports whether every letter in the label has a
+// Latin look-alike
+func isWholeScriptConfusable(label string) bool {
+	letters := 0
+	for _, r := range label {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if !strings.ContainsRune(latinConfusables, r) {
+			return false
+		}
+	}
+	return letters > 0
+}
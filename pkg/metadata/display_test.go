@@ -0,0 +1,115 @@
+package metadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewDisplayURL(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedASCII   string
+		expectedUnicode string
+		expectWarning   bool
+	}{
+		{
+			name:            "plain ascii host",
+			input:           "https://example.com/path?q=1",
+			expectedASCII:   "https://example.com/path?q=1",
+			expectedUnicode: "https://example.com/path?q=1",
+		},
+		{
+			name:            "punycode host",
+			input:           "https://xn--mnchen-3ya.de/",
+			expectedASCII:   "https://xn--mnchen-3ya.de/",
+			expectedUnicode: "https://münchen.de/",
+		},
+		{
+			name:            "unicode host with port",
+			input:           "http://münchen.de:8080/karte",
+			expectedASCII:   "http://xn--mnchen-3ya.de:8080/karte",
+			expectedUnicode: "http://münchen.de:8080/karte",
+		},
+		{
+			name:            "mixed script homograph",
+			input:           "https://xn--pypal-4ve.com/",
+			expectedASCII:   "https://xn--pypal-4ve.com/",
+			expectedUnicode: "https://pаypal.com/",
+			expectWarning:   true,
+		},
+		{
+			name:            "whole script confusable",
+			input:           "https://xn--80ak6aa92e.com/",
+			expectedASCII:   "https://xn--80ak6aa92e.com/",
+			expectedUnicode: "https://аррӏе.com/",
+			expectWarning:   true,
+		},
+		{
+			name:            "legitimate cyrillic host",
+			input:           "https://xn--d1acpjx3f.xn--p1ai/",
+			expectedASCII:   "https://xn--d1acpjx3f.xn--p1ai/",
+			expectedUnicode: "https://яндекс.рф/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewDisplayURL(tt.input)
+			if result == nil {
+				t.Fatal("NewDisplayURL() = nil, want non-nil")
+			}
+
+			if result.ASCII != tt.expectedASCII {
+				t.Errorf("ASCII = %v, want %v", result.ASCII, tt.expectedASCII)
+			}
+
+			if result.Unicode != tt.expectedUnicode {
+				t.Errorf("Unicode = %v, want %v", result.Unicode, tt.expectedUnicode)
+			}
+
+			if hasWarning := len(result.Warnings) > 0; hasWarning != tt.expectWarning {
+				t.Errorf("Warnings = %v, expectWarning %v", result.Warnings, tt.expectWarning)
+			}
+		})
+	}
+}
+
+func TestNewDisplayURL_Invalid(t *testing.T) {
+	for _, input := range []string{"", "/relative/path", "://bad"} {
+		if result := NewDisplayURL(input); result != nil {
+			t.Errorf("NewDisplayURL(%q) = %+v, want nil", input, result)
+		}
+	}
+}
+
+func TestDisplayURL_IsInternationalized(t *testing.T) {
+	if NewDisplayURL("https://example.com").IsInternationalized() {
+		t.Error("Expected ASCII host not to be internationalized")
+	}
+
+	if !NewDisplayURL("https://xn--mnchen-3ya.de").IsInternationalized() {
+		t.Error("Expected punycode host to be internationalized")
+	}
+}
+
+func TestMetadata_DisplayURL(t *testing.T) {
+	mockProvider := &MockProvider{name: "test", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+
+	if result := m.DisplayURL(); result != nil {
+		t.Errorf("DisplayURL() = %+v, want nil", result)
+	}
+
+	m.AddData("test", "url", "https://xn--pypal-4ve.com/login")
+
+	result := m.DisplayURL()
+	if result == nil {
+		t.Fatal("DisplayURL() = nil, want non-nil")
+	}
+
+	if !strings.Contains(strings.Join(result.Warnings, " "), "Latin and Cyrillic") {
+		t.Errorf("Expected mixed-script warning, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,67 @@
+package metadata
+
+import "github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+
+// FallbackStep is one link in a fallback chain: a key read from a specific
+// provider, or from every provider in priority order when Provider is empty
+type FallbackStep struct {
+	Provider string
+	Key      string
+}
+
+// FallbackChain lists the steps tried, in order, to resolve a field
+type FallbackChain []FallbackStep
+
+// Fallbacks maps fields to the chains that resolve them. Fields without a
+// chain use the built-in provider priority order.
+//
+// For example, to prefer Twitter Card titles over Open Graph and never fall
+// back to the first heading:
+//
+//	m.Fallbacks = metadata.Fallbacks{
+//		keys.FieldTitle: {
+//			{Provider: "twitter", Key: keys.Title},
+//			{Provider: "openGraph", Key: keys.Title},
+//			{Provider: "other", Key: keys.Title},
+//		},
+//	}
+type Fallbacks map[keys.Field]FallbackChain
+
+// fieldValue resolves a field through its configured fallback chain, or with
+// defaultResolve when no chain is configured
+func (m *Metadata) fieldValue(field keys.Field, defaultResolve func() *string) *string {
+	if chain, configured := m.Fallbacks[field]; configured {
+		return m.resolveChain(chain)
+	}
+	return defaultResolve()
+}
+
+// resolveChain returns the value from the first step that yields one
+func (m *Metadata) resolveChain(chain FallbackChain) *string {
+	for _, step := range chain {
+		if step.Provider == "" {
+			if value := m.resolveValue(step.Key); value != nil {
+				return value
+			}
+			continue
+		}
+
+		if values := m.stepValues(step); len(values) > 0 {
+			return &values[0]
+		}
+	}
+	return nil
+}
+
+// stepValues returns a named provider's values for a step's key. Providers
+// missing from the registry are read from the raw provider data.
+func (m *Metadata) stepValues(step FallbackStep) []string {
+	if m.registry != nil {
+		for _, provider := range m.registry.GetProviders() {
+			if provider.Name() == step.Provider {
+				return m.providerValues(provider, step.Key)
+			}
+		}
+	}
+	return m.providerData[step.Provider][step.Key]
+}
@@ -0,0 +1,85 @@
+package metadata
+
+import (
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+func newFallbackMetadata() *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "twitter", priority: 2},
+		&MockProvider{name: "other", priority: 3},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "title", "OG Title")
+	m.AddData("twitter", "title", "Twitter Title")
+	m.AddData("other", "title", "Page Title")
+	m.AddData("other", "firstHeading", "Heading")
+	m.AddData("twitter", "description", "Twitter Description")
+	return m
+}
+
+func TestMetadata_Fallbacks(t *testing.T) {
+	tests := []struct {
+		name      string
+		fallbacks Fallbacks
+		field     keys.Field
+		expected  *string
+	}{
+		{
+			name:     "built-in order without a chain",
+			field:    keys.FieldTitle,
+			expected: stringPtr("OG Title"),
+		},
+		{
+			name: "provider-specific steps",
+			fallbacks: Fallbacks{keys.FieldTitle: {
+				{Provider: "twitter", Key: keys.Title},
+				{Provider: "openGraph", Key: keys.Title},
+			}},
+			field:    keys.FieldTitle,
+			expected: stringPtr("Twitter Title"),
+		},
+		{
+			name: "falls through missing steps",
+			fallbacks: Fallbacks{keys.FieldTitle: {
+				{Provider: "missing", Key: keys.Title},
+				{Provider: "other", Key: keys.FirstHeading},
+			}},
+			field:    keys.FieldTitle,
+			expected: stringPtr("Heading"),
+		},
+		{
+			name:      "any-provider step follows priority",
+			fallbacks: Fallbacks{keys.FieldDescription: {{Key: keys.Description}}},
+			field:     keys.FieldDescription,
+			expected:  stringPtr("Twitter Description"),
+		},
+		{
+			name:      "configured chain is authoritative",
+			fallbacks: Fallbacks{keys.FieldTitle: {{Provider: "openGraph", Key: keys.Description}}},
+			field:     keys.FieldTitle,
+			expected:  nil,
+		},
+		{
+			name:      "chains apply per field",
+			fallbacks: Fallbacks{keys.FieldDescription: {{Provider: "other", Key: keys.Title}}},
+			field:     keys.FieldTitle,
+			expected:  stringPtr("OG Title"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFallbackMetadata()
+			m.Fallbacks = tt.fallbacks
+
+			result := m.Get(tt.field)
+			if (result == nil) != (tt.expected == nil) || (result != nil && *result != *tt.expected) {
+				t.Errorf("Get(%s) = %v, want %v", tt.field, result, tt.expected)
+			}
+		})
+	}
+}
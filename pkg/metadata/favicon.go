@@ -0,0 +1,143 @@
+package metadata
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Icon rel values
+const (
+	IconRelIcon             = "icon"
+	IconRelShortcutIcon     = "shortcut icon"
+	IconRelAppleTouchIcon   = "apple-touch-icon"
+	IconRelAppleTouchLegacy = "apple-touch-icon-precomposed"
+	IconRelMaskIcon         = "mask-icon"
+)
+
+// appleTouchIconSize is the size iOS assumes for an apple-touch-icon
+// declared without a sizes attribute
+const appleTouchIconSize = 180
+
+// iconCandidate is one size an icon is available at
+type iconCandidate struct {
+	icon     *Icon
+	size     int  // largest dimension in pixels, 0 when unknown
+	scalable bool // sizes="any" or an SVG icon
+	mask     bool // monochrome Safari pinned-tab icon
+}
+
+// BestFavicon returns the declared icon closest to preferredSize pixels.
+// Icons at least as large as preferredSize are preferred over smaller ones
+// since downscaling looks better than upscaling, and scalable icons match any
+// size. Icons with unknown sizes come after sized ones and mask icons are
+// only used as a last resort. A preferredSize of 0 or less selects the
+// largest icon. When the page declares no icons, the Favicon fallback is
+// returned.
+func (m *Metadata) BestFavicon(preferredSize int) *Icon {
+	var best *iconCandidate
+	for _, icon := range m.Icons {
+		for _, candidate := range iconCandidates(icon) {
+			if best == nil || candidate.betterThan(best, preferredSize) {
+				best = &candidate
+			}
+		}
+	}
+
+	if best == nil {
+		return &Icon{Rel: IconRelIcon, Href: m.Favicon()}
+	}
+
+	icon := *best.icon
+	icon.Href = m.ResolveURL(icon.Href)
+	return &icon
+}
+
+// iconCandidates expands an icon into one candidate per declared size
+func iconCandidates(icon *Icon) []iconCandidate {
+	rel := strings.ToLower(icon.Rel)
+	base := iconCandidate{
+		icon:     icon,
+		mask:     rel == IconRelMaskIcon,
+		scalable: strings.EqualFold(icon.Type, "image/svg+xml"),
+	}
+
+	var candidates []iconCandidate
+	for _, size := range strings.Fields(strings.ToLower(icon.Sizes)) {
+		candidate := base
+		if size == "any" {
+			candidate.scalable = true
+		} else if candidate.size = parseIconSize(size); candidate.size == 0 {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if len(candidates) == 0 {
+		if rel == IconRelAppleTouchIcon || rel == IconRelAppleTouchLegacy {
+			base.size = appleTouchIconSize
+		}
+		candidates = append(candidates, base)
+	}
+	return candidates
+}
+
+// parseIconSize parses a WIDTHxHEIGHT size token, returning the larger
+// dimension or 0 when the token is malformed
+func parseIconSize(size string) int {
+	width, height, found := strings.Cut(size, "x")
+	if !found {
+		return 0
+	}
+
+	w, err := strconv.Atoi(width)
+	if err != nil || w <= 0 {
+		return 0
+	}
+	h, err := strconv.Atoi(height)
+	if err != nil || h <= 0 {
+		return 0
+	}
+	return max(w, h)
+}
+
+// tier ranks candidates before size is considered: sized or scalable icons
+// first, then icons of unknown size, then mask icons
+func (c *iconCandidate) tier() int {
+	switch {
+	case c.mask:
+		return 2
+	case c.scalable || c.size > 0:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// betterThan reports whether c is a closer match for preferredSize than
+// other. Ties keep the earlier icon.
+func (c *iconCandidate) betterThan(other *iconCandidate, preferredSize int) bool {
+	if c.tier() != other.tier() {
+		return c.tier() < other.tier()
+	}
+	if c.scalable || other.scalable {
+		return c.scalable && !other.scalable
+	}
+
+	if preferredSize <= 0 {
+		return c.size > other.size
+	}
+
+	cSmaller, otherSmaller := c.size < preferredSize, other.size < preferredSize
+	if cSmaller != otherSmaller {
+		return !cSmaller
+	}
+	return abs(c.size-preferredSize) < abs(other.size-preferredSize)
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,99 @@
+package metadata
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestMetadata_BestFavicon(t *testing.T) {
+	icons := []*Icon{
+		{Rel: "icon", Href: "/favicon-16.png", Sizes: "16x16"},
+		{Rel: "icon", Href: "/favicon-multi.ico", Sizes: "32x32 48x48"},
+		{Rel: "apple-touch-icon", Href: "/apple-touch-icon.png"},
+		{Rel: "mask-icon", Href: "/mask.svg"},
+	}
+
+	tests := []struct {
+		name          string
+		icons         []*Icon
+		preferredSize int
+		expected      string
+	}{
+		{name: "exact match", icons: icons, preferredSize: 16, expected: "/favicon-16.png"},
+		{name: "multi-size icon", icons: icons, preferredSize: 48, expected: "/favicon-multi.ico"},
+		{name: "prefers larger over smaller", icons: icons, preferredSize: 64, expected: "/apple-touch-icon.png"},
+		{name: "closest smaller when none larger", icons: icons, preferredSize: 512, expected: "/apple-touch-icon.png"},
+		{name: "largest when no preference", icons: icons, preferredSize: 0, expected: "/apple-touch-icon.png"},
+		{
+			name:          "scalable icon matches any size",
+			icons:         append([]*Icon{{Rel: "icon", Href: "/icon.svg", Type: "image/svg+xml"}}, icons...),
+			preferredSize: 64,
+			expected:      "/icon.svg",
+		},
+		{
+			name:          "sized icon beats unknown size",
+			icons:         []*Icon{{Rel: "icon", Href: "/unknown.ico"}, {Rel: "icon", Href: "/tiny.png", Sizes: "8x8"}},
+			preferredSize: 32,
+			expected:      "/tiny.png",
+		},
+		{
+			name:          "mask icon as last resort",
+			icons:         []*Icon{{Rel: "mask-icon", Href: "/mask.svg"}},
+			preferredSize: 32,
+			expected:      "/mask.svg",
+		},
+		{
+			name:          "malformed sizes treated as unknown",
+			icons:         []*Icon{{Rel: "icon", Href: "/bad.png", Sizes: "big 0x0"}},
+			preferredSize: 32,
+			expected:      "/bad.png",
+		},
+		{name: "falls back to default favicon", icons: nil, preferredSize: 32, expected: "/favicon.ico"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMetadata(&MockRegistry{})
+			m.Icons = tt.icons
+
+			result := m.BestFavicon(tt.preferredSize)
+			if result == nil || result.Href != tt.expected {
+				t.Errorf("BestFavicon(%d) = %+v, want %v", tt.preferredSize, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_BestFavicon_BaseURL(t *testing.T) {
+	m := NewMetadata(&MockRegistry{})
+	m.BaseURL, _ = url.Parse("https://example.com/")
+	m.Icons = []*Icon{{Rel: "icon", Href: "/favicon.png", Sizes: "32x32"}}
+
+	result := m.BestFavicon(32)
+	if result.Href != "https://example.com/favicon.png" {
+		t.Errorf("BestFavicon() = %v, want %v", result.Href, "https://example.com/favicon.png")
+	}
+
+	if m.Icons[0].Href != "/favicon.png" {
+		t.Errorf("BestFavicon() modified Icons: %v", m.Icons[0].Href)
+	}
+}
+
+func TestParseIconSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int
+	}{
+		{"16x16", 16},
+		{"32x64", 64},
+		{"16", 0},
+		{"axb", 0},
+		{"-1x16", 0},
+	}
+
+	for _, tt := range tests {
+		if result := parseIconSize(tt.size); result != tt.expected {
+			t.Errorf("parseIconSize(%q) = %v, want %v", tt.size, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package metadata
+
+// Heading is an <h1>–<h6> element in the document outline
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+}
+
+// AddHeading appends a heading to the document outline
+func (m *Metadata) AddHeading(level int, text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.headings = append(m.headings, Heading{Level: level, Text: text})
+}
+
+// Headings returns the document outline: every h1–h6 heading in document
+// order
+func (m *Metadata) Headings() []Heading {
+	headings := make([]Heading, len(m.headings))
+	copy(headings, m.headings)
+	return headings
+}
@@ -0,0 +1,31 @@
+package metadata
+
+import "testing"
+
+func TestMetadata_Headings(t *testing.T) {
+	m := NewMetadata(&MockRegistry{})
+
+	if headings := m.Headings(); len(headings) != 0 {
+		t.Errorf("Headings() = %v, want none", headings)
+	}
+
+	m.AddHeading(1, "Title")
+	m.AddHeading(2, "Section")
+	m.AddHeading(3, "Subsection")
+
+	expected := []Heading{{1, "Title"}, {2, "Section"}, {3, "Subsection"}}
+	headings := m.Headings()
+	if len(headings) != len(expected) {
+		t.Fatalf("Headings() = %v, want %v", headings, expected)
+	}
+	for i := range expected {
+		if headings[i] != expected[i] {
+			t.Errorf("Headings()[%d] = %v, want %v", i, headings[i], expected[i])
+		}
+	}
+
+	headings[0].Text = "Changed"
+	if m.Headings()[0].Text != "Title" {
+		t.Error("Expected Headings() to return a copy")
+	}
+}
@@ -0,0 +1,95 @@
+package metadata
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Image represents an image declared by the page along with its structured
+// properties (og:image:width, og:image:alt, ...)
+type Image struct {
+	URL       string `json:"url"`
+	SecureURL string `json:"secureUrl,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	Alt       string `json:"alt,omitempty"`
+}
+
+// Images returns the Open Graph images in document order, each grouped with
+// the sub-properties that follow it. Twitter Card images are returned when
+// the page declares no Open Graph image, and BodyImages when it declares
+// neither. Once images have been probed, only ProbedImages are returned.
+func (m *Metadata) Images() []Image {
+	if m.imagesSuppressed() {
+		return nil
+	}
+	if m.ProbedImages != nil {
+		return slices.Clone(m.ProbedImages)
+	}
+
+	images := m.collectImages("openGraph")
+	if len(images) == 0 {
+		images = m.collectImages("twitter")
+	}
+	if len(images) == 0 && len(m.BodyImages) > 0 {
+		images = slices.Clone(m.BodyImages)
+	}
+
+	for i := range images {
+		images[i].URL = m.ResolveURL(images[i].URL)
+		if images[i].SecureURL != "" {
+			images[i].SecureURL = m.ResolveURL(images[i].SecureURL)
+		}
+	}
+	return images
+}
+
+// collectImages groups a provider's image properties in document order. A
+// new image starts at each image (or image:src) entry; image:url and
+// image:secure_url describe the current image and only start one when
+// there is none yet. Other sub-properties that appear before any image are
+// ignored.
+func (m *Metadata) collectImages(providerName string) []Image {
+	var images []Image
+
+	for _, entry := range m.entries {
+		if entry.provider != providerName {
+			continue
+		}
+
+		switch entry.key {
+		case keys.Image, keys.ImageSrc:
+			images = append(images, Image{URL: entry.value})
+			continue
+		case keys.ImageURL, keys.ImageSecureURL:
+			if len(images) == 0 {
+				images = append(images, Image{URL: entry.value})
+			}
+		}
+		if len(images) == 0 {
+			continue
+		}
+
+		current := &images[len(images)-1]
+		switch entry.key {
+		case keys.ImageURL:
+			current.URL = entry.value
+		case keys.ImageSecureURL:
+			current.SecureURL = entry.value
+		case keys.ImageType:
+			current.Type = entry.value
+		case keys.ImageWidth:
+			current.Width, _ = strconv.Atoi(strings.TrimSpace(entry.value))
+		case keys.ImageHeight:
+			current.Height, _ = strconv.Atoi(strings.TrimSpace(entry.value))
+		case keys.ImageAlt:
+			current.Alt = entry.value
+		}
+	}
+
+	return images
+}
@@ -0,0 +1,171 @@
+package metadata
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestMetadata_Images(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "twitter", priority: 2},
+	}}
+	m := NewMetadata(registry)
+
+	// Sub-property before any image is ignored
+	m.AddData("openGraph", "image:width", "10")
+	m.AddData("openGraph", "image", "https://example.com/a.jpg")
+	m.AddData("openGraph", "image:secure_url", "https://secure.example.com/a.jpg")
+	m.AddData("openGraph", "image:type", "image/jpeg")
+	m.AddData("openGraph", "image:width", "1200")
+	m.AddData("openGraph", "image:height", "630")
+	m.AddData("openGraph", "title", "Interleaved Title")
+	m.AddData("twitter", "image", "https://example.com/twitter.jpg")
+	m.AddData("openGraph", "image:alt", "First image")
+	m.AddData("openGraph", "image", "https://example.com/b.png")
+	m.AddData("openGraph", "image:width", "not-a-number")
+
+	images := m.Images()
+	if len(images) != 2 {
+		t.Fatalf("Expected 2 images, got %d: %+v", len(images), images)
+	}
+
+	expectedFirst := Image{
+		URL:       "https://example.com/a.jpg",
+		SecureURL: "https://secure.example.com/a.jpg",
+		Type:      "image/jpeg",
+		Width:     1200,
+		Height:    630,
+		Alt:       "First image",
+	}
+	if images[0] != expectedFirst {
+		t.Errorf("Images()[0] = %+v, want %+v", images[0], expectedFirst)
+	}
+
+	expectedSecond := Image{URL: "https://example.com/b.png"}
+	if images[1] != expectedSecond {
+		t.Errorf("Images()[1] = %+v, want %+v", images[1], expectedSecond)
+	}
+}
+
+func TestMetadata_Images_URLProperties(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries [][2]string
+		want    []Image
+	}{
+		{
+			name:    "image:url repeats the current image",
+			entries: [][2]string{{"image", "https://example.com/a.jpg"}, {"image:url", "https://example.com/a.jpg"}, {"image:alt", "A"}},
+			want:    []Image{{URL: "https://example.com/a.jpg", Alt: "A"}},
+		},
+		{
+			name:    "image:url starts the first image",
+			entries: [][2]string{{"image:url", "https://example.com/a.jpg"}, {"image:width", "100"}, {"image", "https://example.com/b.jpg"}},
+			want:    []Image{{URL: "https://example.com/a.jpg", Width: 100}, {URL: "https://example.com/b.jpg"}},
+		},
+		{
+			name:    "image:secure_url describes the current image",
+			entries: [][2]string{{"image", "https://example.com/a.jpg"}, {"image", "http://example.com/b.jpg"}, {"image:secure_url", "https://example.com/b.jpg"}},
+			want:    []Image{{URL: "https://example.com/a.jpg"}, {URL: "http://example.com/b.jpg", SecureURL: "https://example.com/b.jpg"}},
+		},
+		{
+			name:    "image:secure_url starts the first image",
+			entries: [][2]string{{"image:secure_url", "https://example.com/a.jpg"}},
+			want:    []Image{{URL: "https://example.com/a.jpg", SecureURL: "https://example.com/a.jpg"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMetadata(&MockRegistry{providers: []MetadataProvider{&MockProvider{name: "openGraph", priority: 1}}})
+			for _, entry := range tt.entries {
+				m.AddData("openGraph", entry[0], entry[1])
+			}
+
+			images := m.Images()
+			if len(images) != len(tt.want) {
+				t.Fatalf("Images() = %+v, want %+v", images, tt.want)
+			}
+			for i := range images {
+				if images[i] != tt.want[i] {
+					t.Errorf("Images()[%d] = %+v, want %+v", i, images[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMetadata_Images_TwitterFallback(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "twitter", priority: 2},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("twitter", "image", "https://example.com/twitter.jpg")
+	m.AddData("twitter", "image:alt", "Twitter image")
+
+	images := m.Images()
+	if len(images) != 1 {
+		t.Fatalf("Expected 1 image, got %d", len(images))
+	}
+
+	if images[0].URL != "https://example.com/twitter.jpg" || images[0].Alt != "Twitter image" {
+		t.Errorf("Images()[0] = %+v", images[0])
+	}
+}
+
+func TestMetadata_Images_BodyFallback(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "twitter", priority: 2},
+	}}
+	m := NewMetadata(registry)
+	m.BaseURL, _ = url.Parse("https://example.com/post")
+	m.BodyImages = []Image{{URL: "/body.jpg", Width: 640, Alt: "Body image"}}
+
+	images := m.Images()
+	if len(images) != 1 || images[0].URL != "https://example.com/body.jpg" || images[0].Width != 640 {
+		t.Fatalf("Images() = %+v, want the resolved body image", images)
+	}
+
+	if m.BodyImages[0].URL != "/body.jpg" {
+		t.Errorf("BodyImages[0].URL = %v, want it left unresolved", m.BodyImages[0].URL)
+	}
+
+	m.AddData("twitter", "image", "https://example.com/twitter.jpg")
+	if images := m.Images(); len(images) != 1 || images[0].URL != "https://example.com/twitter.jpg" {
+		t.Errorf("Images() = %+v, want only the declared image", images)
+	}
+}
+
+func TestMetadata_Images_None(t *testing.T) {
+	m := &Metadata{providerData: make(ProviderData)}
+
+	if images := m.Images(); len(images) != 0 {
+		t.Errorf("Images() = %+v, want empty", images)
+	}
+}
+
+func TestMetadata_Images_Probed(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "image", "https://example.com/dead.jpg")
+	m.AddData("openGraph", "image", "https://example.com/live.png")
+
+	m.ProbedImages = []Image{{URL: "https://example.com/live.png", Type: "image/png", Width: 1200, Height: 630}}
+	if images := m.Images(); len(images) != 1 || images[0] != m.ProbedImages[0] {
+		t.Errorf("Images() = %+v, want only the probed image", images)
+	}
+	if image := m.Image(); image == nil || *image != "https://example.com/live.png" {
+		t.Errorf("Image() = %v, want %v", image, "https://example.com/live.png")
+	}
+
+	m.ProbedImages = []Image{}
+	if images := m.Images(); len(images) != 0 {
+		t.Errorf("Images() = %+v, want none once every image failed probing", images)
+	}
+	if image := m.Image(); image != nil {
+		t.Errorf("Image() = %v, want nil", *image)
+	}
+}
@@ -0,0 +1,212 @@
+package metadata
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// metadataJSON is the serialized form of Metadata. The schema is:
+//
+//	{
+//	  "title": "...",              // resolved values, omitted when unset
+//	  "description": "...",
+//	  "image": "...",
+//	  "images": [{"url": "...", "type": "...", "width": 1200, ...}],
+//	  "url": "...",
+//	  "siteName": "...",
+//	  "locale": "...",
+//	  "type": "...",
+//	  "author": "...",
+//	  "keywords": ["..."],
+//	  "publishedTime": "RFC 3339",
+//	  "modifiedTime": "RFC 3339",
+//	  "favicon": "...",            // always present
+//	  "finalUrl": "...",           // omitted when unknown
+//	  "baseHref": "...",           // the page's <base href>, omitted when unset
+//	  "redirects": ["..."],        // omitted when not redirected
+//	  "archive": {"url": "...", "timestamp": "RFC 3339"}, // omitted when live
+//	  "providers": {"openGraph": {"title": ["..."]}, ...}, // informational
+//	  "entries": [{"provider": "openGraph", "key": "title", "value": "...",
+//	               "element": "...", "attribute": "..."}], // document order
+//	  "feeds": [{"title": "...", "type": "...", "href": "..."}],
+//	  "icons": [{"rel": "...", "href": "...", "type": "...", "sizes": "..."}],
+//	  "headings": [{"level": 1, "text": "..."}],
+//	  "wordCount": 1200,           // omitted when the body has no text
+//	  "headers": {"Content-Type": ["..."]}, // only serializedHeaders
+//	  "scrapedAt": "RFC 3339"      // omitted when unknown
+//	}
+//
+// Resolved values and providers are informational; only entries, feeds,
+// icons, headings, the word count, redirects, the final URL and base href,
+// the archive, headers and the scrape time are read back by UnmarshalJSON.
+type metadataJSON struct {
+	Title         *string      `json:"title,omitempty"`
+	Description   *string      `json:"description,omitempty"`
+	Image         *string      `json:"image,omitempty"`
+	Images        []Image      `json:"images,omitempty"`
+	URL           *string      `json:"url,omitempty"`
+	SiteName      *string      `json:"siteName,omitempty"`
+	Locale        *string      `json:"locale,omitempty"`
+	Type          *string      `json:"type,omitempty"`
+	Author        *string      `json:"author,omitempty"`
+	Keywords      []string     `json:"keywords,omitempty"`
+	PublishedTime *time.Time   `json:"publishedTime,omitempty"`
+	ModifiedTime  *time.Time   `json:"modifiedTime,omitempty"`
+	Favicon       string       `json:"favicon"`
+	FinalURL      *string      `json:"finalUrl,omitempty"`
+	BaseHref      string       `json:"baseHref,omitempty"`
+	Redirects     []string     `json:"redirects,omitempty"`
+	Archive       *Archive     `json:"archive,omitempty"`
+	Providers     ProviderData `json:"providers"`
+	Entries       []entryJSON  `json:"entries"`
+	Feeds         []*Feed      `json:"feeds"`
+	Icons         []*Icon      `json:"icons"`
+	Headings      []Heading    `json:"headings"`
+	WordCount     int          `json:"wordCount,omitempty"`
+	Headers       http.Header  `json:"headers,omitempty"`
+	ScrapedAt     *time.Time   `json:"scrapedAt,omitempty"`
+}
+
+// entryJSON is one scraped value and where it came from. Entries are
+// written in document order, which the providers map loses, so grouped
+// values such as each og:image and its own sub-properties survive a round
+// trip.
+type entryJSON struct {
+	Source
+	Value string `json:"value"`
+}
+
+// serializedHeaders are the response headers MarshalJSON writes: those the
+// library reads. Others, such as Set-Cookie or Authorization, can carry
+// credentials and are left out so snapshots are safe to store.
+var serializedHeaders = []string{"Content-Type", "Content-Language", "Link", "X-Robots-Tag"}
+
+// MarshalJSON serializes the resolved fields alongside the raw provider
+// data, feeds and icons
+func (m *Metadata) MarshalJSON() ([]byte, error) {
+	feeds := m.Feeds
+	if feeds == nil {
+		feeds = make([]*Feed, 0)
+	}
+
+	icons := m.Icons
+	if icons == nil {
+		icons = make([]*Icon, 0)
+	}
+
+	providers := m.providerData
+	if providers == nil {
+		providers = make(ProviderData)
+	}
+
+	entries := make([]entryJSON, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entryJSON{Source: entry.source(), Value: entry.value})
+	}
+
+	var scrapedAt *time.Time
+	if !m.ScrapedAt.IsZero() {
+		scrapedAt = &m.ScrapedAt
+	}
+
+	return json.Marshal(metadataJSON{
+		Title:         m.Title(),
+		Description:   m.Description(),
+		Image:         m.Image(),
+		Images:        m.Images(),
+		URL:           m.URL(),
+		SiteName:      m.SiteName(),
+		Locale:        m.Locale(),
+		Type:          m.Type(),
+		Author:        m.Author(),
+		Keywords:      m.Keywords(),
+		PublishedTime: m.PublishedTime(),
+		ModifiedTime:  m.ModifiedTime(),
+		Favicon:       m.Favicon(),
+		FinalURL:      m.FinalURL(),
+		BaseHref:      m.BaseHref,
+		Redirects:     m.Redirects,
+		Archive:       m.Archive,
+		Providers:     providers,
+		Entries:       entries,
+		Feeds:         feeds,
+		Icons:         icons,
+		Headings:      m.Headings(),
+		WordCount:     m.WordCount(),
+		Headers:       keptHeaders(m.Headers),
+		ScrapedAt:     scrapedAt,
+	})
+}
+
+// UnmarshalJSON restores provider data, feeds, icons, headings, the word
+// count, redirects, the final URL (as BaseURL) and base href, so relative
+// values resolve as they did when scraped, the archive, headers and the
+// scrape time. The
+// registry is not serialized, so unmarshal into a Metadata created with
+// NewMetadata for the resolving accessors (Title, Images, ...) to work
+// afterwards.
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	var decoded metadataJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Entries == nil {
+		return errors.New("metadata JSON has no entries")
+	}
+
+	m.providerData = make(ProviderData)
+	m.entries = nil
+	if m.registry != nil {
+		for _, provider := range m.registry.GetProviders() {
+			m.providerData[provider.Name()] = make(map[string][]string)
+		}
+	}
+
+	for _, entry := range decoded.Entries {
+		m.AddSourcedData(entry.Source, entry.Value)
+	}
+
+	m.Feeds = decoded.Feeds
+	if m.Feeds == nil {
+		m.Feeds = make([]*Feed, 0)
+	}
+	m.Icons = decoded.Icons
+	if m.Icons == nil {
+		m.Icons = make([]*Icon, 0)
+	}
+	m.headings = decoded.Headings
+	m.wordCount = decoded.WordCount
+	m.Redirects = decoded.Redirects
+	m.BaseURL = nil
+	if decoded.FinalURL != nil {
+		m.BaseURL, _ = url.Parse(*decoded.FinalURL)
+	}
+	m.BaseHref = decoded.BaseHref
+	m.Archive = decoded.Archive
+	m.Headers = decoded.Headers
+	m.ScrapedAt = time.Time{}
+	if decoded.ScrapedAt != nil {
+		m.ScrapedAt = *decoded.ScrapedAt
+	}
+
+	return nil
+}
+
+// keptHeaders returns the serializedHeaders of header, or nil when it has
+// none of them
+func keptHeaders(header http.Header) http.Header {
+	var kept http.Header
+	for _, name := range serializedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			if kept == nil {
+				kept = make(http.Header)
+			}
+			kept[name] = slices.Clone(values)
+		}
+	}
+	return kept
+}
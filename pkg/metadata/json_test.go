@@ -0,0 +1,322 @@
+package metadata
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newJSONTestMetadata() *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "other", priority: 4},
+	}}
+	return NewMetadata(registry)
+}
+
+func TestMetadata_MarshalJSON(t *testing.T) {
+	m := newJSONTestMetadata()
+	m.AddData("openGraph", "title", "OG Title")
+	m.AddData("openGraph", "image", "https://example.com/a.jpg")
+	m.AddData("other", "title", "Page Title")
+	title := "Feed"
+	m.Feeds = append(m.Feeds, &Feed{Title: &title, Type: "application/rss+xml", Href: "/feed.xml"})
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON: %v", err)
+	}
+
+	if decoded["title"] != "OG Title" {
+		t.Errorf("title = %v, want OG Title", decoded["title"])
+	}
+
+	if decoded["favicon"] != "/favicon.ico" {
+		t.Errorf("favicon = %v, want /favicon.ico", decoded["favicon"])
+	}
+
+	if images, ok := decoded["images"].([]any); !ok || len(images) != 1 {
+		t.Errorf("images = %v, want 1 image", decoded["images"])
+	}
+
+	if _, exists := decoded["description"]; exists {
+		t.Error("Expected unset description to be omitted")
+	}
+
+	providers, ok := decoded["providers"].(map[string]any)
+	if !ok || providers["other"] == nil {
+		t.Errorf("providers = %v, want raw data for each provider", decoded["providers"])
+	}
+
+	if feeds, ok := decoded["feeds"].([]any); !ok || len(feeds) != 1 {
+		t.Errorf("feeds = %v, want 1 feed", decoded["feeds"])
+	}
+}
+
+func TestMetadata_MarshalJSON_Stable(t *testing.T) {
+	m := newJSONTestMetadata()
+	m.AddData("openGraph", "title", "Title")
+	m.AddData("openGraph", "description", "Description")
+	m.AddData("other", "icon", "/icon.png")
+
+	first, _ := json.Marshal(m)
+	for i := 0; i < 10; i++ {
+		if next, _ := json.Marshal(m); string(next) != string(first) {
+			t.Fatalf("MarshalJSON() is not stable:\n%s\n%s", first, next)
+		}
+	}
+}
+
+func TestMetadata_UnmarshalJSON(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.AddData("openGraph", "title", "OG Title")
+	original.AddData("openGraph", "image", "https://example.com/a.jpg")
+	original.AddData("openGraph", "image:width", "100")
+	original.AddData("openGraph", "image", "https://example.com/b.jpg")
+	original.AddData("openGraph", "image:width", "200")
+	original.Feeds = append(original.Feeds, &Feed{Type: "application/atom+xml", Href: "/atom.xml"})
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+
+	if title := restored.Title(); title == nil || *title != "OG Title" {
+		t.Errorf("Title() = %v, want OG Title", title)
+	}
+
+	images := restored.Images()
+	if len(images) != 2 || images[0].Width != 100 || images[1].Width != 200 {
+		t.Errorf("Images() = %+v, want two images with widths 100 and 200", images)
+	}
+
+	if len(restored.Feeds) != 1 || restored.Feeds[0].Href != "/atom.xml" {
+		t.Errorf("Feeds = %v, want 1 feed", restored.Feeds)
+	}
+}
+
+func TestMetadata_JSON_ImageOrder(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.AddData("openGraph", "image", "https://example.com/a.jpg")
+	original.AddData("openGraph", "image:width", "100")
+	original.AddData("openGraph", "image", "https://example.com/b.jpg")
+	original.AddData("openGraph", "image:alt", "B alt")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+
+	images := restored.Images()
+	if len(images) != 2 {
+		t.Fatalf("Images() = %+v, want 2 images", images)
+	}
+	if images[0].Width != 100 || images[0].Alt != "" {
+		t.Errorf("Images()[0] = %+v, want width 100 and no alt", images[0])
+	}
+	if images[1].Width != 0 || images[1].Alt != "B alt" {
+		t.Errorf("Images()[1] = %+v, want alt B alt and no width", images[1])
+	}
+}
+
+func TestMetadata_UnmarshalJSON_WithoutEntries(t *testing.T) {
+	m := newJSONTestMetadata()
+	data := `{"providers": {"openGraph": {"title": ["OG Title"]}}}`
+	if err := json.Unmarshal([]byte(data), m); err == nil {
+		t.Error("UnmarshalJSON() expected error without entries")
+	}
+
+	// An empty page still round-trips
+	empty, err := json.Marshal(newJSONTestMetadata())
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+	if err := json.Unmarshal(empty, m); err != nil {
+		t.Errorf("UnmarshalJSON() of an empty page returned error: %v", err)
+	}
+}
+
+func TestMetadata_JSON_BaseHref(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.BaseURL, _ = url.Parse("https://example.com/blog/post")
+	original.BaseHref = "https://cdn.example.com/assets/"
+	original.AddData("openGraph", "image", "cover.jpg")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+
+	if restored.BaseHref != original.BaseHref {
+		t.Errorf("BaseHref = %q, want %q", restored.BaseHref, original.BaseHref)
+	}
+	if image := restored.Image(); image == nil || *image != "https://cdn.example.com/assets/cover.jpg" {
+		t.Errorf("Image() = %v, want it resolved against the base href", image)
+	}
+	if favicon := restored.Favicon(); favicon != original.Favicon() {
+		t.Errorf("Favicon() = %v, want %v", favicon, original.Favicon())
+	}
+
+	if data, _ := json.Marshal(newJSONTestMetadata()); strings.Contains(string(data), "baseHref") {
+		t.Errorf("MarshalJSON() = %s, want baseHref omitted when unset", data)
+	}
+}
+
+func TestMetadata_UnmarshalJSON_Invalid(t *testing.T) {
+	m := newJSONTestMetadata()
+	if err := json.NewDecoder(strings.NewReader(`{"providers": []}`)).Decode(m); err == nil {
+		t.Error("UnmarshalJSON() expected error for invalid providers")
+	}
+}
+
+func TestMetadata_JSON_ScrapedAt(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.ScrapedAt = time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"scrapedAt":"2024-03-15T10:30:00Z"`) {
+		t.Errorf("MarshalJSON() = %s, want scrapedAt", data)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+	if !restored.ScrapedAt.Equal(original.ScrapedAt) {
+		t.Errorf("ScrapedAt = %v, want %v", restored.ScrapedAt, original.ScrapedAt)
+	}
+
+	if data, _ := json.Marshal(newJSONTestMetadata()); strings.Contains(string(data), "scrapedAt") {
+		t.Errorf("MarshalJSON() = %s, want scrapedAt omitted when unknown", data)
+	}
+}
+
+func TestMetadata_JSON_WordCount(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.SetWordCount(450)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+	if count := restored.WordCount(); count != 450 {
+		t.Errorf("WordCount() = %v, want %v", count, 450)
+	}
+
+	if data, _ := json.Marshal(newJSONTestMetadata()); strings.Contains(string(data), "wordCount") {
+		t.Errorf("MarshalJSON() = %s, want wordCount omitted when zero", data)
+	}
+}
+
+func TestMetadata_JSON_Headers(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.Headers = http.Header{
+		"Content-Type":  {"text/html; charset=utf-8"},
+		"X-Robots-Tag":  {"noindex"},
+		"Set-Cookie":    {"session=SECRET123; Path=/"},
+		"Authorization": {"Bearer SECRET123"},
+		"Server":        {"nginx"},
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+	if strings.Contains(string(data), "SECRET123") || strings.Contains(string(data), "nginx") {
+		t.Errorf("MarshalJSON() = %s, want only the headers the library reads", data)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+	if len(restored.Headers) != 2 || restored.Headers.Get("X-Robots-Tag") != "noindex" || restored.Headers.Get("Content-Type") != "text/html; charset=utf-8" {
+		t.Errorf("Headers = %v, want Content-Type and X-Robots-Tag", restored.Headers)
+	}
+
+	if data, _ := json.Marshal(newJSONTestMetadata()); strings.Contains(string(data), "headers") {
+		t.Errorf("MarshalJSON() = %s, want headers omitted when there are none", data)
+	}
+}
+
+func TestMetadata_JSON_Redirects(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.BaseURL, _ = url.Parse("https://example.com/landed")
+	original.Redirects = []string{"http://example.com/start"}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+	if !strings.Contains(string(data), `"finalUrl":"https://example.com/landed"`) {
+		t.Errorf("MarshalJSON() = %s, want the final URL", data)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+	if len(restored.Redirects) != 1 || restored.Redirects[0] != "http://example.com/start" {
+		t.Errorf("Redirects = %v, want [http://example.com/start]", restored.Redirects)
+	}
+	if finalURL := restored.FinalURL(); finalURL == nil || *finalURL != "https://example.com/landed" {
+		t.Errorf("FinalURL() = %v, want the restored final URL", finalURL)
+	}
+}
+
+func TestMetadata_JSON_Archive(t *testing.T) {
+	original := newJSONTestMetadata()
+	original.Archive = &Archive{
+		URL:       "https://web.archive.org/web/20240102030405id_/https://example.com/",
+		Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("MarshalJSON() returned error: %v", err)
+	}
+
+	restored := newJSONTestMetadata()
+	if err := json.Unmarshal(data, restored); err != nil {
+		t.Fatalf("UnmarshalJSON() returned error: %v", err)
+	}
+	if restored.Archive == nil || restored.Archive.URL != original.Archive.URL || !restored.Archive.Timestamp.Equal(original.Archive.Timestamp) {
+		t.Errorf("Archive = %+v, want %+v", restored.Archive, original.Archive)
+	}
+
+	if data, _ := json.Marshal(newJSONTestMetadata()); strings.Contains(string(data), "archive") {
+		t.Errorf("MarshalJSON() = %s, want archive omitted when live", data)
+	}
+}
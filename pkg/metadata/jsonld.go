@@ -0,0 +1,68 @@
+package metadata
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// JSONLDValues returns the values of a property across the top-level objects
+// of a JSON-LD document (including @graph members) in document order.
+// Object values such as Person or ImageObject are reduced to their name,
+// url, or @id.
+func JSONLDValues(document string, property string) []string {
+	var root any
+	if err := json.Unmarshal([]byte(document), &root); err != nil {
+		return nil
+	}
+
+	var values []string
+	for _, object := range jsonLDObjects(root) {
+		values = append(values, jsonLDStrings(object[property])...)
+	}
+	return values
+}
+
+// jsonLDObjects flattens top-level arrays and @graph containers into a list
+// of objects
+func jsonLDObjects(node any) []map[string]any {
+	switch value := node.(type) {
+	case []any:
+		var objects []map[string]any
+		for _, item := range value {
+			objects = append(objects, jsonLDObjects(item)...)
+		}
+		return objects
+	case map[string]any:
+		objects := []map[string]any{value}
+		if graph, exists := value["@graph"]; exists {
+			objects = append(objects, jsonLDObjects(graph)...)
+		}
+		return objects
+	}
+	return nil
+}
+
+// jsonLDStrings converts a property value into strings
+func jsonLDStrings(node any) []string {
+	switch value := node.(type) {
+	case string:
+		if value != "" {
+			return []string{value}
+		}
+	case float64:
+		return []string{strconv.FormatFloat(value, 'f', -1, 64)}
+	case []any:
+		var values []string
+		for _, item := range value {
+			values = append(values, jsonLDStrings(item)...)
+		}
+		return values
+	case map[string]any:
+		for _, key := range []string{"name", "url", "@id"} {
+			if text, ok := value[key].(string); ok && text != "" {
+				return []string{text}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package metadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONLDValues(t *testing.T) {
+	document := `{
+		"@context": "https://schema.org",
+		"@graph": [
+			{"@type": "WebSite", "name": "Example Site"},
+			{
+				"@type": "NewsArticle",
+				"headline": "Graph Headline",
+				"author": [{"@type": "Person", "name": "Jane Doe"}, {"@type": "Person", "url": "https://example.com/john"}],
+				"image": {"@type": "ImageObject", "url": "https://example.com/a.jpg"},
+				"wordCount": 1200
+			}
+		]
+	}`
+
+	tests := []struct {
+		property string
+		expected []string
+	}{
+		{"headline", []string{"Graph Headline"}},
+		{"author", []string{"Jane Doe", "https://example.com/john"}},
+		{"image", []string{"https://example.com/a.jpg"}},
+		{"wordCount", []string{"1200"}},
+		{"name", []string{"Example Site"}},
+		{"missing", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			result := JSONLDValues(document, tt.property)
+			if strings.Join(result, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("JSONLDValues() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJSONLDValues_TopLevelArray(t *testing.T) {
+	document := `[{"@type": "Article", "datePublished": "2024-01-01"}, {"@type": "Organization", "name": "Org"}]`
+
+	if result := JSONLDValues(document, "datePublished"); len(result) != 1 || result[0] != "2024-01-01" {
+		t.Errorf("JSONLDValues() = %v, want [2024-01-01]", result)
+	}
+}
+
+func TestJSONLDValues_InvalidDocument(t *testing.T) {
+	if result := JSONLDValues("{not json", "name"); result != nil {
+		t.Errorf("JSONLDValues() = %v, want nil", result)
+	}
+}
@@ -0,0 +1,118 @@
+// Package keys defines the well-known keys providers store scraped values
+// under, and the fields a Metadata result can resolve.
+package keys
+
+// Keys shared across providers. A provider stores a value under one of
+// these keys when the value means the same thing regardless of its source.
+const (
+	Title        = "title"
+	FirstHeading = "firstHeading"
+	Description  = "description"
+	Image        = "image"
+	URL          = "url"
+	SiteName     = "site_name"
+	Site         = "site"
+	Icon         = "icon"
+	ShortcutIcon = "shortcut icon"
+	Charset      = "charset"
+	Lang         = "lang"
+	Locale       = "locale"
+	Type         = "type"
+	Author       = "author"
+	Creator      = "creator"
+	Keywords     = "keywords"
+	ThemeColor   = "theme-color"
+
+	// Canonical is the key for <link rel="canonical">, which is stored as
+	// the page URL
+	Canonical = URL
+
+	PublishedTime = "published_time"
+	ModifiedTime  = "modified_time"
+	UpdatedTime   = "updated_time"
+)
+
+// Namespaced keys stored verbatim by the standard meta provider
+const (
+	ArticleAuthor        = "article:author"
+	ArticleTag           = "article:tag"
+	ArticlePublishedTime = "article:published_time"
+	ArticleModifiedTime  = "article:modified_time"
+	LocaleAlternate      = "locale:alternate"
+)
+
+// Structured image properties, stored by the Open Graph and Twitter Card
+// providers after their image entry
+const (
+	ImageURL       = "image:url"
+	ImageSrc       = "image:src"
+	ImageSecureURL = "image:secure_url"
+	ImageType      = "image:type"
+	ImageWidth     = "image:width"
+	ImageHeight    = "image:height"
+	ImageAlt       = "image:alt"
+)
+
+// http-equiv and security declarations, keyed by lowercased header name
+const (
+	ContentLanguage       = "content-language"
+	LastModified          = "last-modified"
+	ContentSecurityPolicy = "content-security-policy"
+	PermissionsPolicy     = "permissions-policy"
+	Referrer              = "referrer"
+	Refresh               = "refresh"
+	Robots                = "robots"
+)
+
+// Twitter Card keys
+const (
+	// Card is the card type, such as summary or summary_large_image
+	Card = "card"
+)
+
+// Google News keys
+const (
+	NewsKeywords      = "news_keywords"
+	Standout          = "standout"
+	SyndicationSource = "syndication-source"
+	OriginalSource    = "original-source"
+)
+
+// Field identifies a value a Metadata result can resolve across providers
+type Field string
+
+// Resolvable fields
+const (
+	FieldTitle         Field = "title"
+	FieldDescription   Field = "description"
+	FieldImage         Field = "image"
+	FieldURL           Field = "url"
+	FieldSiteName      Field = "siteName"
+	FieldFavicon       Field = "favicon"
+	FieldLocale        Field = "locale"
+	FieldType          Field = "type"
+	FieldAuthor        Field = "author"
+	FieldKeywords      Field = "keywords"
+	FieldCharset       Field = "charset"
+	FieldPublishedTime Field = "publishedTime"
+	FieldModifiedTime  Field = "modifiedTime"
+)
+
+// Fields returns every resolvable field in display order
+func Fields() []Field {
+	return []Field{
+		FieldTitle,
+		FieldDescription,
+		FieldImage,
+		FieldURL,
+		FieldSiteName,
+		FieldFavicon,
+		FieldLocale,
+		FieldType,
+		FieldAuthor,
+		FieldKeywords,
+		FieldCharset,
+		FieldPublishedTime,
+		FieldModifiedTime,
+	}
+}
@@ -0,0 +1,31 @@
+package keys
+
+import "testing"
+
+func TestFields(t *testing.T) {
+	fields := Fields()
+	if len(fields) == 0 {
+		t.Fatal("Fields() returned no fields")
+	}
+
+	seen := make(map[Field]bool)
+	for _, field := range fields {
+		if field == "" {
+			t.Error("Fields() contains an empty field")
+		}
+		if seen[field] {
+			t.Errorf("Fields() contains duplicate field %q", field)
+		}
+		seen[field] = true
+	}
+
+	if fields[0] != FieldTitle {
+		t.Errorf("Fields()[0] = %v, want %v", fields[0], FieldTitle)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	if Canonical != URL {
+		t.Errorf("Canonical = %q, want %q", Canonical, URL)
+	}
+}
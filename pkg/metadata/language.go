@@ -0,0 +1,75 @@
+package metadata
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// minLanguageMatches is how many stopwords text needs before DetectLanguage
+// trusts its guess
+const minLanguageMatches = 5
+
+// languageStopwords are frequent function words that identify a language
+// from a small sample of text
+var languageStopwords = map[string][]string{
+	"de": {"der", "die", "und", "das", "ist", "nicht", "mit", "den", "ein", "eine", "auf", "ich", "sich", "auch", "wir"},
+	"en": {"the", "and", "is", "of", "to", "that", "with", "for", "this", "are", "was", "you", "have", "it", "not"},
+	"es": {"el", "los", "las", "del", "que", "y", "es", "por", "una", "con", "para", "como", "pero", "más", "está"},
+	"fr": {"le", "les", "des", "et", "est", "une", "du", "que", "pour", "dans", "avec", "pas", "sur", "qui", "nous"},
+	"it": {"il", "gli", "che", "è", "della", "di", "per", "una", "sono", "con", "non", "anche", "questo", "nel", "più"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "op", "met", "voor", "zijn", "ook", "wij", "maar"},
+	"pt": {"o", "os", "as", "da", "do", "que", "e", "não", "uma", "com", "para", "por", "mais", "são", "está"},
+}
+
+// DetectLanguage guesses the language of text, returning an ISO 639-1 code
+// or "" when the text is too short or ambiguous. It recognizes de, en, es,
+// fr, it, nl and pt.
+func DetectLanguage(text string) string {
+	counts := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		counts[word]++
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for _, language := range slices.Sorted(maps.Keys(languageStopwords)) {
+		score := 0
+		for _, stopword := range languageStopwords[language] {
+			score += counts[stopword]
+		}
+
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = language, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+
+	if bestScore < minLanguageMatches || bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
+
+// SetDetectedLanguage records the language detected from the page text
+func (m *Metadata) SetDetectedLanguage(language string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.detectedLanguage = language
+}
+
+// DetectedLanguage returns the language detected from the page text, or nil
+// when detection was not run or was inconclusive. It is only set for pages
+// that declare no language; use Locale for the declared one.
+func (m *Metadata) DetectedLanguage() *string {
+	if m.detectedLanguage == "" {
+		return nil
+	}
+	language := m.detectedLanguage
+	return &language
+}
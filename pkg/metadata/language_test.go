@@ -0,0 +1,62 @@
+package metadata
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "english",
+			text:     "The quick brown fox jumps over the lazy dog, and this is the story of what it was like to be that dog.",
+			expected: "en",
+		},
+		{
+			name:     "spanish",
+			text:     "El perro de los vecinos es muy simpático y juega con las niñas del barrio por la tarde, pero no por la noche.",
+			expected: "es",
+		},
+		{
+			name:     "french",
+			text:     "Le chat est dans le jardin avec les enfants, et nous pensons que les fleurs sont belles pour une fois.",
+			expected: "fr",
+		},
+		{
+			name:     "german",
+			text:     "Der Hund und die Katze sind nicht immer Freunde, aber das ist auch nicht schlimm, denn wir haben eine Lösung.",
+			expected: "de",
+		},
+		{
+			name:     "too short",
+			text:     "The end",
+			expected: "",
+		},
+		{
+			name:     "no text",
+			text:     "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DetectLanguage(tt.text); result != tt.expected {
+				t.Errorf("DetectLanguage() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_DetectedLanguage(t *testing.T) {
+	m := &Metadata{providerData: make(ProviderData)}
+	if language := m.DetectedLanguage(); language != nil {
+		t.Errorf("DetectedLanguage() = %v, want nil", *language)
+	}
+
+	m.SetDetectedLanguage("fr")
+	if language := m.DetectedLanguage(); language == nil || *language != "fr" {
+		t.Errorf("DetectedLanguage() = %v, want %q", language, "fr")
+	}
+}
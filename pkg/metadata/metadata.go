@@ -1,10 +1,94 @@
 package metadata
 
-// Metadata represents the scraped metadata from a webpage
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Metadata represents the scraped metadata from a webpage. AddData and
+// AddSourcedData may be called from concurrent goroutines; the accessors do
+// not lock, so read only once population has finished.
 type Metadata struct {
-	providerData ProviderData
-	registry     Registry
-	Feeds        []*Feed
+	mu               sync.Mutex
+	providerData     ProviderData
+	entries          []dataEntry
+	headings         []Heading
+	wordCount        int
+	detectedLanguage string
+	registry         Registry
+	Feeds            []*Feed
+
+	// Icons holds every icon link the page declares, in document order
+	Icons []*Icon
+
+	// Headers holds the HTTP response headers the page was served with, when
+	// known. Directives such as X-Robots-Tag are read from here.
+	Headers http.Header
+
+	// RespectRobots suppresses image fields when the page opts out of image
+	// previews via max-image-preview:none or noimageindex
+	RespectRobots bool
+
+	// BaseURL is the URL relative links are resolved against, typically the
+	// page URL. When set, Image, Images, Favicon and URL return absolute
+	// URLs, and the scraper resolves feed hrefs as it records them.
+	BaseURL *url.URL
+
+	// Redirects holds the URLs requested before reaching BaseURL, in order,
+	// when the page was fetched through redirects
+	Redirects []string
+
+	// BaseHref is the document's <base href>, as declared. Relative links
+	// are resolved against it, itself resolved against BaseURL.
+	BaseHref string
+
+	// BodyDescription is the first meaningful paragraph of the body, used by
+	// Description when the page declares no description. The scraper only
+	// sets it when asked to (see scraper.ScrapeOptions).
+	BodyDescription string
+
+	// BodyImages are <img> elements from the body, returned by Images when
+	// the page declares no Open Graph or Twitter Card image. The scraper only
+	// sets them when asked to (see scraper.ScrapeOptions).
+	BodyImages []Image
+
+	// ProbedImages, when non-nil, replaces the images returned by Images and
+	// Image with those verified to exist, typed and sized by fetching them
+	// (see fetcher.ProbeImages)
+	ProbedImages []Image
+
+	// Archive is the archived copy the page was scraped from when the live
+	// page was unavailable; nil when scraped live
+	Archive *Archive
+
+	// ScrapedAt is when the page was scraped; zero when unknown
+	ScrapedAt time.Time
+
+	// URLPolicy decides which URL URL returns when og:url, the canonical
+	// link and the fetched URL disagree
+	URLPolicy URLPolicy
+
+	// Fallbacks overrides how individual fields are resolved. Title,
+	// Description, Image, URL, SiteName, Locale, Type and Charset consult it
+	// before the built-in order.
+	Fallbacks Fallbacks
+}
+
+// dataEntry records a single piece of scraped data in document order, so
+// related keys (e.g. og:image and og:image:width) can be correlated
+type dataEntry struct {
+	provider  string
+	key       string
+	value     string
+	element   string
+	attribute string
 }
 
 // NewMetadata creates a new Metadata instance
@@ -13,6 +97,7 @@ func NewMetadata(registry Registry) *Metadata {
 		providerData: make(ProviderData),
 		registry:     registry,
 		Feeds:        make([]*Feed, 0),
+		Icons:        make([]*Icon, 0),
 	}
 
 	// Initialize provider data maps
@@ -25,71 +110,406 @@ func NewMetadata(registry Registry) *Metadata {
 
 // AddData adds scraped data to the metadata
 func (m *Metadata) AddData(providerName, key, value string) {
-	if m.providerData[providerName] == nil {
-		m.providerData[providerName] = make(map[string][]string)
+	m.AddSourcedData(Source{Provider: providerName, Key: key}, value)
+}
+
+// AddSourcedData adds scraped data along with where it came from, so
+// ResolveWithSource can report it later
+func (m *Metadata) AddSourcedData(source Source, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.providerData[source.Provider] == nil {
+		m.providerData[source.Provider] = make(map[string][]string)
 	}
 
-	data := m.providerData[providerName]
-	data[key] = append(data[key], value)
+	data := m.providerData[source.Provider]
+	data[source.Key] = append(data[source.Key], value)
+
+	m.entries = append(m.entries, dataEntry{
+		provider:  source.Provider,
+		key:       source.Key,
+		value:     value,
+		element:   source.Element,
+		attribute: source.Attribute,
+	})
 }
 
-// resolveValue resolves a value using the provider registry
+// resolveValue resolves a value using the provider registry. The value is
+// copied so callers cannot write through to the stored data.
 func (m *Metadata) resolveValue(key string) *string {
 	if m.registry == nil {
 		return nil
 	}
-	return m.registry.ResolveValue(key, m.providerData)
+	if value := m.registry.ResolveValue(key, m.providerData); value != nil {
+		resolved := *value
+		return &resolved
+	}
+	return nil
+}
+
+// providerValues returns every value a provider holds for a key
+func (m *Metadata) providerValues(provider MetadataProvider, key string) []string {
+	data := m.providerData[provider.Name()]
+
+	if valuesProvider, ok := provider.(ValuesProvider); ok {
+		return valuesProvider.GetValues(key, data)
+	}
+
+	if values := data[key]; len(values) > 0 {
+		return values
+	}
+
+	if value := provider.GetValue(key, data); value != nil {
+		return []string{*value}
+	}
+	return nil
 }
 
 // Favicon returns the favicon URL with fallback
 func (m *Metadata) Favicon() string {
-	if icon := m.resolveValue("icon"); icon != nil {
-		return *icon
+	if icon := m.resolveValue(keys.Icon); icon != nil {
+		return m.ResolveURL(*icon)
+	}
+	if shortcutIcon := m.resolveValue(keys.ShortcutIcon); shortcutIcon != nil {
+		return m.ResolveURL(*shortcutIcon)
+	}
+	return m.ResolveURL("/favicon.ico")
+}
+
+// ResolveURL resolves a possibly relative reference against BaseHref and
+// BaseURL. The reference is returned unchanged when there is no base URL or
+// it cannot be parsed.
+func (m *Metadata) ResolveURL(ref string) string {
+	base := m.linkBase()
+	if base == nil {
+		return ref
+	}
+
+	parsed, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+	return base.ResolveReference(parsed).String()
+}
+
+// linkBase returns the URL relative links resolve against: BaseHref
+// resolved against BaseURL, or BaseURL alone when BaseHref is unset or
+// unusable
+func (m *Metadata) linkBase() *url.URL {
+	if m.BaseHref == "" {
+		return m.BaseURL
+	}
+
+	href, err := url.Parse(strings.TrimSpace(m.BaseHref))
+	if err != nil {
+		return m.BaseURL
+	}
+	if m.BaseURL != nil {
+		return m.BaseURL.ResolveReference(href)
 	}
-	if shortcutIcon := m.resolveValue("shortcut icon"); shortcutIcon != nil {
-		return *shortcutIcon
+	if href.IsAbs() {
+		return href
 	}
-	return "/favicon.ico"
+	return nil
+}
+
+// resolveURLValue resolves an optional URL value against BaseURL
+func (m *Metadata) resolveURLValue(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	resolved := m.ResolveURL(*value)
+	return &resolved
 }
 
 // Title returns the page title
 func (m *Metadata) Title() *string {
-	if title := m.resolveValue("title"); title != nil {
-		return title
+	return m.fieldValue(keys.FieldTitle, func() *string {
+		if title := m.resolveValue(keys.Title); title != nil {
+			return title
+		}
+		return m.resolveValue(keys.FirstHeading)
+	})
+}
+
+// authorKeys are the keys that identify an author, checked in order within
+// each provider
+var authorKeys = []string{keys.ArticleAuthor, keys.Creator, keys.Author}
+
+// Author returns the primary author, respecting provider priority
+func (m *Metadata) Author() *string {
+	if authors := m.Authors(); len(authors) > 0 {
+		return &authors[0]
+	}
+	return nil
+}
+
+// Authors returns every distinct author across article:author,
+// twitter:creator, the author meta tag and JSON-LD author objects, ordered
+// by provider priority
+func (m *Metadata) Authors() []string {
+	var authors []string
+	seen := make(map[string]bool)
+
+	for _, author := range m.collectValues(authorKeys) {
+		if author = strings.TrimSpace(author); author != "" && !seen[author] {
+			seen[author] = true
+			authors = append(authors, author)
+		}
+	}
+
+	return authors
+}
+
+// keywordKeys are the keys that carry page keywords or tags
+var keywordKeys = []string{keys.Keywords, keys.ArticleTag}
+
+// Keywords returns the page keywords and article tags as a trimmed,
+// de-duplicated slice, keywords first and then tags
+func (m *Metadata) Keywords() []string {
+	var keywords []string
+	seen := make(map[string]bool)
+
+	for _, value := range m.collectValues(keywordKeys) {
+		for _, keyword := range splitList(value) {
+			// Keywords differing only by case are considered duplicates
+			if folded := strings.ToLower(keyword); !seen[folded] {
+				seen[folded] = true
+				keywords = append(keywords, keyword)
+			}
+		}
 	}
-	return m.resolveValue("firstHeading")
+
+	return keywords
 }
 
-// Description returns the page description
+// collectValues returns every value held for the given keys, ordered by
+// provider priority and then by key
+func (m *Metadata) collectValues(keys []string) []string {
+	if m.registry == nil {
+		return nil
+	}
+
+	var values []string
+	for _, provider := range m.registry.GetProviders() {
+		for _, key := range keys {
+			values = append(values, m.providerValues(provider, key)...)
+		}
+	}
+	return values
+}
+
+// Description returns the page description, falling back to
+// BodyDescription
 func (m *Metadata) Description() *string {
-	return m.resolveValue("description")
+	return m.fieldValue(keys.FieldDescription, func() *string {
+		if description := m.resolveValue(keys.Description); description != nil {
+			return description
+		}
+		if m.BodyDescription != "" {
+			description := m.BodyDescription
+			return &description
+		}
+		return nil
+	})
 }
 
-// Image returns the page image URL
+// Image returns the primary page image URL. Use Images for every declared
+// image along with its structured properties.
 func (m *Metadata) Image() *string {
-	return m.resolveValue("image")
+	if m.imagesSuppressed() {
+		return nil
+	}
+	return m.resolveURLValue(m.fieldValue(keys.FieldImage, func() *string {
+		if m.ProbedImages != nil {
+			if len(m.ProbedImages) == 0 {
+				return nil
+			}
+			image := m.ProbedImages[0].URL
+			return &image
+		}
+		return m.resolveValue(keys.Image)
+	}))
 }
 
-// URL returns the canonical URL
+// URL returns the page URL, choosing between og:url, the canonical link and
+// the fetched URL according to URLPolicy
 func (m *Metadata) URL() *string {
-	return m.resolveValue("url")
+	return m.resolveURLValue(m.fieldValue(keys.FieldURL, m.urlByPolicy))
+}
+
+// FinalURL returns the URL the page was actually served from, after any
+// redirects, or nil when unknown
+func (m *Metadata) FinalURL() *string {
+	if m.BaseURL == nil {
+		return nil
+	}
+	finalURL := m.BaseURL.String()
+	return &finalURL
 }
 
 // SiteName returns the site name
 func (m *Metadata) SiteName() *string {
-	if siteName := m.resolveValue("site_name"); siteName != nil {
-		return siteName
+	return m.fieldValue(keys.FieldSiteName, func() *string {
+		if siteName := m.resolveValue(keys.SiteName); siteName != nil {
+			return siteName
+		}
+		// Twitter uses 'site' instead of 'site_name'
+		return m.resolveValue(keys.Site)
+	})
+}
+
+// Locale returns the page locale, preferring og:locale over the root
+// element's lang attribute and the content-language declaration
+func (m *Metadata) Locale() *string {
+	return m.fieldValue(keys.FieldLocale, func() *string {
+		if locale := m.resolveValue(keys.Locale); locale != nil {
+			return locale
+		}
+		if lang := m.resolveValue(keys.Lang); lang != nil {
+			return lang
+		}
+		if languages := m.resolveValue(keys.ContentLanguage); languages != nil {
+			// content-language may list several languages; the first is primary
+			if items := splitList(*languages); len(items) > 0 {
+				return &items[0]
+			}
+		}
+		return nil
+	})
+}
+
+// AlternateLocales returns the other locales the page is available in
+func (m *Metadata) AlternateLocales() []string {
+	return slices.Clone(m.RawProviderData("openGraph")[keys.LocaleAlternate])
+}
+
+// Charset returns the character encoding declared by the document
+func (m *Metadata) Charset() *string {
+	return m.fieldValue(keys.FieldCharset, func() *string {
+		return m.resolveValue(keys.Charset)
+	})
+}
+
+// NewsKeywords returns the Google News keywords as a trimmed slice
+func (m *Metadata) NewsKeywords() []string {
+	if keywords := m.resolveValue(keys.NewsKeywords); keywords != nil {
+		return splitList(*keywords)
 	}
-	// Twitter uses 'site' instead of 'site_name'
-	return m.resolveValue("site")
+	return nil
+}
+
+// Standout returns the URLs of articles flagged as standout journalism
+func (m *Metadata) Standout() []string {
+	return slices.Clone(m.RawProviderData("news")[keys.Standout])
 }
 
-// GetProviderData returns the raw provider data for a specific provider
+// SyndicationSource returns the URL of the original syndicated article
+func (m *Metadata) SyndicationSource() *string {
+	return m.resolveValue(keys.SyndicationSource)
+}
+
+// OriginalSource returns the URL of the article that first broke the story
+func (m *Metadata) OriginalSource() *string {
+	return m.resolveValue(keys.OriginalSource)
+}
+
+// GetProviderData returns a copy of the raw data for a specific provider, so
+// changes to it do not affect the scraped result
 func (m *Metadata) GetProviderData(providerName string) map[string][]string {
-	if data, exists := m.providerData[providerName]; exists {
-		return data
+	data := m.providerData[providerName]
+
+	copied := make(map[string][]string, len(data))
+	for key, values := range data {
+		copied[key] = slices.Clone(values)
+	}
+	return copied
+}
+
+// RawProviderData returns the provider's data without copying, or nil when
+// the provider holds none. The map is shared with the Metadata and must not
+// be modified.
+func (m *Metadata) RawProviderData(providerName string) map[string][]string {
+	return m.providerData[providerName]
+}
+
+// Fields returns every key held by any provider, sorted and de-duplicated
+func (m *Metadata) Fields() []string {
+	seen := make(map[string]bool)
+	fields := make([]string, 0)
+
+	for _, data := range m.providerData {
+		for key, values := range data {
+			if len(values) > 0 && !seen[key] {
+				seen[key] = true
+				fields = append(fields, key)
+			}
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
+// ToMap returns a flat map of each field to its resolved value. Fields that
+// no provider resolves (e.g. raw JSON-LD blocks) are omitted.
+func (m *Metadata) ToMap() map[string]string {
+	flat := make(map[string]string)
+	for _, field := range m.Fields() {
+		if value := m.resolveValue(field); value != nil {
+			flat[field] = *value
+		}
+	}
+	return flat
+}
+
+// Get resolves a field by name, so consumers can enumerate keys.Fields()
+// instead of calling each accessor. Times are formatted as RFC 3339 and
+// keywords are joined with ", ".
+func (m *Metadata) Get(field keys.Field) *string {
+	switch field {
+	case keys.FieldTitle:
+		return m.Title()
+	case keys.FieldDescription:
+		return m.Description()
+	case keys.FieldImage:
+		return m.Image()
+	case keys.FieldURL:
+		return m.URL()
+	case keys.FieldSiteName:
+		return m.SiteName()
+	case keys.FieldFavicon:
+		favicon := m.Favicon()
+		return &favicon
+	case keys.FieldLocale:
+		return m.Locale()
+	case keys.FieldType:
+		return m.Type()
+	case keys.FieldAuthor:
+		return m.Author()
+	case keys.FieldKeywords:
+		if keywords := m.Keywords(); len(keywords) > 0 {
+			joined := strings.Join(keywords, ", ")
+			return &joined
+		}
+	case keys.FieldCharset:
+		return m.Charset()
+	case keys.FieldPublishedTime:
+		return formatTime(m.PublishedTime())
+	case keys.FieldModifiedTime:
+		return formatTime(m.ModifiedTime())
+	}
+	return nil
+}
+
+// formatTime formats an optional time as RFC 3339
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
-	return make(map[string][]string)
+	formatted := t.Format(time.RFC3339)
+	return &formatted
 }
 
 // OpenGraph returns OpenGraph data for backward compatibility
@@ -107,7 +527,23 @@ func (m *Metadata) Meta() map[string][]string {
 	return m.GetProviderData("meta")
 }
 
+// Citations returns scholarly citation data
+func (m *Metadata) Citations() map[string][]string {
+	return m.GetProviderData("citation")
+}
+
 // Other returns other elements data for backward compatibility
 func (m *Metadata) Other() map[string][]string {
 	return m.GetProviderData("other")
 }
+
+// splitList splits a comma-separated value into trimmed, non-empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -2,7 +2,11 @@ package metadata
 
 import (
 	"golang.org/x/net/html"
+	"net/url"
+	"sync"
 	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
 )
 
 func TestMetadata_Favicon(t *testing.T) {
@@ -78,6 +82,28 @@ func TestMetadata_GetProviderData(t *testing.T) {
 	}
 }
 
+func TestMetadata_GetProviderData_Copy(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "openGraph", priority: 1}}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "title", "Title")
+
+	data := m.OpenGraph()
+	data["title"][0] = "Changed"
+	data["description"] = []string{"Added"}
+
+	if title := m.RawProviderData("openGraph")["title"][0]; title != "Title" {
+		t.Errorf("Stored title = %v, want Title after mutating the copy", title)
+	}
+
+	if _, exists := m.RawProviderData("openGraph")["description"]; exists {
+		t.Error("Expected key added to the copy not to reach the stored data")
+	}
+
+	if raw := m.RawProviderData("nonexistent"); raw != nil {
+		t.Errorf("RawProviderData() = %v, want nil for non-existent provider", raw)
+	}
+}
+
 // MockRegistry for testing
 type MockRegistry struct {
 	providers []MetadataProvider
@@ -251,6 +277,263 @@ func TestMetadata_Title(t *testing.T) {
 	}
 }
 
+// MockValuesProvider resolves multiple values for every key
+type MockValuesProvider struct {
+	MockProvider
+	values []string
+}
+
+func (m *MockValuesProvider) GetValues(key string, data map[string][]string) []string {
+	if key == "author" {
+		return m.values
+	}
+	return nil
+}
+
+func TestMetadata_Authors(t *testing.T) {
+	twitter := &MockProvider{name: "twitter", priority: 2}
+	meta := &MockProvider{name: "meta", priority: 3}
+	jsonLd := &MockValuesProvider{
+		MockProvider: MockProvider{name: "jsonLd", priority: 9},
+		values:       []string{"Jane Doe", "John Roe"},
+	}
+	registry := &MockRegistry{providers: []MetadataProvider{twitter, meta, jsonLd}}
+	m := NewMetadata(registry)
+	m.AddData("meta", "author", "Jane Doe")
+	m.AddData("meta", "article:author", "https://example.com/jane")
+	m.AddData("twitter", "creator", "@jane")
+
+	expected := []string{"@jane", "https://example.com/jane", "Jane Doe", "John Roe"}
+	authors := m.Authors()
+	if len(authors) != len(expected) {
+		t.Fatalf("Authors() = %v, want %v", authors, expected)
+	}
+	for i := range expected {
+		if authors[i] != expected[i] {
+			t.Errorf("Authors()[%d] = %v, want %v", i, authors[i], expected[i])
+		}
+	}
+
+	if author := m.Author(); author == nil || *author != "@jane" {
+		t.Errorf("Author() = %v, want @jane", author)
+	}
+}
+
+func TestMetadata_Author_None(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "meta", priority: 3}}}
+	m := NewMetadata(registry)
+
+	if author := m.Author(); author != nil {
+		t.Errorf("Author() = %v, want nil", *author)
+	}
+
+	if authors := (&Metadata{}).Authors(); authors != nil {
+		t.Errorf("Authors() with nil registry = %v, want nil", authors)
+	}
+}
+
+func TestMetadata_Keywords(t *testing.T) {
+	tests := []struct {
+		name     string
+		keywords []string
+		tags     []string
+		expected []string
+	}{
+		{
+			name:     "comma separated keywords",
+			keywords: []string{" go, html ,metadata,, "},
+			expected: []string{"go", "html", "metadata"},
+		},
+		{
+			name:     "keywords and article tags deduplicated",
+			keywords: []string{"Go, scraping"},
+			tags:     []string{"go", "Open Graph"},
+			expected: []string{"Go", "scraping", "Open Graph"},
+		},
+		{
+			name:     "no keywords",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "meta", priority: 3}}}
+			m := NewMetadata(registry)
+			for _, keywords := range tt.keywords {
+				m.AddData("meta", "keywords", keywords)
+			}
+			for _, tag := range tt.tags {
+				m.AddData("meta", "article:tag", tag)
+			}
+
+			result := m.Keywords()
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Keywords() = %v, want %v", result, tt.expected)
+			}
+			for i := range tt.expected {
+				if result[i] != tt.expected[i] {
+					t.Errorf("Keywords()[%d] = %v, want %v", i, result[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMetadata_Fields(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "meta", priority: 3},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "title", "OG Title")
+	m.AddData("openGraph", "image", "https://example.com/a.jpg")
+	m.AddData("meta", "title", "Meta Title")
+	m.AddData("meta", "description", "Meta Description")
+
+	expected := []string{"description", "image", "title"}
+	fields := m.Fields()
+	if len(fields) != len(expected) {
+		t.Fatalf("Fields() = %v, want %v", fields, expected)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("Fields()[%d] = %v, want %v", i, fields[i], expected[i])
+		}
+	}
+}
+
+func TestMetadata_ToMap(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "meta", priority: 3},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "title", "OG Title")
+	m.AddData("meta", "title", "Meta Title")
+	m.AddData("meta", "description", "Meta Description")
+
+	flat := m.ToMap()
+	if len(flat) != 2 {
+		t.Errorf("ToMap() = %v, want 2 entries", flat)
+	}
+
+	if flat["title"] != "OG Title" {
+		t.Errorf("ToMap()[title] = %v, want OG Title", flat["title"])
+	}
+
+	if flat["description"] != "Meta Description" {
+		t.Errorf("ToMap()[description] = %v, want Meta Description", flat["description"])
+	}
+
+	if empty := (&Metadata{}).ToMap(); len(empty) != 0 {
+		t.Errorf("ToMap() on empty metadata = %v, want empty", empty)
+	}
+}
+
+func TestMetadata_Get(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "meta", priority: 3},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "title", "OG Title")
+	m.AddData("meta", "keywords", "go, html")
+	m.AddData("meta", "article:published_time", "2024-01-02T03:04:05Z")
+
+	tests := []struct {
+		field    keys.Field
+		expected *string
+	}{
+		{field: keys.FieldTitle, expected: stringPtr("OG Title")},
+		{field: keys.FieldKeywords, expected: stringPtr("go, html")},
+		{field: keys.FieldPublishedTime, expected: stringPtr("2024-01-02T03:04:05Z")},
+		{field: keys.FieldFavicon, expected: stringPtr("/favicon.ico")},
+		{field: keys.FieldDescription, expected: nil},
+		{field: keys.Field("unknown"), expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.field), func(t *testing.T) {
+			result := m.Get(tt.field)
+			if (result == nil) != (tt.expected == nil) || (result != nil && *result != *tt.expected) {
+				t.Errorf("Get(%v) = %v, want %v", tt.field, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_Locale(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]map[string]string
+		expected *string
+	}{
+		{
+			name: "og:locale takes precedence",
+			data: map[string]map[string]string{
+				"openGraph": {"locale": "en_US"},
+				"other":     {"lang": "en-GB"},
+			},
+			expected: stringPtr("en_US"),
+		},
+		{
+			name: "html lang fallback",
+			data: map[string]map[string]string{
+				"other": {"lang": "en-GB"},
+				"meta":  {"content-language": "de"},
+			},
+			expected: stringPtr("en-GB"),
+		},
+		{
+			name: "content-language fallback uses first language",
+			data: map[string]map[string]string{
+				"meta": {"content-language": "de, en"},
+			},
+			expected: stringPtr("de"),
+		},
+		{
+			name:     "no locale",
+			data:     map[string]map[string]string{},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &MockRegistry{providers: []MetadataProvider{
+				&MockProvider{name: "openGraph", priority: 1},
+				&MockProvider{name: "meta", priority: 3},
+				&MockProvider{name: "other", priority: 4},
+			}}
+			m := NewMetadata(registry)
+			for provider, values := range tt.data {
+				for key, value := range values {
+					m.AddData(provider, key, value)
+				}
+			}
+
+			result := m.Locale()
+			if (result == nil) != (tt.expected == nil) || (result != nil && *result != *tt.expected) {
+				t.Errorf("Locale() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_AlternateLocales(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "openGraph", priority: 1}}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "locale", "en_US")
+	m.AddData("openGraph", "locale:alternate", "fr_FR")
+	m.AddData("openGraph", "locale:alternate", "es_ES")
+
+	alternates := m.AlternateLocales()
+	if len(alternates) != 2 || alternates[0] != "fr_FR" || alternates[1] != "es_ES" {
+		t.Errorf("AlternateLocales() = %v, want [fr_FR es_ES]", alternates)
+	}
+}
+
 func TestMetadata_Description(t *testing.T) {
 	mockProvider := &MockProvider{name: "test", priority: 1, data: map[string][]string{"description": {"Test Description"}}}
 	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
@@ -268,6 +551,35 @@ func TestMetadata_Description(t *testing.T) {
 	}
 }
 
+func TestMetadata_Description_BodyFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		body     string
+		expected *string
+	}{
+		{name: "no description", expected: nil},
+		{name: "body only", body: "Body paragraph", expected: stringPtr("Body paragraph")},
+		{name: "declared wins", data: "Declared", body: "Body paragraph", expected: stringPtr("Declared")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := &MockProvider{name: "test", priority: 1}
+			m := NewMetadata(&MockRegistry{providers: []MetadataProvider{mockProvider}})
+			if tt.data != "" {
+				m.AddData("test", "description", tt.data)
+			}
+			m.BodyDescription = tt.body
+
+			result := m.Description()
+			if (result == nil) != (tt.expected == nil) || (result != nil && *result != *tt.expected) {
+				t.Errorf("Description() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestMetadata_Image(t *testing.T) {
 	mockProvider := &MockProvider{name: "test", priority: 1, data: map[string][]string{"image": {"https://example.com/image.jpg"}}}
 	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
@@ -302,6 +614,98 @@ func TestMetadata_URL(t *testing.T) {
 	}
 }
 
+func TestMetadata_FinalURL(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "test", priority: 1}}}
+	m := NewMetadata(registry)
+
+	if result := m.FinalURL(); result != nil {
+		t.Errorf("FinalURL() = %v, want nil", *result)
+	}
+
+	m.BaseURL, _ = url.Parse("https://example.com/landed")
+	if result := m.FinalURL(); result == nil || *result != "https://example.com/landed" {
+		t.Errorf("FinalURL() = %v, want %v", result, "https://example.com/landed")
+	}
+}
+
+func TestMetadata_Charset(t *testing.T) {
+	mockProvider := &MockProvider{name: "test", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+
+	if result := m.Charset(); result != nil {
+		t.Errorf("Charset() = %v, want nil", *result)
+	}
+
+	m.AddData("test", "charset", "Shift_JIS")
+
+	result := m.Charset()
+	if result == nil {
+		t.Error("Charset() = nil, want non-nil")
+		return
+	}
+
+	if *result != "Shift_JIS" {
+		t.Errorf("Charset() = %v, want %v", *result, "Shift_JIS")
+	}
+}
+
+func TestMetadata_News(t *testing.T) {
+	mockProvider := &MockProvider{name: "news", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+	m.AddData("news", "news_keywords", "World Cup, soccer, ,Brazil")
+	m.AddData("news", "standout", "https://example.com/a")
+	m.AddData("news", "standout", "https://example.com/b")
+	m.AddData("news", "syndication-source", "https://example.com/syndicated")
+	m.AddData("news", "original-source", "https://example.com/original")
+
+	keywords := m.NewsKeywords()
+	expectedKeywords := []string{"World Cup", "soccer", "Brazil"}
+	if len(keywords) != len(expectedKeywords) {
+		t.Fatalf("NewsKeywords() = %v, want %v", keywords, expectedKeywords)
+	}
+	for i, keyword := range expectedKeywords {
+		if keywords[i] != keyword {
+			t.Errorf("NewsKeywords()[%d] = %v, want %v", i, keywords[i], keyword)
+		}
+	}
+
+	if len(m.Standout()) != 2 {
+		t.Errorf("Expected 2 standout URLs, got %d", len(m.Standout()))
+	}
+
+	if source := m.SyndicationSource(); source == nil || *source != "https://example.com/syndicated" {
+		t.Errorf("SyndicationSource() = %v, want %v", source, "https://example.com/syndicated")
+	}
+
+	if source := m.OriginalSource(); source == nil || *source != "https://example.com/original" {
+		t.Errorf("OriginalSource() = %v, want %v", source, "https://example.com/original")
+	}
+}
+
+func TestMetadata_News_Missing(t *testing.T) {
+	mockProvider := &MockProvider{name: "news", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+
+	if keywords := m.NewsKeywords(); keywords != nil {
+		t.Errorf("NewsKeywords() = %v, want nil", keywords)
+	}
+
+	if standout := m.Standout(); len(standout) != 0 {
+		t.Errorf("Standout() = %v, want empty", standout)
+	}
+
+	if source := m.SyndicationSource(); source != nil {
+		t.Errorf("SyndicationSource() = %v, want nil", *source)
+	}
+
+	if source := m.OriginalSource(); source != nil {
+		t.Errorf("OriginalSource() = %v, want nil", *source)
+	}
+}
+
 func TestMetadata_SiteName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -464,6 +868,30 @@ func TestMetadata_Meta(t *testing.T) {
 	}
 }
 
+func TestMetadata_Citations(t *testing.T) {
+	m := &Metadata{
+		providerData: ProviderData{
+			"citation": map[string][]string{
+				"author": {"Doe, Jane", "Roe, Richard"},
+				"doi":    {"10.1000/xyz123"},
+			},
+		},
+	}
+
+	result := m.Citations()
+	if len(result) != 2 {
+		t.Errorf("Expected 2 keys in Citations data, got %d", len(result))
+	}
+
+	if len(result["author"]) != 2 {
+		t.Errorf("Expected 2 authors, got %d", len(result["author"]))
+	}
+
+	if result["doi"][0] != "10.1000/xyz123" {
+		t.Errorf("Expected '10.1000/xyz123', got '%s'", result["doi"][0])
+	}
+}
+
 func TestMetadata_Other(t *testing.T) {
 	m := &Metadata{
 		providerData: ProviderData{
@@ -498,3 +926,119 @@ func TestMetadata_resolveValue_NilRegistry(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func TestMetadata_ResolveURL(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/post")
+
+	tests := []struct {
+		name     string
+		base     *url.URL
+		ref      string
+		expected string
+	}{
+		{name: "no base URL", base: nil, ref: "/image.png", expected: "/image.png"},
+		{name: "root-relative", base: base, ref: "/image.png", expected: "https://example.com/image.png"},
+		{name: "path-relative", base: base, ref: "image.png", expected: "https://example.com/blog/image.png"},
+		{name: "protocol-relative", base: base, ref: "//cdn.example.com/a.png", expected: "https://cdn.example.com/a.png"},
+		{name: "absolute", base: base, ref: "http://other.com/a.png", expected: "http://other.com/a.png"},
+		{name: "unparseable", base: base, ref: "http://[::1", expected: "http://[::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Metadata{BaseURL: tt.base}
+			if result := m.ResolveURL(tt.ref); result != tt.expected {
+				t.Errorf("ResolveURL(%q) = %v, want %v", tt.ref, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_ResolveURL_BaseHref(t *testing.T) {
+	base, _ := url.Parse("https://example.com/blog/post")
+
+	tests := []struct {
+		name     string
+		base     *url.URL
+		baseHref string
+		ref      string
+		expected string
+	}{
+		{name: "relative base href", base: base, baseHref: "/static/", ref: "icon.png", expected: "https://example.com/static/icon.png"},
+		{name: "absolute base href", base: base, baseHref: "https://cdn.example.com/assets/", ref: "feed.xml", expected: "https://cdn.example.com/assets/feed.xml"},
+		{name: "absolute base href without page URL", base: nil, baseHref: "https://cdn.example.com/assets/", ref: "feed.xml", expected: "https://cdn.example.com/assets/feed.xml"},
+		{name: "relative base href without page URL", base: nil, baseHref: "/static/", ref: "icon.png", expected: "icon.png"},
+		{name: "unparseable base href", base: base, baseHref: "http://[::1", ref: "icon.png", expected: "https://example.com/blog/icon.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Metadata{BaseURL: tt.base, BaseHref: tt.baseHref}
+			if result := m.ResolveURL(tt.ref); result != tt.expected {
+				t.Errorf("ResolveURL(%q) = %v, want %v", tt.ref, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_BaseURL(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "other", priority: 2},
+	}}
+	m := NewMetadata(registry)
+	m.BaseURL, _ = url.Parse("https://example.com/blog/post")
+	m.AddData("openGraph", "image", "/images/cover.jpg")
+	m.AddData("other", "icon", "favicon.png")
+	m.AddData("other", "url", "/blog/post")
+
+	if image := m.Image(); image == nil || *image != "https://example.com/images/cover.jpg" {
+		t.Errorf("Image() = %v, want %v", image, "https://example.com/images/cover.jpg")
+	}
+
+	if images := m.Images(); len(images) != 1 || images[0].URL != "https://example.com/images/cover.jpg" {
+		t.Errorf("Images() = %+v, want one absolute image", images)
+	}
+
+	if favicon := m.Favicon(); favicon != "https://example.com/blog/favicon.png" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://example.com/blog/favicon.png")
+	}
+
+	if pageURL := m.URL(); pageURL == nil || *pageURL != "https://example.com/blog/post" {
+		t.Errorf("URL() = %v, want %v", pageURL, "https://example.com/blog/post")
+	}
+
+	empty := NewMetadata(registry)
+	empty.BaseURL = m.BaseURL
+	if favicon := empty.Favicon(); favicon != "https://example.com/favicon.ico" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://example.com/favicon.ico")
+	}
+}
+
+func TestMetadata_AddData_Concurrent(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "openGraph", priority: 1}}}
+	m := NewMetadata(registry)
+
+	const goroutines, perGoroutine = 8, 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(provider string) {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				m.AddData(provider, "image", "https://example.com/image.jpg")
+			}
+		}([]string{"openGraph", "new"}[i%2])
+	}
+	wg.Wait()
+
+	total := len(m.GetProviderData("openGraph")["image"]) + len(m.GetProviderData("new")["image"])
+	if total != goroutines*perGoroutine {
+		t.Errorf("Expected %d values, got %d", goroutines*perGoroutine, total)
+	}
+
+	if len(m.entries) != goroutines*perGoroutine {
+		t.Errorf("Expected %d entries, got %d", goroutines*perGoroutine, len(m.entries))
+	}
+}
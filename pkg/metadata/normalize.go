@@ -0,0 +1,62 @@
+package metadata
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// NormalizeValue cleans an extracted value: entities left over from double
+// escaping are decoded, the result is sanitized, runs of whitespace
+// (including newlines and tabs) collapse to a single space, and the ends are
+// trimmed
+func NormalizeValue(value string) string {
+	return strings.Join(strings.Fields(SanitizeValue(html.UnescapeString(value))), " ")
+}
+
+// SanitizeValue strips characters that have no place in metadata and can be
+// abused when it is displayed: control characters other than tab, newline
+// and carriage return (e.g. terminal escape sequences), bidirectional
+// overrides, and invisible zero-width characters. Zero-width joiners are
+// kept, since emoji sequences and some scripts need them.
+func SanitizeValue(value string) string {
+	if strings.IndexFunc(value, isJunkRune) == -1 {
+		return value
+	}
+	return strings.Map(func(r rune) rune {
+		if isJunkRune(r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// isJunkRune reports whether SanitizeValue removes r
+func isJunkRune(r rune) bool {
+	switch r {
+	case '\t', '\n', '\r':
+		return false
+	case '\u200b', '\u200e', '\u200f', '\u2060', '\ufeff':
+		return true
+	}
+	return unicode.IsControl(r) ||
+		(r >= '\u202a' && r <= '\u202e') ||
+		(r >= '\u2066' && r <= '\u2069')
+}
+
+// nonTextElements hold content that is never rendered as text
+var nonTextElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"template": true,
+	"noscript": true,
+	"svg":      true,
+	"math":     true,
+}
+
+// IsNonTextElement reports whether an element with the given tag name holds
+// content that is never rendered as text, so its subtree is skipped when
+// reading titles, headings and text content
+func IsNonTextElement(tag string) bool {
+	return nonTextElements[tag]
+}
@@ -0,0 +1,69 @@
+package metadata
+
+import "testing"
+
+func TestNormalizeValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "Tom &amp; Jerry", expected: "Tom & Jerry"},
+		{value: "  Title\n\twith   breaks  ", expected: "Title with breaks"},
+		{value: "caf&eacute; &#8212; &quot;menu&quot;", expected: `café — "menu"`},
+		{value: "Fish&nbsp;&amp;&nbsp;Chips", expected: "Fish & Chips"},
+		{value: "Already clean", expected: "Already clean"},
+		{value: " \n ", expected: ""},
+		{value: "Red&#27;[31m alert", expected: "Red[31m alert"},
+	}
+
+	for _, tt := range tests {
+		if result := NormalizeValue(tt.value); result != tt.expected {
+			t.Errorf("NormalizeValue(%q) = %q, want %q", tt.value, result, tt.expected)
+		}
+	}
+}
+
+func TestSanitizeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "clean", value: "Plain title", expected: "Plain title"},
+		{name: "terminal escape", value: "\x1b[2J\x1b[31mRed\x1b[0m", expected: "[2J[31mRed[0m"},
+		{name: "C1 control", value: "a\u009bb", expected: "ab"},
+		{name: "keeps whitespace", value: "line\none\ttab\r", expected: "line\none\ttab\r"},
+		{name: "zero-width", value: "in\u200bvis\ufeffible\u2060", expected: "invisible"},
+		{name: "bidi override", value: "file\u202egnp.exe\u2066", expected: "filegnp.exe"},
+		{name: "keeps joiner", value: "👩\u200d💻", expected: "👩\u200d💻"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := SanitizeValue(tt.value); result != tt.expected {
+				t.Errorf("SanitizeValue(%q) = %q, want %q", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsNonTextElement(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected bool
+	}{
+		{tag: "script", expected: true},
+		{tag: "style", expected: true},
+		{tag: "svg", expected: true},
+		{tag: "title", expected: false},
+		{tag: "p", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if result := IsNonTextElement(tt.tag); result != tt.expected {
+				t.Errorf("IsNonTextElement(%q) = %v, want %v", tt.tag, result, tt.expected)
+			}
+		})
+	}
+}
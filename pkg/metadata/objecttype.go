@@ -0,0 +1,115 @@
+package metadata
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Article holds the Open Graph article namespace properties (article:*)
+type Article struct {
+	PublishedTime  *time.Time `json:"publishedTime,omitempty"`
+	ModifiedTime   *time.Time `json:"modifiedTime,omitempty"`
+	ExpirationTime *time.Time `json:"expirationTime,omitempty"`
+	Authors        []string   `json:"authors,omitempty"`
+	Section        string     `json:"section,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+}
+
+// Profile holds the Open Graph profile namespace properties (profile:*)
+type Profile struct {
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Gender    string `json:"gender,omitempty"`
+}
+
+// Video holds the Open Graph video namespace properties (video:*) shared by
+// the video.movie, video.episode, video.tv_show and video.other types
+type Video struct {
+	Actors      []string   `json:"actors,omitempty"`
+	Directors   []string   `json:"directors,omitempty"`
+	Writers     []string   `json:"writers,omitempty"`
+	Duration    int        `json:"duration,omitempty"`
+	ReleaseDate *time.Time `json:"releaseDate,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	Series      string     `json:"series,omitempty"`
+}
+
+// Type returns the Open Graph object type (og:type), e.g. "article"
+func (m *Metadata) Type() *string {
+	return m.fieldValue(keys.FieldType, func() *string {
+		if values := m.RawProviderData("openGraph")[keys.Type]; len(values) > 0 {
+			objectType := values[0]
+			return &objectType
+		}
+		return nil
+	})
+}
+
+// AsArticle returns the article view, or nil when og:type is not article
+func (m *Metadata) AsArticle() *Article {
+	if !m.isType("article") {
+		return nil
+	}
+
+	return &Article{
+		PublishedTime:  m.resolveTime([]string{keys.ArticlePublishedTime}),
+		ModifiedTime:   m.resolveTime([]string{keys.ArticleModifiedTime}),
+		ExpirationTime: m.resolveTime([]string{"article:expiration_time"}),
+		Authors:        m.collectValues([]string{keys.ArticleAuthor}),
+		Section:        m.firstValue("article:section"),
+		Tags:           m.collectValues([]string{keys.ArticleTag}),
+	}
+}
+
+// AsProfile returns the profile view, or nil when og:type is not profile
+func (m *Metadata) AsProfile() *Profile {
+	if !m.isType("profile") {
+		return nil
+	}
+
+	return &Profile{
+		FirstName: m.firstValue("profile:first_name"),
+		LastName:  m.firstValue("profile:last_name"),
+		Username:  m.firstValue("profile:username"),
+		Gender:    m.firstValue("profile:gender"),
+	}
+}
+
+// AsVideo returns the video view, or nil when og:type is not one of the
+// video.* types
+func (m *Metadata) AsVideo() *Video {
+	objectType := m.Type()
+	if objectType == nil || !strings.HasPrefix(strings.ToLower(*objectType), "video.") {
+		return nil
+	}
+
+	video := &Video{
+		Actors:      m.collectValues([]string{"video:actor"}),
+		Directors:   m.collectValues([]string{"video:director"}),
+		Writers:     m.collectValues([]string{"video:writer"}),
+		ReleaseDate: m.resolveTime([]string{"video:release_date"}),
+		Tags:        m.collectValues([]string{"video:tag"}),
+		Series:      m.firstValue("video:series"),
+	}
+	video.Duration, _ = strconv.Atoi(strings.TrimSpace(m.firstValue("video:duration")))
+
+	return video
+}
+
+// isType reports whether og:type matches the given type
+func (m *Metadata) isType(objectType string) bool {
+	declared := m.Type()
+	return declared != nil && strings.EqualFold(strings.TrimSpace(*declared), objectType)
+}
+
+// firstValue returns the resolved value for a key, or "" when unset
+func (m *Metadata) firstValue(key string) string {
+	if value := m.resolveValue(key); value != nil {
+		return *value
+	}
+	return ""
+}
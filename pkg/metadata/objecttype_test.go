@@ -0,0 +1,115 @@
+package metadata
+
+import "testing"
+
+func newObjectTypeMetadata(objectType string) *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "meta", priority: 3},
+	}}
+	m := NewMetadata(registry)
+	if objectType != "" {
+		m.AddData("openGraph", "type", objectType)
+	}
+	return m
+}
+
+func TestMetadata_Type(t *testing.T) {
+	m := newObjectTypeMetadata("article")
+	if objectType := m.Type(); objectType == nil || *objectType != "article" {
+		t.Errorf("Type() = %v, want article", objectType)
+	}
+
+	if objectType := newObjectTypeMetadata("").Type(); objectType != nil {
+		t.Errorf("Type() = %v, want nil", *objectType)
+	}
+}
+
+func TestMetadata_AsArticle(t *testing.T) {
+	m := newObjectTypeMetadata("article")
+	m.AddData("meta", "article:published_time", "2024-01-02T03:04:05Z")
+	m.AddData("meta", "article:author", "https://example.com/jane")
+	m.AddData("meta", "article:author", "https://example.com/john")
+	m.AddData("meta", "article:section", "Technology")
+	m.AddData("meta", "article:tag", "go")
+
+	article := m.AsArticle()
+	if article == nil {
+		t.Fatal("AsArticle() = nil, want non-nil")
+	}
+
+	if article.PublishedTime == nil || article.PublishedTime.Year() != 2024 {
+		t.Errorf("PublishedTime = %v, want 2024-01-02T03:04:05Z", article.PublishedTime)
+	}
+
+	if len(article.Authors) != 2 {
+		t.Errorf("Authors = %v, want 2 authors", article.Authors)
+	}
+
+	if article.Section != "Technology" {
+		t.Errorf("Section = %v, want Technology", article.Section)
+	}
+
+	if len(article.Tags) != 1 || article.Tags[0] != "go" {
+		t.Errorf("Tags = %v, want [go]", article.Tags)
+	}
+
+	if article.ModifiedTime != nil {
+		t.Errorf("ModifiedTime = %v, want nil", article.ModifiedTime)
+	}
+}
+
+func TestMetadata_AsProfile(t *testing.T) {
+	m := newObjectTypeMetadata("profile")
+	m.AddData("meta", "profile:first_name", "Jane")
+	m.AddData("meta", "profile:username", "jdoe")
+
+	profile := m.AsProfile()
+	if profile == nil {
+		t.Fatal("AsProfile() = nil, want non-nil")
+	}
+
+	if profile.FirstName != "Jane" || profile.Username != "jdoe" || profile.LastName != "" {
+		t.Errorf("AsProfile() = %+v, want FirstName Jane and Username jdoe", profile)
+	}
+}
+
+func TestMetadata_AsVideo(t *testing.T) {
+	m := newObjectTypeMetadata("video.movie")
+	m.AddData("meta", "video:actor", "https://example.com/actor")
+	m.AddData("meta", "video:duration", "5400")
+	m.AddData("meta", "video:release_date", "2020-05-01")
+
+	video := m.AsVideo()
+	if video == nil {
+		t.Fatal("AsVideo() = nil, want non-nil")
+	}
+
+	if video.Duration != 5400 {
+		t.Errorf("Duration = %v, want 5400", video.Duration)
+	}
+
+	if len(video.Actors) != 1 {
+		t.Errorf("Actors = %v, want 1 actor", video.Actors)
+	}
+
+	if video.ReleaseDate == nil || video.ReleaseDate.Month() != 5 {
+		t.Errorf("ReleaseDate = %v, want 2020-05-01", video.ReleaseDate)
+	}
+}
+
+func TestMetadata_Views_TypeMismatch(t *testing.T) {
+	m := newObjectTypeMetadata("website")
+
+	if article := m.AsArticle(); article != nil {
+		t.Errorf("AsArticle() = %+v, want nil", article)
+	}
+
+	if profile := m.AsProfile(); profile != nil {
+		t.Errorf("AsProfile() = %+v, want nil", profile)
+	}
+
+	if video := m.AsVideo(); video != nil {
+		t.Errorf("AsVideo() = %+v, want nil", video)
+	}
+}
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Platform names a site whose link previews NewPlatformCard approximates
+type Platform string
+
+const (
+	PlatformTwitter  Platform = "twitter"
+	PlatformFacebook Platform = "facebook"
+	PlatformSlack    Platform = "slack"
+	PlatformDiscord  Platform = "discord"
+)
+
+// Platforms lists the supported platforms
+var Platforms = []Platform{PlatformTwitter, PlatformFacebook, PlatformSlack, PlatformDiscord}
+
+// platformRule describes what a platform shows in a preview
+type platformRule struct {
+	// titleLength and descriptionLength are roughly where the platform cuts
+	// the title and description off
+	titleLength       int
+	descriptionLength int
+
+	// twitterTags prefers twitter: values over the resolved ones
+	twitterTags bool
+
+	// hostAsSite shows the page's host instead of og:site_name
+	hostAsSite bool
+
+	// alwaysLargeImage shows every image across the card; otherwise only a
+	// summary_large_image twitter:card does
+	alwaysLargeImage bool
+}
+
+// platformRules holds the rule for each platform
+var platformRules = map[Platform]platformRule{
+	PlatformTwitter:  {titleLength: 70, descriptionLength: 200, twitterTags: true, hostAsSite: true},
+	PlatformFacebook: {titleLength: 88, descriptionLength: 155, hostAsSite: true, alwaysLargeImage: true},
+	PlatformSlack:    {titleLength: 150, descriptionLength: 300},
+	PlatformDiscord:  {titleLength: 256, descriptionLength: 350},
+}
+
+// PlatformCard is a Card as a platform would approximately render it
+type PlatformCard struct {
+	Card
+	Platform Platform `json:"platform"`
+
+	// ImageWidth and ImageHeight are the image's declared dimensions, if any
+	ImageWidth  int `json:"imageWidth,omitempty"`
+	ImageHeight int `json:"imageHeight,omitempty"`
+
+	// LargeImage is set when the image spans the card above the text
+	// rather than sitting beside it as a thumbnail
+	LargeImage bool `json:"largeImage"`
+}
+
+// NewPlatformCard builds the preview platform would show for m: NewCard's
+// fields, preferring twitter: tags on Twitter, the host as the site name
+// where the platform shows it, and the title and description truncated to
+// the platform's limits. The rules are approximations of each platform's
+// current behavior.
+func NewPlatformCard(m *Metadata, platform Platform) (*PlatformCard, error) {
+	rule, known := platformRules[platform]
+	if !known {
+		return nil, fmt.Errorf("unknown platform %q", platform)
+	}
+
+	card := &PlatformCard{Card: *NewCard(m), Platform: platform}
+	card.Description = valueOrEmpty(m.Description())
+
+	twitter := m.TwitterCard()
+	if rule.twitterTags {
+		if title := firstTrimmed(twitter[keys.Title]); title != "" {
+			card.Title = title
+		}
+		if description := firstTrimmed(twitter[keys.Description]); description != "" {
+			card.Description = description
+		}
+		if image := firstTrimmed(twitter[keys.Image]); image != "" {
+			card.Image = m.ResolveURL(image)
+		}
+	}
+	if rule.hostAsSite {
+		if host := hostName(card.URL); host != "" {
+			card.SiteName = host
+		}
+	}
+
+	card.Title = truncate(card.Title, rule.titleLength)
+	card.Description = truncate(card.Description, rule.descriptionLength)
+
+	for _, image := range m.Images() {
+		if image.URL == card.Image || image.SecureURL == card.Image {
+			card.ImageWidth, card.ImageHeight = image.Width, image.Height
+			break
+		}
+	}
+	card.LargeImage = card.Image != "" && (rule.alwaysLargeImage || firstTrimmed(twitter[keys.Card]) == "summary_large_image")
+
+	return card, nil
+}
+
+// firstTrimmed returns the first of values without surrounding space, or ""
+// when there is none
+func firstTrimmed(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
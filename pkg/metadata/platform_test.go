@@ -0,0 +1,109 @@
+package metadata
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewPlatformCard(t *testing.T) {
+	m := newCardMetadata(map[string]map[string]string{
+		"openGraph": {
+			"title":       "OG Title",
+			"description": "OG Description",
+			"image":       "https://example.com/og.jpg",
+			"site_name":   "Example",
+			"url":         "https://www.example.com/page",
+		},
+		"twitter": {
+			"card":  "summary_large_image",
+			"title": "Twitter Title",
+			"image": "/twitter.jpg",
+		},
+	})
+	m.BaseURL, _ = url.Parse("https://www.example.com/page")
+
+	tests := []struct {
+		platform    Platform
+		title       string
+		description string
+		image       string
+		siteName    string
+		largeImage  bool
+	}{
+		{platform: PlatformTwitter, title: "Twitter Title", description: "OG Description", image: "https://www.example.com/twitter.jpg", siteName: "example.com", largeImage: true},
+		{platform: PlatformFacebook, title: "OG Title", description: "OG Description", image: "https://example.com/og.jpg", siteName: "example.com", largeImage: true},
+		{platform: PlatformSlack, title: "OG Title", description: "OG Description", image: "https://example.com/og.jpg", siteName: "Example", largeImage: true},
+		{platform: PlatformDiscord, title: "OG Title", description: "OG Description", image: "https://example.com/og.jpg", siteName: "Example", largeImage: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.platform), func(t *testing.T) {
+			card, err := NewPlatformCard(m, tt.platform)
+			if err != nil {
+				t.Fatalf("NewPlatformCard() returned error: %v", err)
+			}
+
+			if card.Platform != tt.platform || card.Title != tt.title || card.Description != tt.description || card.Image != tt.image || card.SiteName != tt.siteName || card.LargeImage != tt.largeImage {
+				t.Errorf("NewPlatformCard() = %+v, want title %q, description %q, image %q, site %q, large %v", card, tt.title, tt.description, tt.image, tt.siteName, tt.largeImage)
+			}
+		})
+	}
+
+	if _, err := NewPlatformCard(m, "myspace"); err == nil {
+		t.Error("NewPlatformCard() expected an error for an unknown platform")
+	}
+}
+
+func TestNewPlatformCard_Truncation(t *testing.T) {
+	m := newCardMetadata(map[string]map[string]string{
+		"openGraph": {
+			"title":       strings.Repeat("title ", 30),
+			"description": strings.Repeat("description ", 40),
+		},
+	})
+
+	tests := []struct {
+		platform          Platform
+		titleLength       int
+		descriptionLength int
+	}{
+		{platform: PlatformTwitter, titleLength: 70, descriptionLength: 200},
+		{platform: PlatformFacebook, titleLength: 88, descriptionLength: 155},
+		{platform: PlatformSlack, titleLength: 150, descriptionLength: 300},
+		{platform: PlatformDiscord, titleLength: 180, descriptionLength: 350},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.platform), func(t *testing.T) {
+			card, _ := NewPlatformCard(m, tt.platform)
+			if length := utf8.RuneCountInString(card.Title); length > tt.titleLength {
+				t.Errorf("Title length = %v, want at most %v", length, tt.titleLength)
+			}
+			if length := utf8.RuneCountInString(card.Description); length > tt.descriptionLength {
+				t.Errorf("Description length = %v, want at most %v", length, tt.descriptionLength)
+			}
+		})
+	}
+}
+
+func TestNewPlatformCard_ImageDimensions(t *testing.T) {
+	m := newCardMetadata(nil)
+	m.AddData("openGraph", "image", "https://example.com/small.jpg")
+	m.AddData("openGraph", "image", "https://example.com/large.jpg")
+	m.AddData("openGraph", "image:width", "1200")
+	m.AddData("openGraph", "image:height", "630")
+	m.AddData("twitter", "image", "https://example.com/large.jpg")
+
+	card, err := NewPlatformCard(m, PlatformTwitter)
+	if err != nil {
+		t.Fatalf("NewPlatformCard() returned error: %v", err)
+	}
+	if card.ImageWidth != 1200 || card.ImageHeight != 630 {
+		t.Errorf("Image dimensions = %vx%v, want 1200x630", card.ImageWidth, card.ImageHeight)
+	}
+	if card.LargeImage {
+		t.Error("LargeImage = true, want false without a summary_large_image card")
+	}
+}
@@ -0,0 +1,107 @@
+package metadata
+
+import "strings"
+
+// Source describes where a scraped value came from
+type Source struct {
+	// Provider is the name of the provider that extracted the value
+	Provider string `json:"provider"`
+
+	// Key is the key the provider stored the value under
+	Key string `json:"key"`
+
+	// Element describes the source element, e.g. <meta property="og:title">
+	Element string `json:"element,omitempty"`
+
+	// Attribute is the attribute the value was read from; empty for text
+	// content or when unknown
+	Attribute string `json:"attribute,omitempty"`
+}
+
+// ResolvedValue is a resolved value together with its provenance
+type ResolvedValue struct {
+	Value  string `json:"value"`
+	Source Source `json:"source"`
+}
+
+// ResolveWithSource resolves a key like the accessors do, following
+// provider priority, and reports which provider and element produced it
+func (m *Metadata) ResolveWithSource(key string) *ResolvedValue {
+	if m.registry == nil {
+		return nil
+	}
+
+	for _, provider := range m.registry.GetProviders() {
+		data, exists := m.providerData[provider.Name()]
+		if !exists {
+			continue
+		}
+
+		if value := provider.GetValue(key, data); value != nil {
+			return &ResolvedValue{
+				Value:  *value,
+				Source: m.sourceOf(provider.Name(), key, *value),
+			}
+		}
+	}
+	return nil
+}
+
+// ResolveAll returns every value stored for a key, across providers in
+// priority order and in document order within a provider, each with its
+// provenance
+func (m *Metadata) ResolveAll(key string) []ResolvedValue {
+	if m.registry == nil {
+		return nil
+	}
+
+	var resolved []ResolvedValue
+	for _, provider := range m.registry.GetProviders() {
+		if _, exists := m.providerData[provider.Name()]; !exists {
+			continue
+		}
+
+		for _, value := range m.providerValues(provider, key) {
+			resolved = append(resolved, ResolvedValue{
+				Value:  value,
+				Source: m.sourceOf(provider.Name(), key, value),
+			})
+		}
+	}
+	return resolved
+}
+
+// sourceOf finds the recorded entry that produced a resolved value. Values
+// derived from a larger stored value (e.g. a JSON-LD property) are traced
+// back to the entry containing them.
+func (m *Metadata) sourceOf(providerName, key, value string) Source {
+	var containing *dataEntry
+
+	for i := range m.entries {
+		entry := &m.entries[i]
+		if entry.provider != providerName {
+			continue
+		}
+		if entry.key == key && entry.value == value {
+			return entry.source()
+		}
+		if containing == nil && strings.Contains(entry.value, value) {
+			containing = entry
+		}
+	}
+
+	if containing != nil {
+		return containing.source()
+	}
+	return Source{Provider: providerName, Key: key}
+}
+
+// source returns the provenance recorded for an entry
+func (e *dataEntry) source() Source {
+	return Source{
+		Provider:  e.provider,
+		Key:       e.key,
+		Element:   e.element,
+		Attribute: e.attribute,
+	}
+}
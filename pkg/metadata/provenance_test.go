@@ -0,0 +1,106 @@
+package metadata
+
+import "testing"
+
+func TestMetadata_ResolveWithSource(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "other", priority: 4},
+	}}
+	m := NewMetadata(registry)
+	m.AddSourcedData(Source{Provider: "other", Key: "title", Element: "<title>"}, "Page Title")
+	m.AddSourcedData(Source{
+		Provider:  "openGraph",
+		Key:       "title",
+		Element:   `<meta property="og:title">`,
+		Attribute: "content",
+	}, "OG Title")
+
+	resolved := m.ResolveWithSource("title")
+	if resolved == nil {
+		t.Fatal("ResolveWithSource() = nil, want non-nil")
+	}
+
+	expected := Source{Provider: "openGraph", Key: "title", Element: `<meta property="og:title">`, Attribute: "content"}
+	if resolved.Value != "OG Title" || resolved.Source != expected {
+		t.Errorf("ResolveWithSource() = %+v, want OG Title from %+v", resolved, expected)
+	}
+}
+
+func TestMetadata_ResolveWithSource_DerivedValue(t *testing.T) {
+	provider := &MockProvider{name: "jsonLd", priority: 1, data: map[string][]string{"title": {"Headline"}}}
+	registry := &MockRegistry{providers: []MetadataProvider{provider}}
+	m := NewMetadata(registry)
+	m.AddSourcedData(Source{
+		Provider: "jsonLd",
+		Key:      "json-ld",
+		Element:  `<script type="application/ld+json">`,
+	}, `{"headline": "Headline"}`)
+
+	resolved := m.ResolveWithSource("title")
+	if resolved == nil {
+		t.Fatal("ResolveWithSource() = nil, want non-nil")
+	}
+
+	if resolved.Source.Key != "json-ld" || resolved.Source.Element != `<script type="application/ld+json">` {
+		t.Errorf("Source = %+v, want the JSON-LD script entry", resolved.Source)
+	}
+}
+
+func TestMetadata_ResolveWithSource_NotFound(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "openGraph", priority: 1}}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "title", "Title")
+
+	if resolved := m.ResolveWithSource("description"); resolved != nil {
+		t.Errorf("ResolveWithSource() = %+v, want nil", resolved)
+	}
+
+	// Data added without provenance still reports its provider and key
+	resolved := m.ResolveWithSource("title")
+	if resolved == nil || resolved.Source != (Source{Provider: "openGraph", Key: "title"}) {
+		t.Errorf("ResolveWithSource() = %+v, want source without element", resolved)
+	}
+
+	if resolved := (&Metadata{}).ResolveWithSource("title"); resolved != nil {
+		t.Errorf("ResolveWithSource() with nil registry = %+v, want nil", resolved)
+	}
+}
+
+func TestMetadata_ResolveAll(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "twitter", priority: 2},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("twitter", "image", "https://example.com/twitter.jpg")
+	m.AddSourcedData(Source{Provider: "openGraph", Key: "image", Element: `<meta property="og:image">`, Attribute: "content"}, "https://example.com/a.jpg")
+	m.AddData("openGraph", "image", "https://example.com/b.jpg")
+
+	expected := []ResolvedValue{
+		{
+			Value:  "https://example.com/a.jpg",
+			Source: Source{Provider: "openGraph", Key: "image", Element: `<meta property="og:image">`, Attribute: "content"},
+		},
+		{Value: "https://example.com/b.jpg", Source: Source{Provider: "openGraph", Key: "image"}},
+		{Value: "https://example.com/twitter.jpg", Source: Source{Provider: "twitter", Key: "image"}},
+	}
+
+	resolved := m.ResolveAll("image")
+	if len(resolved) != len(expected) {
+		t.Fatalf("ResolveAll() = %+v, want %+v", resolved, expected)
+	}
+	for i := range expected {
+		if resolved[i] != expected[i] {
+			t.Errorf("ResolveAll()[%d] = %+v, want %+v", i, resolved[i], expected[i])
+		}
+	}
+
+	if resolved := m.ResolveAll("description"); len(resolved) != 0 {
+		t.Errorf("ResolveAll() = %+v, want none", resolved)
+	}
+
+	if resolved := (&Metadata{}).ResolveAll("image"); resolved != nil {
+		t.Errorf("ResolveAll() with nil registry = %+v, want nil", resolved)
+	}
+}
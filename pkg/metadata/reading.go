@@ -0,0 +1,31 @@
+package metadata
+
+import "time"
+
+// ReadingWordsPerMinute is the reading speed ReadingTime assumes
+const ReadingWordsPerMinute = 200
+
+// SetWordCount records how many words of visible text the page body holds
+func (m *Metadata) SetWordCount(count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.wordCount = count
+}
+
+// WordCount returns the number of words of visible body text, excluding
+// scripts, styles and navigation
+func (m *Metadata) WordCount() int {
+	return m.wordCount
+}
+
+// ReadingTime estimates how long the body takes to read at
+// ReadingWordsPerMinute, rounded up to the next whole minute. It is zero
+// for pages without body text.
+func (m *Metadata) ReadingTime() time.Duration {
+	if m.wordCount <= 0 {
+		return 0
+	}
+	minutes := (m.wordCount + ReadingWordsPerMinute - 1) / ReadingWordsPerMinute
+	return time.Duration(minutes) * time.Minute
+}
@@ -0,0 +1,33 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMetadata_ReadingTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		wordCount int
+		expected  time.Duration
+	}{
+		{name: "no text", wordCount: 0, expected: 0},
+		{name: "a few words", wordCount: 12, expected: time.Minute},
+		{name: "exact minutes", wordCount: 2 * ReadingWordsPerMinute, expected: 2 * time.Minute},
+		{name: "rounds up", wordCount: 2*ReadingWordsPerMinute + 1, expected: 3 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Metadata{providerData: make(ProviderData)}
+			m.SetWordCount(tt.wordCount)
+
+			if count := m.WordCount(); count != tt.wordCount {
+				t.Errorf("WordCount() = %v, want %v", count, tt.wordCount)
+			}
+			if readingTime := m.ReadingTime(); readingTime != tt.expected {
+				t.Errorf("ReadingTime() = %v, want %v", readingTime, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Refresh is a <meta http-equiv="refresh"> declaration: reload, or redirect
+// to URL, after Delay
+type Refresh struct {
+	Delay time.Duration
+	URL   string
+}
+
+// ParseRefresh parses refresh content such as "5; url=/next", reporting
+// false when it does not start with a delay
+func ParseRefresh(content string) (Refresh, bool) {
+	content = strings.TrimSpace(content)
+
+	end := strings.IndexFunc(content, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if end == -1 {
+		end = len(content)
+	}
+	seconds, err := strconv.ParseFloat(content[:end], 64)
+	if end == 0 || err != nil {
+		return Refresh{}, false
+	}
+
+	refresh := Refresh{Delay: time.Duration(seconds * float64(time.Second))}
+
+	target := strings.TrimLeft(content[end:], " \t\n\r;,")
+	if prefix, rest, found := strings.Cut(target, "="); found && strings.EqualFold(strings.TrimSpace(prefix), "url") {
+		target = strings.TrimSpace(rest)
+	}
+	if len(target) > 1 && (target[0] == '\'' || target[0] == '"') {
+		if closing := strings.IndexByte(target[1:], target[0]); closing != -1 {
+			target = target[1 : closing+1]
+		} else {
+			target = target[1:]
+		}
+	}
+	refresh.URL = strings.TrimSpace(target)
+
+	return refresh, true
+}
+
+// Refresh returns the page's meta refresh declaration, with its URL resolved
+// against BaseURL, or nil when there is none
+func (m *Metadata) Refresh() *Refresh {
+	content := m.resolveValue(keys.Refresh)
+	if content == nil {
+		return nil
+	}
+
+	refresh, ok := ParseRefresh(*content)
+	if !ok {
+		return nil
+	}
+	if refresh.URL != "" {
+		refresh.URL = m.ResolveURL(refresh.URL)
+	}
+	return &refresh
+}
+
+// RefreshURL returns the URL a meta refresh redirects to, or nil when the
+// page declares no refresh or only reloads itself
+func (m *Metadata) RefreshURL() *string {
+	refresh := m.Refresh()
+	if refresh == nil || refresh.URL == "" {
+		return nil
+	}
+	return &refresh.URL
+}
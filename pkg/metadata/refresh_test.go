@@ -0,0 +1,59 @@
+package metadata
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseRefresh(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected Refresh
+		ok       bool
+	}{
+		{content: "0; url=https://example.com/next", expected: Refresh{URL: "https://example.com/next"}, ok: true},
+		{content: "5;URL='/moved'", expected: Refresh{Delay: 5 * time.Second, URL: "/moved"}, ok: true},
+		{content: `3, url="/quoted"`, expected: Refresh{Delay: 3 * time.Second, URL: "/quoted"}, ok: true},
+		{content: "1.5; /bare?a=b", expected: Refresh{Delay: 1500 * time.Millisecond, URL: "/bare?a=b"}, ok: true},
+		{content: " 30 ", expected: Refresh{Delay: 30 * time.Second}, ok: true},
+		{content: "url=/missing-delay", ok: false},
+		{content: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		result, ok := ParseRefresh(tt.content)
+		if ok != tt.ok || result != tt.expected {
+			t.Errorf("ParseRefresh(%q) = %+v, %v, want %+v, %v", tt.content, result, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestMetadata_RefreshURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *string
+	}{
+		{name: "no refresh", expected: nil},
+		{name: "reload only", content: "60", expected: nil},
+		{name: "relative target", content: "0; url=/landing", expected: stringPtr("https://example.com/landing")},
+		{name: "invalid", content: "soon", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "meta", priority: 3}}}
+			m := NewMetadata(registry)
+			m.BaseURL, _ = url.Parse("https://example.com/interstitial")
+			if tt.content != "" {
+				m.AddData("meta", "refresh", tt.content)
+			}
+
+			result := m.RefreshURL()
+			if (result == nil) != (tt.expected == nil) || (result != nil && *result != *tt.expected) {
+				t.Errorf("RefreshURL() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
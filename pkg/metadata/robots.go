@@ -0,0 +1,195 @@
+package metadata
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Image preview sizes allowed by the max-image-preview directive, from most
+// to least restrictive
+const (
+	ImagePreviewNone     = "none"
+	ImagePreviewStandard = "standard"
+	ImagePreviewLarge    = "large"
+)
+
+// imagePreviewRank orders image preview sizes by restrictiveness
+var imagePreviewRank = map[string]int{
+	ImagePreviewNone:     0,
+	ImagePreviewStandard: 1,
+	ImagePreviewLarge:    2,
+}
+
+// RobotsDirectives is the combined set of crawler directives declared by the
+// robots meta tag and the X-Robots-Tag response header. When a directive is
+// declared more than once, the most restrictive value wins.
+type RobotsDirectives struct {
+	NoIndex          bool       `json:"noindex,omitempty"`
+	NoFollow         bool       `json:"nofollow,omitempty"`
+	NoArchive        bool       `json:"noarchive,omitempty"`
+	NoSnippet        bool       `json:"nosnippet,omitempty"`
+	NoImageIndex     bool       `json:"noimageindex,omitempty"`
+	NoTranslate      bool       `json:"notranslate,omitempty"`
+	MaxSnippet       *int       `json:"maxSnippet,omitempty"`
+	MaxImagePreview  string     `json:"maxImagePreview,omitempty"`
+	MaxVideoPreview  *int       `json:"maxVideoPreview,omitempty"`
+	UnavailableAfter *time.Time `json:"unavailableAfter,omitempty"`
+}
+
+// RobotsDirectives returns the directives declared by robots meta tags and
+// any X-Robots-Tag response headers, or nil when the page declares none
+func (m *Metadata) RobotsDirectives() *RobotsDirectives {
+	var values []string
+	for key, declared := range m.Meta() {
+		if strings.EqualFold(key, keys.Robots) {
+			values = append(values, declared...)
+		}
+	}
+	values = append(values, m.Headers.Values("X-Robots-Tag")...)
+
+	if len(values) == 0 {
+		return nil
+	}
+	return ParseRobotsDirectives(values...)
+}
+
+// AllowedImagePreview returns the largest image preview the page permits.
+// It is ImagePreviewNone when the page declares max-image-preview:none or
+// noimageindex, the declared max-image-preview size otherwise, and
+// ImagePreviewLarge when the page is silent.
+func (m *Metadata) AllowedImagePreview() string {
+	directives := m.RobotsDirectives()
+	if directives == nil {
+		return ImagePreviewLarge
+	}
+
+	if directives.NoImageIndex {
+		return ImagePreviewNone
+	}
+
+	if directives.MaxImagePreview != "" {
+		return directives.MaxImagePreview
+	}
+	return ImagePreviewLarge
+}
+
+// imagesSuppressed reports whether image fields should be withheld because
+// robots directives are enforced and the page opts out of image previews
+func (m *Metadata) imagesSuppressed() bool {
+	return m.RespectRobots && m.AllowedImagePreview() == ImagePreviewNone
+}
+
+// ParseRobotsDirectives parses robots meta content or X-Robots-Tag header
+// values into a combined directive set. Header values scoped to a specific
+// crawler (e.g. "googlebot: noindex") are ignored.
+func ParseRobotsDirectives(values ...string) *RobotsDirectives {
+	directives := &RobotsDirectives{}
+
+	for _, value := range values {
+		tokens := strings.Split(value, ",")
+		if len(tokens) > 0 && isCrawlerScoped(tokens[0]) {
+			continue
+		}
+
+		for i := 0; i < len(tokens); i++ {
+			name, arg, _ := strings.Cut(strings.TrimSpace(tokens[i]), ":")
+			name = strings.ToLower(strings.TrimSpace(name))
+			arg = strings.TrimSpace(arg)
+
+			if name == "unavailable_after" {
+				// RFC 850 style dates contain a comma, so retry with the
+				// following token before giving up
+				date, err := ParseTime(arg)
+				if err != nil && i+1 < len(tokens) {
+					if date, err = ParseTime(arg + "," + tokens[i+1]); err == nil {
+						i++
+					}
+				}
+				if err == nil {
+					directives.setUnavailableAfter(date)
+				}
+				continue
+			}
+
+			directives.apply(name, arg)
+		}
+	}
+
+	return directives
+}
+
+// isCrawlerScoped reports whether a header value starts with a user agent
+// prefix rather than a directive
+func isCrawlerScoped(token string) bool {
+	name, _, found := strings.Cut(strings.TrimSpace(token), ":")
+	if !found {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "max-snippet", "max-image-preview", "max-video-preview", "unavailable_after":
+		return false
+	}
+
+	// A scoped value is "<crawler>: <directive>", so the name is not itself
+	// a directive
+	return !strings.ContainsAny(name, " ")
+}
+
+// apply records a single directive
+func (d *RobotsDirectives) apply(name, arg string) {
+	switch name {
+	case "none":
+		d.NoIndex = true
+		d.NoFollow = true
+	case "noindex":
+		d.NoIndex = true
+	case "nofollow":
+		d.NoFollow = true
+	case "noarchive", "nocache":
+		d.NoArchive = true
+	case "nosnippet":
+		d.NoSnippet = true
+	case "noimageindex":
+		d.NoImageIndex = true
+	case "notranslate":
+		d.NoTranslate = true
+	case "max-snippet":
+		d.MaxSnippet = minLimit(d.MaxSnippet, arg)
+	case "max-video-preview":
+		d.MaxVideoPreview = minLimit(d.MaxVideoPreview, arg)
+	case "max-image-preview":
+		size := strings.ToLower(arg)
+		rank, known := imagePreviewRank[size]
+		if !known {
+			return
+		}
+		if current, set := imagePreviewRank[d.MaxImagePreview]; !set || rank < current {
+			d.MaxImagePreview = size
+		}
+	}
+}
+
+// setUnavailableAfter keeps the earliest unavailable_after date
+func (d *RobotsDirectives) setUnavailableAfter(date time.Time) {
+	if d.UnavailableAfter == nil || date.Before(*d.UnavailableAfter) {
+		d.UnavailableAfter = &date
+	}
+}
+
+// minLimit returns the more restrictive of an existing limit and a newly
+// declared one. A limit of -1 means no limit.
+func minLimit(current *int, arg string) *int {
+	limit, err := strconv.Atoi(arg)
+	if err != nil || limit < -1 {
+		return current
+	}
+
+	if current == nil || *current == -1 || (limit != -1 && limit < *current) {
+		return &limit
+	}
+	return current
+}
@@ -0,0 +1,192 @@
+package metadata
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestParseRobotsDirectives(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected RobotsDirectives
+	}{
+		{
+			name:     "noindex nofollow",
+			values:   []string{"noindex, nofollow"},
+			expected: RobotsDirectives{NoIndex: true, NoFollow: true},
+		},
+		{
+			name:     "none expands to noindex and nofollow",
+			values:   []string{"NONE"},
+			expected: RobotsDirectives{NoIndex: true, NoFollow: true},
+		},
+		{
+			name:     "preview limits",
+			values:   []string{"max-image-preview:large, max-snippet:50, max-video-preview:-1"},
+			expected: RobotsDirectives{MaxImagePreview: ImagePreviewLarge, MaxSnippet: intPtr(50), MaxVideoPreview: intPtr(-1)},
+		},
+		{
+			name:     "most restrictive value wins",
+			values:   []string{"max-image-preview:large, max-snippet:-1", "max-image-preview:none, max-snippet:20"},
+			expected: RobotsDirectives{MaxImagePreview: ImagePreviewNone, MaxSnippet: intPtr(20)},
+		},
+		{
+			name:     "unknown image preview size ignored",
+			values:   []string{"max-image-preview:huge"},
+			expected: RobotsDirectives{},
+		},
+		{
+			name:     "crawler scoped header ignored",
+			values:   []string{"googlebot: noindex", "noimageindex"},
+			expected: RobotsDirectives{NoImageIndex: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseRobotsDirectives(tt.values...)
+
+			if result.NoIndex != tt.expected.NoIndex || result.NoFollow != tt.expected.NoFollow ||
+				result.NoImageIndex != tt.expected.NoImageIndex {
+				t.Errorf("ParseRobotsDirectives() = %+v, want %+v", result, tt.expected)
+			}
+
+			if result.MaxImagePreview != tt.expected.MaxImagePreview {
+				t.Errorf("MaxImagePreview = %v, want %v", result.MaxImagePreview, tt.expected.MaxImagePreview)
+			}
+
+			if !equalIntPtr(result.MaxSnippet, tt.expected.MaxSnippet) {
+				t.Errorf("MaxSnippet = %v, want %v", result.MaxSnippet, tt.expected.MaxSnippet)
+			}
+
+			if !equalIntPtr(result.MaxVideoPreview, tt.expected.MaxVideoPreview) {
+				t.Errorf("MaxVideoPreview = %v, want %v", result.MaxVideoPreview, tt.expected.MaxVideoPreview)
+			}
+		})
+	}
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func TestParseRobotsDirectives_UnavailableAfter(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Time
+	}{
+		{
+			name:     "ISO 8601 date",
+			value:    "noindex, unavailable_after: 2025-06-25",
+			expected: time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "RFC 850 style date containing a comma",
+			value:    "unavailable_after: Wed, 25 Jun 2025 15:00:00 GMT, nofollow",
+			expected: time.Date(2025, 6, 25, 15, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ParseRobotsDirectives(tt.value)
+
+			if result.UnavailableAfter == nil || !result.UnavailableAfter.Equal(tt.expected) {
+				t.Errorf("UnavailableAfter = %v, want %v", result.UnavailableAfter, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_RobotsDirectives(t *testing.T) {
+	mockProvider := &MockProvider{name: "meta", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+	m.AddData("meta", "robots", "max-image-preview:standard")
+	m.Headers = http.Header{"X-Robots-Tag": {"noindex"}}
+
+	directives := m.RobotsDirectives()
+	if directives == nil {
+		t.Fatal("RobotsDirectives() = nil, want non-nil")
+	}
+
+	if !directives.NoIndex {
+		t.Error("NoIndex = false, want true from X-Robots-Tag header")
+	}
+
+	if directives.MaxImagePreview != ImagePreviewStandard {
+		t.Errorf("MaxImagePreview = %v, want %v", directives.MaxImagePreview, ImagePreviewStandard)
+	}
+}
+
+func TestMetadata_RobotsDirectives_None(t *testing.T) {
+	mockProvider := &MockProvider{name: "meta", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+
+	if directives := m.RobotsDirectives(); directives != nil {
+		t.Errorf("RobotsDirectives() = %+v, want nil", directives)
+	}
+}
+
+func TestMetadata_AllowedImagePreview(t *testing.T) {
+	tests := []struct {
+		name     string
+		robots   string
+		expected string
+	}{
+		{name: "no directives", robots: "", expected: ImagePreviewLarge},
+		{name: "standard preview", robots: "max-image-preview:standard", expected: ImagePreviewStandard},
+		{name: "no image preview", robots: "max-image-preview:none", expected: ImagePreviewNone},
+		{name: "noimageindex", robots: "noimageindex, max-image-preview:large", expected: ImagePreviewNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProvider := &MockProvider{name: "meta", priority: 1}
+			registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+			m := NewMetadata(registry)
+			if tt.robots != "" {
+				m.AddData("meta", "robots", tt.robots)
+			}
+
+			if result := m.AllowedImagePreview(); result != tt.expected {
+				t.Errorf("AllowedImagePreview() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_RespectRobots(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "meta", priority: 2},
+	}}
+	m := NewMetadata(registry)
+	m.AddData("openGraph", "image", "https://example.com/image.jpg")
+	m.AddData("meta", "robots", "max-image-preview:none")
+
+	if image := m.Image(); image == nil {
+		t.Error("Image() = nil, want image when robots directives are not enforced")
+	}
+
+	m.RespectRobots = true
+
+	if image := m.Image(); image != nil {
+		t.Errorf("Image() = %v, want nil when image previews are disallowed", *image)
+	}
+
+	if images := m.Images(); images != nil {
+		t.Errorf("Images() = %v, want nil when image previews are disallowed", images)
+	}
+}
@@ -0,0 +1,50 @@
+package metadata
+
+import "github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+
+// ScoreComponent is one weighted check in a completeness score
+type ScoreComponent struct {
+	Name    string `json:"name"`
+	Weight  int    `json:"weight"`
+	Present bool   `json:"present"`
+}
+
+// Score is a page's weighted completeness score out of 100 along with the
+// checks that produced it
+type Score struct {
+	Total      int              `json:"total"`
+	Components []ScoreComponent `json:"components"`
+}
+
+// scoreWeights are the completeness checks and their weights, which sum to 100
+var scoreWeights = []struct {
+	name    string
+	weight  int
+	present func(m *Metadata) bool
+}{
+	{keys.Title, 25, func(m *Metadata) bool { return m.Title() != nil }},
+	{keys.Description, 20, func(m *Metadata) bool { return m.Description() != nil }},
+	{keys.Image, 25, func(m *Metadata) bool { return m.Image() != nil }},
+	{"canonical", 15, func(m *Metadata) bool { return m.URLCandidates().Canonical != "" }},
+	{keys.Card, 15, func(m *Metadata) bool { return len(m.TwitterCard()[keys.Card]) > 0 }},
+}
+
+// Score computes how complete the page's preview metadata is, so pages can
+// be sorted by a single number when auditing
+func (m *Metadata) Score() *Score {
+	score := &Score{Components: make([]ScoreComponent, 0, len(scoreWeights))}
+
+	for _, check := range scoreWeights {
+		present := check.present(m)
+		if present {
+			score.Total += check.weight
+		}
+		score.Components = append(score.Components, ScoreComponent{
+			Name:    check.name,
+			Weight:  check.weight,
+			Present: present,
+		})
+	}
+
+	return score
+}
@@ -0,0 +1,66 @@
+package metadata
+
+import "testing"
+
+func TestMetadata_Score(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]map[string]string
+		expected int
+	}{
+		{
+			name:     "empty page",
+			data:     map[string]map[string]string{},
+			expected: 0,
+		},
+		{
+			name: "title and description",
+			data: map[string]map[string]string{
+				"other": {"title": "Title"},
+				"meta":  {"description": "Description"},
+			},
+			expected: 45,
+		},
+		{
+			name: "og:url without a canonical link",
+			data: map[string]map[string]string{
+				"openGraph": {"title": "Title", "url": "https://example.com/"},
+			},
+			expected: 25,
+		},
+		{
+			name: "complete page",
+			data: map[string]map[string]string{
+				"openGraph": {"title": "Title", "description": "Description", "image": "https://example.com/a.jpg"},
+				"other":     {"url": "https://example.com/"},
+				"twitter":   {"card": "summary"},
+			},
+			expected: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := newValidationMetadata(tt.data).Score()
+
+			if score.Total != tt.expected {
+				t.Errorf("Score().Total = %v, want %v", score.Total, tt.expected)
+			}
+
+			if len(score.Components) != len(scoreWeights) {
+				t.Errorf("Expected %d components, got %d", len(scoreWeights), len(score.Components))
+			}
+		})
+	}
+}
+
+func TestScoreWeights_SumTo100(t *testing.T) {
+	total := 0
+	for _, check := range scoreWeights {
+		total += check.weight
+	}
+
+	if total != 100 {
+		t.Errorf("score weights sum to %d, want 100", total)
+	}
+}
@@ -0,0 +1,54 @@
+package metadata
+
+import (
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// SecurityPolicy summarizes the page-level security policies declared in
+// meta tags
+type SecurityPolicy struct {
+	Referrer              *string  `json:"referrer,omitempty"`
+	ContentSecurityPolicy []string `json:"contentSecurityPolicy,omitempty"`
+	PermissionsPolicy     *string  `json:"permissionsPolicy,omitempty"`
+}
+
+// Security returns the declared security policies, or nil when the page
+// declares none
+func (m *Metadata) Security() *SecurityPolicy {
+	policy := &SecurityPolicy{
+		Referrer:              m.resolveValue(keys.Referrer),
+		ContentSecurityPolicy: m.Meta()[keys.ContentSecurityPolicy],
+		PermissionsPolicy:     m.resolveValue(keys.PermissionsPolicy),
+	}
+
+	if policy.Referrer == nil && len(policy.ContentSecurityPolicy) == 0 && policy.PermissionsPolicy == nil {
+		return nil
+	}
+
+	return policy
+}
+
+// CSPDirectives parses the declared Content-Security-Policy values into
+// directive name → source list. When several policies declare the same
+// directive, the first declaration is kept.
+func (p *SecurityPolicy) CSPDirectives() map[string][]string {
+	directives := make(map[string][]string)
+
+	for _, policy := range p.ContentSecurityPolicy {
+		for _, directive := range strings.Split(policy, ";") {
+			fields := strings.Fields(directive)
+			if len(fields) == 0 {
+				continue
+			}
+
+			name := strings.ToLower(fields[0])
+			if _, exists := directives[name]; !exists {
+				directives[name] = fields[1:]
+			}
+		}
+	}
+
+	return directives
+}
@@ -0,0 +1,67 @@
+package metadata
+
+import "testing"
+
+func TestMetadata_Security(t *testing.T) {
+	mockProvider := &MockProvider{name: "meta", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+	m.AddData("meta", "referrer", "strict-origin-when-cross-origin")
+	m.AddData("meta", "content-security-policy", "default-src 'self'; img-src https: data:")
+	m.AddData("meta", "content-security-policy", "default-src 'none'; script-src 'self'")
+	m.AddData("meta", "permissions-policy", "geolocation=()")
+
+	policy := m.Security()
+	if policy == nil {
+		t.Fatal("Security() = nil, want non-nil")
+	}
+
+	if policy.Referrer == nil || *policy.Referrer != "strict-origin-when-cross-origin" {
+		t.Errorf("Referrer = %v, want %v", policy.Referrer, "strict-origin-when-cross-origin")
+	}
+
+	if len(policy.ContentSecurityPolicy) != 2 {
+		t.Errorf("Expected 2 CSP declarations, got %d", len(policy.ContentSecurityPolicy))
+	}
+
+	if policy.PermissionsPolicy == nil || *policy.PermissionsPolicy != "geolocation=()" {
+		t.Errorf("PermissionsPolicy = %v, want %v", policy.PermissionsPolicy, "geolocation=()")
+	}
+}
+
+func TestMetadata_Security_None(t *testing.T) {
+	mockProvider := &MockProvider{name: "meta", priority: 1}
+	registry := &MockRegistry{providers: []MetadataProvider{mockProvider}}
+	m := NewMetadata(registry)
+
+	if policy := m.Security(); policy != nil {
+		t.Errorf("Security() = %+v, want nil", policy)
+	}
+}
+
+func TestSecurityPolicy_CSPDirectives(t *testing.T) {
+	policy := &SecurityPolicy{
+		ContentSecurityPolicy: []string{
+			"default-src 'self'; img-src https: data:;",
+			"DEFAULT-SRC 'none'; upgrade-insecure-requests",
+		},
+	}
+
+	directives := policy.CSPDirectives()
+
+	if len(directives) != 3 {
+		t.Errorf("Expected 3 directives, got %d: %v", len(directives), directives)
+	}
+
+	if sources := directives["default-src"]; len(sources) != 1 || sources[0] != "'self'" {
+		t.Errorf("default-src = %v, want ['self']", sources)
+	}
+
+	if sources := directives["img-src"]; len(sources) != 2 {
+		t.Errorf("img-src = %v, want 2 sources", sources)
+	}
+
+	if sources, exists := directives["upgrade-insecure-requests"]; !exists || len(sources) != 0 {
+		t.Errorf("upgrade-insecure-requests = %v, want present with no sources", sources)
+	}
+}
@@ -0,0 +1,96 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// timeLayouts are the date formats accepted by ParseTime, tried in order.
+// Layouts without a zone are interpreted as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.ANSIC,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"January 2, 2006",
+	"Jan 2, 2006",
+}
+
+// publishedTimeKeys is the fallback order for PublishedTime: the Open Graph
+// article namespace, JSON-LD datePublished, microformats dt-published, then
+// generic date meta tags
+var publishedTimeKeys = []string{
+	keys.ArticlePublishedTime,
+	"datePublished",
+	keys.PublishedTime,
+	"date",
+	"DC.date.issued",
+	"dc.date",
+}
+
+// modifiedTimeKeys is the fallback order for ModifiedTime: the Open Graph
+// article namespace and og:updated_time, JSON-LD dateModified, microformats
+// dt-updated, then the Last-Modified http-equiv declaration
+var modifiedTimeKeys = []string{
+	keys.ArticleModifiedTime,
+	keys.UpdatedTime,
+	"dateModified",
+	keys.ModifiedTime,
+	keys.LastModified,
+}
+
+// ParseTime parses a date in ISO-8601 or one of the common RFC formats
+func ParseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time format: %q", value)
+}
+
+// PublishedTime returns the publication time, taking the first parseable
+// value in publishedTimeKeys order
+func (m *Metadata) PublishedTime() *time.Time {
+	return m.resolveTime(publishedTimeKeys)
+}
+
+// ModifiedTime returns the last modification time, taking the first
+// parseable value in modifiedTimeKeys order
+func (m *Metadata) ModifiedTime() *time.Time {
+	return m.resolveTime(modifiedTimeKeys)
+}
+
+// resolveTime returns the first key whose value parses as a time
+func (m *Metadata) resolveTime(keys []string) *time.Time {
+	for _, key := range keys {
+		value := m.resolveValue(key)
+		if value == nil {
+			continue
+		}
+		if parsed, err := ParseTime(*value); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"2024-03-05T10:20:30Z", time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)},
+		{"2024-03-05T10:20:30.123+02:00", time.Date(2024, 3, 5, 8, 20, 30, 123000000, time.UTC)},
+		{"2024-03-05T10:20:30+0200", time.Date(2024, 3, 5, 8, 20, 30, 0, time.UTC)},
+		{"2024-03-05T10:20", time.Date(2024, 3, 5, 10, 20, 0, 0, time.UTC)},
+		{"2024-03-05 10:20:30", time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)},
+		{" 2024-03-05 ", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"Tue, 05 Mar 2024 10:20:30 GMT", time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)},
+		{"Tue, 5 Mar 2024 10:20:30 -0500", time.Date(2024, 3, 5, 15, 20, 30, 0, time.UTC)},
+		{"March 5, 2024", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseTime(tt.input)
+			if err != nil {
+				t.Fatalf("ParseTime() returned error: %v", err)
+			}
+
+			if !result.Equal(tt.expected) {
+				t.Errorf("ParseTime() = %v, want %v", result.UTC(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTime_Invalid(t *testing.T) {
+	for _, input := range []string{"", "yesterday", "2024-13-45"} {
+		if _, err := ParseTime(input); err == nil {
+			t.Errorf("ParseTime(%q) expected error", input)
+		}
+	}
+}
+
+func TestMetadata_PublishedTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]string
+		expected *time.Time
+	}{
+		{
+			name: "article published time wins",
+			data: map[string]string{
+				"article:published_time": "2024-01-01T00:00:00Z",
+				"date":                   "2023-01-01",
+			},
+			expected: timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name: "unparseable value falls through",
+			data: map[string]string{
+				"article:published_time": "last week",
+				"date":                   "2023-06-07",
+			},
+			expected: timePtr(time.Date(2023, 6, 7, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:     "no date",
+			data:     map[string]string{"title": "Untimed"},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "test", priority: 1}}}
+			m := NewMetadata(registry)
+			for key, value := range tt.data {
+				m.AddData("test", key, value)
+			}
+
+			result := m.PublishedTime()
+
+			if tt.expected == nil {
+				if result != nil {
+					t.Errorf("PublishedTime() = %v, want nil", result)
+				}
+				return
+			}
+
+			if result == nil || !result.Equal(*tt.expected) {
+				t.Errorf("PublishedTime() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetadata_ModifiedTime(t *testing.T) {
+	registry := &MockRegistry{providers: []MetadataProvider{&MockProvider{name: "test", priority: 1}}}
+	m := NewMetadata(registry)
+	m.AddData("test", "last-modified", "Tue, 05 Mar 2024 10:20:30 GMT")
+	m.AddData("test", "updated_time", "2024-04-01T12:00:00Z")
+
+	result := m.ModifiedTime()
+	expected := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	if result == nil || !result.Equal(expected) {
+		t.Errorf("ModifiedTime() = %v, want %v", result, expected)
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
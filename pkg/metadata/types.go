@@ -1,6 +1,10 @@
 package metadata
 
-import "golang.org/x/net/html"
+import (
+	"time"
+
+	"golang.org/x/net/html"
+)
 
 // MetadataProvider defines the interface for metadata extraction providers
 type MetadataProvider interface {
@@ -20,10 +24,22 @@ type MetadataProvider interface {
 	GetValue(key string, data map[string][]string) *string
 }
 
+// ValuesProvider is an optional interface for providers that can resolve
+// every value for a key rather than only the first. Providers that don't
+// implement it are assumed to store values directly under their keys.
+type ValuesProvider interface {
+	// GetValues resolves all values for a given key from the provider's data
+	GetValues(key string, data map[string][]string) []string
+}
+
 // ScrapedData represents extracted metadata from a provider
 type ScrapedData struct {
 	Key   string
 	Value string
+
+	// Attribute optionally names the element attribute the value was read
+	// from (e.g. "content"). It is empty for text content.
+	Attribute string
 }
 
 // ProviderData aggregates data from all providers
@@ -36,6 +52,22 @@ type Feed struct {
 	Href  string  `json:"href"`
 }
 
+// Icon represents a <link> icon declaration: rel="icon", "shortcut icon",
+// "apple-touch-icon" or "mask-icon"
+type Icon struct {
+	Rel   string `json:"rel"`
+	Href  string `json:"href"`
+	Type  string `json:"type,omitempty"`
+	Sizes string `json:"sizes,omitempty"`
+}
+
+// Archive describes an archived copy of a page, scraped in place of the
+// unavailable live page
+type Archive struct {
+	URL       string    `json:"url"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
 // ScrapingResult represents the result of a scraping operation
 type ScrapingResult struct {
 	Provider *MetadataProvider
@@ -51,3 +83,11 @@ type Registry interface {
 	RemoveProvider(name string)
 	GetProvider(name string) MetadataProvider
 }
+
+// MultiClaimRegistry is an optional interface for registries that can let
+// every capable provider extract from the same element
+type MultiClaimRegistry interface {
+	// ScrapeAllFromElement returns the data from every provider that yields
+	// data for the element, in priority order
+	ScrapeAllFromElement(node *html.Node) []*ScrapingResult
+}
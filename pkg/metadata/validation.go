@@ -0,0 +1,190 @@
+package metadata
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+)
+
+// Severity grades a validation issue
+type Severity string
+
+const (
+	// SeverityError marks tags that are missing or invalid per the spec
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks tags that work but degrade previews
+	SeverityWarning Severity = "warning"
+)
+
+// Length limits beyond which platforms truncate previews
+const (
+	MaxTitleLength       = 70
+	MaxDescriptionLength = 160
+)
+
+// requiredOpenGraph lists the properties the Open Graph protocol requires
+var requiredOpenGraph = []string{keys.Title, keys.Type, keys.Image, keys.URL}
+
+// twitterCardRequirements lists the twitter: properties each card type needs
+var twitterCardRequirements = map[string][]string{
+	"summary":             nil,
+	"summary_large_image": nil,
+	"app":                 {"app:id:iphone", "app:id:ipad", "app:id:googleplay"},
+	"player":              {"player", "player:width", "player:height"},
+}
+
+// Issue is a single validation finding
+type Issue struct {
+	Severity Severity `json:"severity"`
+	Field    string   `json:"field"`
+	Message  string   `json:"message"`
+}
+
+// ValidationReport lists the issues found by Validate
+type ValidationReport struct {
+	Issues []Issue `json:"issues"`
+}
+
+// Valid reports whether the report contains no errors
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors returns the issues with SeverityError
+func (r *ValidationReport) Errors() []Issue {
+	return r.bySeverity(SeverityError)
+}
+
+// Warnings returns the issues with SeverityWarning
+func (r *ValidationReport) Warnings() []Issue {
+	return r.bySeverity(SeverityWarning)
+}
+
+// bySeverity filters issues by severity
+func (r *ValidationReport) bySeverity(severity Severity) []Issue {
+	var issues []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// add records an issue
+func (r *ValidationReport) add(severity Severity, field, format string, args ...any) {
+	r.Issues = append(r.Issues, Issue{
+		Severity: severity,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate checks the page's preview tags against the Open Graph and
+// Twitter Card specs and common length limits
+func (m *Metadata) Validate() *ValidationReport {
+	report := &ValidationReport{Issues: make([]Issue, 0)}
+
+	m.validateOpenGraph(report)
+	m.validateTwitterCard(report)
+	m.validateLengths(report)
+	m.validateURLs(report)
+
+	return report
+}
+
+// validateOpenGraph reports missing required Open Graph properties
+func (m *Metadata) validateOpenGraph(report *ValidationReport) {
+	openGraph := m.OpenGraph()
+	for _, property := range requiredOpenGraph {
+		if len(openGraph[property]) == 0 {
+			report.add(SeverityError, "og:"+property, "missing required Open Graph property og:%s", property)
+		}
+	}
+}
+
+// validateTwitterCard checks the card type and the properties it requires
+func (m *Metadata) validateTwitterCard(report *ValidationReport) {
+	twitter := m.TwitterCard()
+
+	cards := twitter[keys.Card]
+	if len(cards) == 0 {
+		report.add(SeverityWarning, "twitter:card", "missing twitter:card; platforms fall back to a summary card")
+		return
+	}
+
+	card := strings.TrimSpace(cards[0])
+	required, known := twitterCardRequirements[card]
+	if !known {
+		report.add(SeverityError, "twitter:card", "unknown twitter:card type %q", card)
+		return
+	}
+
+	switch card {
+	case "app":
+		// Any one platform's app ID is enough
+		for _, property := range required {
+			if len(twitter[property]) > 0 {
+				return
+			}
+		}
+		report.add(SeverityError, "twitter:app:id", "app card requires twitter:app:id:iphone, twitter:app:id:ipad or twitter:app:id:googleplay")
+	case "summary_large_image":
+		if len(twitter[keys.Image]) == 0 && len(m.OpenGraph()[keys.Image]) == 0 {
+			report.add(SeverityError, "twitter:image", "summary_large_image card requires twitter:image or og:image")
+		}
+	default:
+		for _, property := range required {
+			if len(twitter[property]) == 0 {
+				report.add(SeverityError, "twitter:"+property, "%s card requires twitter:%s", card, property)
+			}
+		}
+	}
+}
+
+// validateLengths warns about titles and descriptions that will be truncated
+func (m *Metadata) validateLengths(report *ValidationReport) {
+	if title := m.Title(); title != nil {
+		if length := utf8.RuneCountInString(*title); length > MaxTitleLength {
+			report.add(SeverityWarning, keys.Title, "title is %d characters; previews truncate after %d", length, MaxTitleLength)
+		}
+	}
+
+	if description := m.Description(); description != nil {
+		if length := utf8.RuneCountInString(*description); length > MaxDescriptionLength {
+			report.add(SeverityWarning, keys.Description, "description is %d characters; previews truncate after %d", length, MaxDescriptionLength)
+		}
+	}
+}
+
+// validateURLs checks that image URLs are absolute and the canonical URL is
+// not relative
+func (m *Metadata) validateURLs(report *ValidationReport) {
+	for _, image := range m.OpenGraph()[keys.Image] {
+		if !isAbsoluteURL(image) {
+			report.add(SeverityError, "og:image", "og:image %q is not an absolute URL", image)
+		}
+	}
+
+	for _, image := range m.TwitterCard()[keys.Image] {
+		if !isAbsoluteURL(image) {
+			report.add(SeverityError, "twitter:image", "twitter:image %q is not an absolute URL", image)
+		}
+	}
+
+	for _, canonical := range m.Other()[keys.Canonical] {
+		if !isAbsoluteURL(canonical) {
+			report.add(SeverityWarning, "canonical", "canonical URL %q is relative", canonical)
+		}
+	}
+}
+
+// isAbsoluteURL reports whether raw is an absolute http(s) URL
+func isAbsoluteURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
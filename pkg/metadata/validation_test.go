@@ -0,0 +1,144 @@
+package metadata
+
+import (
+	"strings"
+	"testing"
+)
+
+func newValidationMetadata(data map[string]map[string]string) *Metadata {
+	registry := &MockRegistry{providers: []MetadataProvider{
+		&MockProvider{name: "openGraph", priority: 1},
+		&MockProvider{name: "twitter", priority: 2},
+		&MockProvider{name: "meta", priority: 3},
+		&MockProvider{name: "other", priority: 4},
+	}}
+	m := NewMetadata(registry)
+	for provider, values := range data {
+		for key, value := range values {
+			m.AddData(provider, key, value)
+		}
+	}
+	return m
+}
+
+// completeOpenGraph returns valid Open Graph and Twitter Card tags
+func completeOpenGraph() map[string]map[string]string {
+	return map[string]map[string]string{
+		"openGraph": {
+			"title": "Title",
+			"type":  "website",
+			"image": "https://example.com/image.jpg",
+			"url":   "https://example.com/",
+		},
+		"twitter": {"card": "summary"},
+	}
+}
+
+func hasIssue(report *ValidationReport, severity Severity, field string) bool {
+	for _, issue := range report.Issues {
+		if issue.Severity == severity && issue.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMetadata_Validate_Valid(t *testing.T) {
+	report := newValidationMetadata(completeOpenGraph()).Validate()
+
+	if !report.Valid() || len(report.Issues) != 0 {
+		t.Errorf("Validate() = %+v, want no issues", report.Issues)
+	}
+}
+
+func TestMetadata_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(data map[string]map[string]string)
+		severity Severity
+		field    string
+	}{
+		{
+			name:     "missing og:image",
+			modify:   func(data map[string]map[string]string) { delete(data["openGraph"], "image") },
+			severity: SeverityError,
+			field:    "og:image",
+		},
+		{
+			name:     "relative og:image",
+			modify:   func(data map[string]map[string]string) { data["openGraph"]["image"] = "/image.jpg" },
+			severity: SeverityError,
+			field:    "og:image",
+		},
+		{
+			name:     "missing twitter:card",
+			modify:   func(data map[string]map[string]string) { delete(data, "twitter") },
+			severity: SeverityWarning,
+			field:    "twitter:card",
+		},
+		{
+			name:     "unknown twitter:card",
+			modify:   func(data map[string]map[string]string) { data["twitter"]["card"] = "gallery" },
+			severity: SeverityError,
+			field:    "twitter:card",
+		},
+		{
+			name:     "player card without player",
+			modify:   func(data map[string]map[string]string) { data["twitter"]["card"] = "player" },
+			severity: SeverityError,
+			field:    "twitter:player",
+		},
+		{
+			name:     "app card without app id",
+			modify:   func(data map[string]map[string]string) { data["twitter"]["card"] = "app" },
+			severity: SeverityError,
+			field:    "twitter:app:id",
+		},
+		{
+			name: "long description",
+			modify: func(data map[string]map[string]string) {
+				data["openGraph"]["description"] = strings.Repeat("a", MaxDescriptionLength+1)
+			},
+			severity: SeverityWarning,
+			field:    "description",
+		},
+		{
+			name:     "relative canonical",
+			modify:   func(data map[string]map[string]string) { data["other"] = map[string]string{"url": "/page"} },
+			severity: SeverityWarning,
+			field:    "canonical",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := completeOpenGraph()
+			tt.modify(data)
+
+			report := newValidationMetadata(data).Validate()
+			if !hasIssue(report, tt.severity, tt.field) {
+				t.Errorf("Validate() = %+v, want %s for %s", report.Issues, tt.severity, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidationReport_Valid(t *testing.T) {
+	report := &ValidationReport{Issues: []Issue{
+		{Severity: SeverityWarning, Field: "title", Message: "long"},
+	}}
+
+	if !report.Valid() {
+		t.Error("Valid() = false, want true when only warnings are present")
+	}
+
+	report.Issues = append(report.Issues, Issue{Severity: SeverityError, Field: "og:title", Message: "missing"})
+
+	if report.Valid() {
+		t.Error("Valid() = true, want false when errors are present")
+	}
+
+	if len(report.Errors()) != 1 || len(report.Warnings()) != 1 {
+		t.Errorf("Errors() = %v, Warnings() = %v, want one of each", report.Errors(), report.Warnings())
+	}
+}
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+const AppLinksPrefix = "al:"
+
+// AppleITunesAppName is the meta name of Apple's Smart App Banner tag
+const AppleITunesAppName = "apple-itunes-app"
+
+// AppLinksProvider extracts App Links and Apple Smart App Banner metadata
+type AppLinksProvider struct {
+	BaseProvider
+}
+
+// NewAppLinksProvider creates a new App Links provider
+func NewAppLinksProvider() *AppLinksProvider {
+	return &AppLinksProvider{}
+}
+
+// Name returns the provider name
+func (p *AppLinksProvider) Name() string {
+	return "appLinks"
+}
+
+// Priority returns the provider priority (after the news provider)
+func (p *AppLinksProvider) Priority() int {
+	return 7
+}
+
+// CanHandle determines if this provider can handle the given element
+func (p *AppLinksProvider) CanHandle(node *html.Node) bool {
+	if node == nil || node.Type != html.ElementNode || node.Data != "meta" {
+		return false
+	}
+
+	property := p.getAttribute(node, "property")
+	name := p.getAttribute(node, "name")
+
+	return strings.HasPrefix(property, AppLinksPrefix) ||
+		strings.HasPrefix(name, AppLinksPrefix) ||
+		name == AppleITunesAppName
+}
+
+// Scrape extracts App Links data from the element
+func (p *AppLinksProvider) Scrape(node *html.Node) *metadata.ScrapedData {
+	if !p.CanHandle(node) {
+		return nil
+	}
+
+	return p.scrapeMetaTag(node, AppLinksPrefix)
+}
@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestAppLinksProvider_Name(t *testing.T) {
+	provider := NewAppLinksProvider()
+	if provider.Name() != "appLinks" {
+		t.Errorf("Expected name 'appLinks', got '%s'", provider.Name())
+	}
+}
+
+func TestAppLinksProvider_Priority(t *testing.T) {
+	provider := NewAppLinksProvider()
+	if provider.Priority() != 7 {
+		t.Errorf("Expected priority 7, got %d", provider.Priority())
+	}
+}
+
+func TestAppLinksProvider_CanHandle(t *testing.T) {
+	provider := NewAppLinksProvider()
+
+	tests := []struct {
+		name     string
+		element  string
+		attrs    []html.Attribute
+		expected bool
+	}{
+		{
+			name:     "al: property",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "property", Val: "al:ios:url"}, {Key: "content", Val: "app://path"}},
+			expected: true,
+		},
+		{
+			name:     "al: name",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "name", Val: "al:android:package"}, {Key: "content", Val: "com.example"}},
+			expected: true,
+		},
+		{
+			name:     "apple-itunes-app",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "name", Val: "apple-itunes-app"}, {Key: "content", Val: "app-id=123"}},
+			expected: true,
+		},
+		{
+			name:     "og: property",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "property", Val: "og:title"}, {Key: "content", Val: "Title"}},
+			expected: false,
+		},
+		{
+			name:     "non-meta element",
+			element:  "link",
+			attrs:    []html.Attribute{{Key: "property", Val: "al:ios:url"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &html.Node{Type: html.ElementNode, Data: tt.element, Attr: tt.attrs}
+			if result := provider.CanHandle(node); result != tt.expected {
+				t.Errorf("CanHandle() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAppLinksProvider_Scrape(t *testing.T) {
+	provider := NewAppLinksProvider()
+
+	tests := []struct {
+		name          string
+		attrs         []html.Attribute
+		expectedKey   string
+		expectedValue string
+	}{
+		{
+			name:          "al: prefix is removed",
+			attrs:         []html.Attribute{{Key: "property", Val: "al:ios:app_store_id"}, {Key: "content", Val: "12345"}},
+			expectedKey:   "ios:app_store_id",
+			expectedValue: "12345",
+		},
+		{
+			name:          "apple-itunes-app keeps its name",
+			attrs:         []html.Attribute{{Key: "name", Val: "apple-itunes-app"}, {Key: "content", Val: "app-id=123"}},
+			expectedKey:   "apple-itunes-app",
+			expectedValue: "app-id=123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &html.Node{Type: html.ElementNode, Data: "meta", Attr: tt.attrs}
+
+			result := provider.Scrape(node)
+			if result == nil {
+				t.Fatal("Scrape() = nil, want non-nil")
+			}
+
+			if result.Key != tt.expectedKey {
+				t.Errorf("Scrape().Key = %v, want %v", result.Key, tt.expectedKey)
+			}
+
+			if result.Value != tt.expectedValue {
+				t.Errorf("Scrape().Value = %v, want %v", result.Value, tt.expectedValue)
+			}
+		})
+	}
+}
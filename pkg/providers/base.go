@@ -11,6 +11,9 @@ type BaseProvider struct{}
 
 // getAttribute gets an attribute value from a node
 func (b *BaseProvider) getAttribute(n *html.Node, key string) string {
+	if n == nil {
+		return ""
+	}
 	for _, attr := range n.Attr {
 		if attr.Key == key {
 			return attr.Val
@@ -19,14 +22,21 @@ func (b *BaseProvider) getAttribute(n *html.Node, key string) string {
 	return ""
 }
 
-// getTextContent extracts text content from a node
+// getTextContent extracts text content from a node, skipping non-text
+// elements (see metadata.IsNonTextElement)
 func (b *BaseProvider) getTextContent(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
 	if n.Type == html.TextNode {
 		return n.Data
 	}
 
 	var result strings.Builder
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && metadata.IsNonTextElement(c.Data) {
+			continue
+		}
 		result.WriteString(b.getTextContent(c))
 	}
 	return strings.TrimSpace(result.String())
@@ -47,7 +57,7 @@ func (b *BaseProvider) scrapeMetaTag(node *html.Node, prefixToRemove string) *me
 		property = b.getAttribute(node, "name")
 	}
 
-	content := b.getAttribute(node, "content")
+	content := metadata.NormalizeValue(b.getAttribute(node, "content"))
 
 	if property == "" || content == "" {
 		return nil
@@ -56,7 +66,8 @@ func (b *BaseProvider) scrapeMetaTag(node *html.Node, prefixToRemove string) *me
 	key := strings.TrimPrefix(property, prefixToRemove)
 
 	return &metadata.ScrapedData{
-		Key:   key,
-		Value: content,
+		Key:       key,
+		Value:     content,
+		Attribute: "content",
 	}
 }
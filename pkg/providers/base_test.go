@@ -0,0 +1,145 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+func TestBaseProvider_NilNode(t *testing.T) {
+	base := &BaseProvider{}
+
+	if value := base.getAttribute(nil, "content"); value != "" {
+		t.Errorf("getAttribute(nil) = %q, want empty", value)
+	}
+
+	if text := base.getTextContent(nil); text != "" {
+		t.Errorf("getTextContent(nil) = %q, want empty", text)
+	}
+
+	if data := base.scrapeMetaTag(&html.Node{Type: html.ElementNode, Data: "meta"}, ""); data != nil {
+		t.Errorf("scrapeMetaTag() with no attributes = %v, want nil", data)
+	}
+}
+
+func TestProviders_NormalizeValues(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<title>
+	Tom &amp;amp; Jerry
+</title>
+<meta property="og:title" content="  Cats &amp;amp;
+	Mice ">
+<meta http-equiv="Content-Language" content=" en ">
+<script type="application/ld+json">{"name": "Tom &amp; Jerry"}</script>
+</head><body><h1>A   multi-line
+heading</h1></body></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	expected := map[string]string{
+		"other/title":           "Tom & Jerry",
+		"other/firstHeading":    "A multi-line heading",
+		"openGraph/title":       "Cats & Mice",
+		"meta/content-language": "en",
+		"jsonLd/" + JSONLDKey:   `{"name": "Tom &amp; Jerry"}`,
+	}
+
+	providers := []metadata.MetadataProvider{NewOtherElementsProvider(), NewOpenGraphProvider(), NewStandardMetaProvider(), NewJSONLDProvider()}
+	for n := range doc.Descendants() {
+		if n.Type != html.ElementNode {
+			continue
+		}
+
+		for _, provider := range providers {
+			if !provider.CanHandle(n) {
+				continue
+			}
+			data := provider.Scrape(n)
+			if data == nil {
+				continue
+			}
+
+			key := provider.Name() + "/" + data.Key
+			if want, found := expected[key]; !found || data.Value != want {
+				t.Errorf("Scrape() %s = %q, want %q", key, data.Value, want)
+			}
+		}
+	}
+}
+
+func TestProviders_MalformedNodes(t *testing.T) {
+	nodes := []struct {
+		name string
+		node *html.Node
+	}{
+		{name: "nil node", node: nil},
+		{name: "empty element", node: &html.Node{Type: html.ElementNode}},
+		{name: "meta without attributes", node: &html.Node{Type: html.ElementNode, Data: "meta"}},
+		{name: "title without children", node: &html.Node{Type: html.ElementNode, Data: "title"}},
+		{name: "script without children", node: &html.Node{
+			Type: html.ElementNode,
+			Data: "script",
+			Attr: []html.Attribute{{Key: "type", Val: "application/ld+json"}},
+		}},
+		{name: "comment child only", node: &html.Node{
+			Type:       html.ElementNode,
+			Data:       "h1",
+			FirstChild: &html.Node{Type: html.CommentNode, Data: "comment"},
+		}},
+		{name: "microformat property without root", node: &html.Node{
+			Type: html.ElementNode,
+			Data: "span",
+			Attr: []html.Attribute{{Key: "class", Val: "p-name"}},
+		}},
+		{name: "document node", node: &html.Node{Type: html.DocumentNode}},
+		{name: "error node", node: &html.Node{Type: html.ErrorNode}},
+	}
+
+	for _, provider := range NewLoader().LoadDefaults() {
+		for _, tt := range nodes {
+			t.Run(provider.Name()+"/"+tt.name, func(t *testing.T) {
+				provider.CanHandle(tt.node)
+				if data := provider.Scrape(tt.node); data != nil {
+					t.Errorf("Scrape() = %+v, want nil", data)
+				}
+			})
+		}
+	}
+}
+
+// FuzzProviders_Scrape runs every default provider over every node of
+// arbitrary documents; none may panic
+func FuzzProviders_Scrape(f *testing.F) {
+	f.Add(`<html lang="en"><head><title>T</title><meta property="og:title" content="x"></head></html>`)
+	f.Add(`<meta charset><meta http-equiv="Content-Type" content=";;"><meta name="robots">`)
+	f.Add(`<script type="application/ld+json">{"@graph": [null, 1, "x"]}</script>`)
+	f.Add(`<div class="h-entry"><time class="dt-published"></time><a class="u-url"></a></div>`)
+	f.Add(`<link rel="icon"><link rel="canonical" href=""><h1><!-- --></h1>`)
+
+	providerList := NewLoader().LoadDefaults()
+
+	f.Fuzz(func(t *testing.T, source string) {
+		doc, err := html.Parse(strings.NewReader(source))
+		if err != nil {
+			return
+		}
+
+		var walk func(*html.Node)
+		walk = func(n *html.Node) {
+			for _, provider := range providerList {
+				if provider.CanHandle(n) {
+					if data := provider.Scrape(n); data != nil {
+						provider.GetValue(data.Key, map[string][]string{data.Key: {data.Value}})
+					}
+				}
+			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walk(c)
+			}
+		}
+		walk(doc)
+	})
+}
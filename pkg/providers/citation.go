@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+	"golang.org/x/net/html"
+)
+
+const CitationPrefix = "citation_"
+
+// citationKeyMap maps Highwire citation properties onto the standard keys
+// shared with the other providers
+var citationKeyMap = map[string]string{
+	"title":  keys.Title,
+	"author": keys.Author,
+}
+
+// CitationProvider extracts scholarly citation (Highwire Press) metadata
+type CitationProvider struct {
+	BaseProvider
+}
+
+// NewCitationProvider creates a new citation provider
+func NewCitationProvider() *CitationProvider {
+	return &CitationProvider{}
+}
+
+// Name returns the provider name
+func (p *CitationProvider) Name() string {
+	return "citation"
+}
+
+// Priority returns the provider priority (after the built-in providers)
+func (p *CitationProvider) Priority() int {
+	return 5
+}
+
+// CanHandle determines if this provider can handle the given element
+func (p *CitationProvider) CanHandle(node *html.Node) bool {
+	if node == nil || node.Type != html.ElementNode || node.Data != "meta" {
+		return false
+	}
+
+	name := p.getAttribute(node, "name")
+	property := p.getAttribute(node, "property")
+
+	return strings.HasPrefix(name, CitationPrefix) || strings.HasPrefix(property, CitationPrefix)
+}
+
+// Scrape extracts citation data from the element
+func (p *CitationProvider) Scrape(node *html.Node) *metadata.ScrapedData {
+	if !p.CanHandle(node) {
+		return nil
+	}
+
+	data := p.scrapeMetaTag(node, CitationPrefix)
+	if data == nil {
+		return nil
+	}
+
+	if key, exists := citationKeyMap[data.Key]; exists {
+		data.Key = key
+	}
+
+	return data
+}
@@ -0,0 +1,182 @@
+package providers
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestCitationProvider_Name(t *testing.T) {
+	provider := NewCitationProvider()
+	if provider.Name() != "citation" {
+		t.Errorf("Expected name 'citation', got '%s'", provider.Name())
+	}
+}
+
+func TestCitationProvider_Priority(t *testing.T) {
+	provider := NewCitationProvider()
+	if provider.Priority() != 5 {
+		t.Errorf("Expected priority 5, got %d", provider.Priority())
+	}
+}
+
+func TestCitationProvider_CanHandle(t *testing.T) {
+	provider := NewCitationProvider()
+
+	tests := []struct {
+		name     string
+		node     *html.Node
+		expected bool
+	}{
+		{
+			name: "meta tag with citation_ name",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "name", Val: "citation_title"},
+					{Key: "content", Val: "A Study"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "meta tag with citation_ property",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "property", Val: "citation_doi"},
+					{Key: "content", Val: "10.1000/xyz123"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "standard meta tag",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "name", Val: "description"},
+					{Key: "content", Val: "Test Description"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "non-meta element",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "div",
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := provider.CanHandle(tt.node)
+			if result != tt.expected {
+				t.Errorf("CanHandle() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCitationProvider_Scrape(t *testing.T) {
+	provider := NewCitationProvider()
+
+	tests := []struct {
+		name          string
+		attrs         []html.Attribute
+		expectedKey   string
+		expectedValue string
+		expectNil     bool
+	}{
+		{
+			name: "citation_title maps to title",
+			attrs: []html.Attribute{
+				{Key: "name", Val: "citation_title"},
+				{Key: "content", Val: "A Study of Things"},
+			},
+			expectedKey:   "title",
+			expectedValue: "A Study of Things",
+		},
+		{
+			name: "citation_author maps to author",
+			attrs: []html.Attribute{
+				{Key: "name", Val: "citation_author"},
+				{Key: "content", Val: "Doe, Jane"},
+			},
+			expectedKey:   "author",
+			expectedValue: "Doe, Jane",
+		},
+		{
+			name: "citation_doi keeps its key",
+			attrs: []html.Attribute{
+				{Key: "name", Val: "citation_doi"},
+				{Key: "content", Val: "10.1000/xyz123"},
+			},
+			expectedKey:   "doi",
+			expectedValue: "10.1000/xyz123",
+		},
+		{
+			name: "citation_pdf_url keeps its key",
+			attrs: []html.Attribute{
+				{Key: "name", Val: "citation_pdf_url"},
+				{Key: "content", Val: "https://example.com/paper.pdf"},
+			},
+			expectedKey:   "pdf_url",
+			expectedValue: "https://example.com/paper.pdf",
+		},
+		{
+			name: "empty content",
+			attrs: []html.Attribute{
+				{Key: "name", Val: "citation_title"},
+				{Key: "content", Val: ""},
+			},
+			expectNil: true,
+		},
+		{
+			name: "non-citation meta tag",
+			attrs: []html.Attribute{
+				{Key: "name", Val: "description"},
+				{Key: "content", Val: "Test Description"},
+			},
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: tt.attrs,
+			}
+
+			result := provider.Scrape(node)
+
+			if tt.expectNil {
+				if result != nil {
+					t.Errorf("Scrape() = %v, want nil", result)
+				}
+				return
+			}
+
+			if result == nil {
+				t.Error("Scrape() = nil, want non-nil")
+				return
+			}
+
+			if result.Key != tt.expectedKey {
+				t.Errorf("Scrape() key = %v, want %v", result.Key, tt.expectedKey)
+			}
+
+			if result.Value != tt.expectedValue {
+				t.Errorf("Scrape() value = %v, want %v", result.Value, tt.expectedValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+	"golang.org/x/net/html"
+)
+
+// JSONLDKey is the key under which raw JSON-LD documents are stored
+const JSONLDKey = "json-ld"
+
+// jsonLDKeyMap maps standard keys onto the schema.org properties that
+// provide them. Keys not listed here are looked up as properties directly
+// (e.g. datePublished).
+var jsonLDKeyMap = map[string]string{
+	keys.Title:         "headline",
+	keys.Description:   "description",
+	keys.Image:         "image",
+	keys.URL:           "url",
+	keys.Author:        "author",
+	keys.PublishedTime: "datePublished",
+	keys.ModifiedTime:  "dateModified",
+}
+
+// JSONLDProvider extracts schema.org JSON-LD metadata
+type JSONLDProvider struct {
+	BaseProvider
+}
+
+// NewJSONLDProvider creates a new JSON-LD provider
+func NewJSONLDProvider() *JSONLDProvider {
+	return &JSONLDProvider{}
+}
+
+// Name returns the provider name
+func (p *JSONLDProvider) Name() string {
+	return "jsonLd"
+}
+
+// Priority returns the provider priority (lowest priority)
+func (p *JSONLDProvider) Priority() int {
+	return 9
+}
+
+// CanHandle determines if this provider can handle the given element
+func (p *JSONLDProvider) CanHandle(node *html.Node) bool {
+	if node == nil || node.Type != html.ElementNode || node.Data != "script" {
+		return false
+	}
+
+	return strings.EqualFold(strings.TrimSpace(p.getAttribute(node, "type")), "application/ld+json")
+}
+
+// Scrape stores the raw JSON-LD document; values are resolved from it on demand
+func (p *JSONLDProvider) Scrape(node *html.Node) *metadata.ScrapedData {
+	if !p.CanHandle(node) {
+		return nil
+	}
+
+	document := p.getTextContent(node)
+	if document == "" || !json.Valid([]byte(document)) {
+		return nil
+	}
+
+	return &metadata.ScrapedData{
+		Key:   JSONLDKey,
+		Value: document,
+	}
+}
+
+// GetValue resolves a value for a given key from the stored JSON-LD documents
+func (p *JSONLDProvider) GetValue(key string, data map[string][]string) *string {
+	if values := p.GetValues(key, data); len(values) > 0 {
+		return &values[0]
+	}
+	return nil
+}
+
+// GetValues resolves every value for a given key across the stored JSON-LD documents
+func (p *JSONLDProvider) GetValues(key string, data map[string][]string) []string {
+	property := key
+	if mapped, exists := jsonLDKeyMap[key]; exists {
+		property = mapped
+	}
+
+	var values []string
+	for _, document := range data[JSONLDKey] {
+		values = append(values, metadata.JSONLDValues(document, property)...)
+	}
+	return values
+}
@@ -0,0 +1,124 @@
+package providers
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func newScriptNode(scriptType, content string) *html.Node {
+	node := &html.Node{
+		Type: html.ElementNode,
+		Data: "script",
+		Attr: []html.Attribute{{Key: "type", Val: scriptType}},
+	}
+	node.AppendChild(&html.Node{Type: html.TextNode, Data: content})
+	return node
+}
+
+func TestJSONLDProvider_Name(t *testing.T) {
+	provider := NewJSONLDProvider()
+	if provider.Name() != "jsonLd" {
+		t.Errorf("Expected name 'jsonLd', got '%s'", provider.Name())
+	}
+}
+
+func TestJSONLDProvider_Priority(t *testing.T) {
+	provider := NewJSONLDProvider()
+	if provider.Priority() != 9 {
+		t.Errorf("Expected priority 9, got %d", provider.Priority())
+	}
+}
+
+func TestJSONLDProvider_CanHandle(t *testing.T) {
+	provider := NewJSONLDProvider()
+
+	tests := []struct {
+		name     string
+		node     *html.Node
+		expected bool
+	}{
+		{name: "ld+json script", node: newScriptNode("application/ld+json", "{}"), expected: true},
+		{name: "mixed case type", node: newScriptNode(" Application/LD+JSON ", "{}"), expected: true},
+		{name: "javascript", node: newScriptNode("text/javascript", "var a;"), expected: false},
+		{name: "non-script element", node: &html.Node{Type: html.ElementNode, Data: "meta"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := provider.CanHandle(tt.node); result != tt.expected {
+				t.Errorf("CanHandle() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJSONLDProvider_Scrape(t *testing.T) {
+	provider := NewJSONLDProvider()
+
+	result := provider.Scrape(newScriptNode("application/ld+json", `{"headline": "Test"}`))
+	if result == nil {
+		t.Fatal("Scrape() = nil, want non-nil")
+	}
+
+	if result.Key != JSONLDKey {
+		t.Errorf("Scrape().Key = %v, want %v", result.Key, JSONLDKey)
+	}
+
+	if result := provider.Scrape(newScriptNode("application/ld+json", `{"headline": `)); result != nil {
+		t.Errorf("Scrape() = %v, want nil for invalid JSON", result)
+	}
+}
+
+func TestJSONLDProvider_GetValue(t *testing.T) {
+	provider := NewJSONLDProvider()
+	data := map[string][]string{
+		JSONLDKey: {
+			`{"@type": "Organization", "name": "Org"}`,
+			`{"@type": "Article", "headline": "Article Headline", "datePublished": "2024-01-01", "author": {"name": "Jane Doe"}}`,
+		},
+	}
+
+	tests := []struct {
+		key      string
+		expected *string
+	}{
+		{key: "title", expected: stringPtr("Article Headline")},
+		{key: "author", expected: stringPtr("Jane Doe")},
+		{key: "published_time", expected: stringPtr("2024-01-01")},
+		{key: "datePublished", expected: stringPtr("2024-01-01")},
+		{key: "description", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			result := provider.GetValue(tt.key, data)
+
+			if tt.expected == nil {
+				if result != nil {
+					t.Errorf("GetValue() = %v, want nil", *result)
+				}
+				return
+			}
+
+			if result == nil || *result != *tt.expected {
+				t.Errorf("GetValue() = %v, want %v", result, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestJSONLDProvider_GetValues(t *testing.T) {
+	provider := NewJSONLDProvider()
+	data := map[string][]string{
+		JSONLDKey: {
+			`{"@type": "Article", "author": [{"name": "Jane Doe"}, {"name": "John Roe"}]}`,
+			`{"@type": "Article", "author": "Third Author"}`,
+		},
+	}
+
+	authors := provider.GetValues("author", data)
+	if len(authors) != 3 || authors[0] != "Jane Doe" || authors[2] != "Third Author" {
+		t.Errorf("GetValues() = %v, want [Jane Doe John Roe Third Author]", authors)
+	}
+}
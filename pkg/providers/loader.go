@@ -22,6 +22,11 @@ func NewLoader() *Loader {
 			NewTwitterProvider(),
 			NewStandardMetaProvider(),
 			NewOtherElementsProvider(),
+			NewCitationProvider(),
+			NewNewsProvider(),
+			NewAppLinksProvider(),
+			NewMicroformatsProvider(),
+			NewJSONLDProvider(),
 		},
 	}
 }
@@ -91,10 +96,15 @@ func (l *Loader) LoadFromList(providerNames []string) ([]metadata.MetadataProvid
 	var providers []metadata.MetadataProvider
 
 	providerMap := map[string]metadata.MetadataProvider{
-		"openGraph": NewOpenGraphProvider(),
-		"twitter":   NewTwitterProvider(),
-		"meta":      NewStandardMetaProvider(),
-		"other":     NewOtherElementsProvider(),
+		"openGraph":    NewOpenGraphProvider(),
+		"twitter":      NewTwitterProvider(),
+		"meta":         NewStandardMetaProvider(),
+		"other":        NewOtherElementsProvider(),
+		"citation":     NewCitationProvider(),
+		"news":         NewNewsProvider(),
+		"appLinks":     NewAppLinksProvider(),
+		"microformats": NewMicroformatsProvider(),
+		"jsonLd":       NewJSONLDProvider(),
 	}
 
 	for _, name := range providerNames {
@@ -114,5 +124,5 @@ func (l *Loader) LoadFromList(providerNames []string) ([]metadata.MetadataProvid
 
 // GetAvailableProviders returns a list of available built-in provider names
 func (l *Loader) GetAvailableProviders() []string {
-	return []string{"openGraph", "twitter", "meta", "other"}
+	return []string{"openGraph", "twitter", "meta", "other", "citation", "news", "appLinks", "microformats", "jsonLd"}
 }
@@ -17,7 +17,7 @@ func TestNewLoader(t *testing.T) {
 	}
 
 	// Check that all expected default providers are present
-	expectedProviders := []string{"openGraph", "twitter", "meta", "other"}
+	expectedProviders := []string{"openGraph", "twitter", "meta", "other", "citation", "news", "appLinks", "microformats", "jsonLd"}
 	if len(loader.defaultProviders) != len(expectedProviders) {
 		t.Errorf("Expected %d default providers, got %d", len(expectedProviders), len(loader.defaultProviders))
 	}
@@ -27,8 +27,8 @@ func TestLoader_LoadDefaults(t *testing.T) {
 	loader := NewLoader()
 	providers := loader.LoadDefaults()
 
-	if len(providers) != 4 {
-		t.Errorf("Expected 4 default providers, got %d", len(providers))
+	if len(providers) != 9 {
+		t.Errorf("Expected 9 default providers, got %d", len(providers))
 	}
 
 	// Check provider names and priorities
@@ -40,6 +40,11 @@ func TestLoader_LoadDefaults(t *testing.T) {
 		{"twitter", 2},
 		{"meta", 3},
 		{"other", 4},
+		{"citation", 5},
+		{"news", 6},
+		{"appLinks", 7},
+		{"microformats", 8},
+		{"jsonLd", 9},
 	}
 
 	for i, provider := range providers {
@@ -61,8 +66,8 @@ func TestLoader_LoadFromDirectory_EmptyDir(t *testing.T) {
 		t.Errorf("LoadFromDirectory(\"\") returned error: %v", err)
 	}
 
-	if len(providers) != 4 {
-		t.Errorf("Expected 4 default providers for empty directory, got %d", len(providers))
+	if len(providers) != 9 {
+		t.Errorf("Expected 9 default providers for empty directory, got %d", len(providers))
 	}
 }
 
@@ -73,7 +78,7 @@ func TestLoader_LoadFromDirectory_NonexistentDir(t *testing.T) {
 	// Should return an error but we expect it to fallback to defaults in the factory
 	if err == nil {
 		// If no error, should have returned defaults
-		if len(providers) != 4 {
+		if len(providers) != 9 {
 			t.Error("Expected default providers when directory doesn't exist")
 		}
 	}
@@ -128,8 +133,8 @@ func TestLoader_LoadFromList(t *testing.T) {
 			name:          "empty list",
 			providerNames: []string{},
 			expectError:   false,
-			expectedCount: 4, // Should return defaults
-			expectedNames: []string{"openGraph", "twitter", "meta", "other"},
+			expectedCount: 9, // Should return defaults
+			expectedNames: []string{"openGraph", "twitter", "meta", "other", "citation", "news", "appLinks", "microformats", "jsonLd"},
 		},
 		{
 			name:          "duplicate providers",
@@ -195,7 +200,7 @@ func TestLoader_GetAvailableProviders(t *testing.T) {
 	loader := NewLoader()
 	available := loader.GetAvailableProviders()
 
-	expected := []string{"openGraph", "twitter", "meta", "other"}
+	expected := []string{"openGraph", "twitter", "meta", "other", "citation", "news", "appLinks", "microformats", "jsonLd"}
 
 	if len(available) != len(expected) {
 		t.Errorf("Expected %d available providers, got %d", len(expected), len(available))
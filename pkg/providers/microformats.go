@@ -0,0 +1,175 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+	"golang.org/x/net/html"
+)
+
+// microformatKeyMap maps microformats2 property classes onto the standard
+// keys, keyed by the type of the nearest enclosing root
+var microformatKeyMap = map[string]map[string]string{
+	"h-entry": {
+		"p-name":       keys.Title,
+		"p-summary":    keys.Description,
+		"u-photo":      keys.Image,
+		"u-url":        keys.URL,
+		"dt-published": keys.PublishedTime,
+		"dt-updated":   keys.ModifiedTime,
+	},
+	"h-card": {
+		"p-name": keys.Author,
+	},
+}
+
+// MicroformatsProvider extracts microformats2 (h-entry/h-card) metadata
+type MicroformatsProvider struct {
+	BaseProvider
+}
+
+// NewMicroformatsProvider creates a new microformats2 provider
+func NewMicroformatsProvider() *MicroformatsProvider {
+	return &MicroformatsProvider{}
+}
+
+// Name returns the provider name
+func (p *MicroformatsProvider) Name() string {
+	return "microformats"
+}
+
+// Priority returns the provider priority (after the App Links provider)
+func (p *MicroformatsProvider) Priority() int {
+	return 8
+}
+
+// CanHandle determines if this provider can handle the given element
+func (p *MicroformatsProvider) CanHandle(node *html.Node) bool {
+	if node == nil || node.Type != html.ElementNode {
+		return false
+	}
+
+	_, key := p.propertyKey(node)
+	return key != ""
+}
+
+// Scrape extracts microformats2 data from the element
+func (p *MicroformatsProvider) Scrape(node *html.Node) *metadata.ScrapedData {
+	if !p.CanHandle(node) {
+		return nil
+	}
+
+	class, key := p.propertyKey(node)
+
+	var value string
+	switch {
+	case strings.HasPrefix(class, "u-"):
+		value = p.urlValue(node)
+	case strings.HasPrefix(class, "dt-"):
+		value = p.dateValue(node)
+	default:
+		value = p.textValue(node)
+	}
+
+	value = metadata.NormalizeValue(value)
+	if value == "" {
+		return nil
+	}
+
+	return &metadata.ScrapedData{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// propertyKey returns the first recognized property class on the node and
+// the standard key it maps to within its nearest root
+func (p *MicroformatsProvider) propertyKey(node *html.Node) (string, string) {
+	keyMap := microformatKeyMap[p.nearestRoot(node)]
+	if keyMap == nil {
+		return "", ""
+	}
+
+	for _, class := range strings.Fields(p.getAttribute(node, "class")) {
+		if key, exists := keyMap[class]; exists {
+			return class, key
+		}
+	}
+	return "", ""
+}
+
+// nearestRoot returns the type of the closest ancestor microformat root.
+// A property element may itself be a nested root, so the search starts at
+// its parent.
+func (p *MicroformatsProvider) nearestRoot(node *html.Node) string {
+	for n := node.Parent; n != nil; n = n.Parent {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		for _, class := range strings.Fields(p.getAttribute(n, "class")) {
+			if strings.HasPrefix(class, "h-") {
+				return class
+			}
+		}
+	}
+	return ""
+}
+
+// textValue resolves a p-* property value
+func (p *MicroformatsProvider) textValue(node *html.Node) string {
+	switch node.Data {
+	case "abbr", "link":
+		if title := p.getAttribute(node, "title"); title != "" {
+			return title
+		}
+	case "data", "input":
+		if value := p.getAttribute(node, "value"); value != "" {
+			return value
+		}
+	case "img", "area":
+		if alt := p.getAttribute(node, "alt"); alt != "" {
+			return alt
+		}
+	}
+	return p.getTextContent(node)
+}
+
+// urlValue resolves a u-* property value
+func (p *MicroformatsProvider) urlValue(node *html.Node) string {
+	var attr string
+	switch node.Data {
+	case "a", "area", "link":
+		attr = "href"
+	case "img", "audio", "video", "source", "iframe":
+		attr = "src"
+	case "object":
+		attr = "data"
+	}
+
+	if attr != "" {
+		if value := p.getAttribute(node, attr); value != "" {
+			return value
+		}
+	}
+	return p.getTextContent(node)
+}
+
+// dateValue resolves a dt-* property value
+func (p *MicroformatsProvider) dateValue(node *html.Node) string {
+	switch node.Data {
+	case "time", "ins", "del":
+		if datetime := p.getAttribute(node, "datetime"); datetime != "" {
+			return datetime
+		}
+	case "abbr":
+		if title := p.getAttribute(node, "title"); title != "" {
+			return title
+		}
+	case "data", "input":
+		if value := p.getAttribute(node, "value"); value != "" {
+			return value
+		}
+	}
+	return p.getTextContent(node)
+}
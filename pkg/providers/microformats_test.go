@@ -0,0 +1,131 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+// findByClass returns the first element carrying the given class
+func findByClass(n *html.Node, class string) *html.Node {
+	if n.Type == html.ElementNode {
+		for _, attr := range n.Attr {
+			if attr.Key == "class" {
+				for _, c := range strings.Fields(attr.Val) {
+					if c == class {
+						return n
+					}
+				}
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findByClass(c, class); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+const microformatsFixture = `<html><body>
+<article class="h-entry">
+  <h2 class="p-name">Entry Title</h2>
+  <p class="p-summary">A short summary</p>
+  <img class="u-photo" src="/photo.jpg" alt="Photo">
+  <a class="u-url" href="https://example.com/entry">permalink</a>
+  <time class="dt-published" datetime="2024-01-02T03:04:05Z">January 2</time>
+  <a class="p-author h-card" href="https://example.com/jane"><span class="p-name">Jane Doe</span></a>
+</article>
+<p class="p-name">Orphan name</p>
+</body></html>`
+
+func TestMicroformatsProvider_Name(t *testing.T) {
+	provider := NewMicroformatsProvider()
+	if provider.Name() != "microformats" {
+		t.Errorf("Expected name 'microformats', got '%s'", provider.Name())
+	}
+}
+
+func TestMicroformatsProvider_Priority(t *testing.T) {
+	provider := NewMicroformatsProvider()
+	if provider.Priority() != 8 {
+		t.Errorf("Expected priority 8, got %d", provider.Priority())
+	}
+}
+
+func TestMicroformatsProvider_Scrape(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(microformatsFixture))
+	if err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	provider := NewMicroformatsProvider()
+	entry := findByClass(doc, "h-entry")
+	card := findByClass(doc, "h-card")
+
+	tests := []struct {
+		name          string
+		node          *html.Node
+		expectedKey   string
+		expectedValue string
+	}{
+		{name: "p-name in h-entry", node: findByClass(entry, "p-name"), expectedKey: "title", expectedValue: "Entry Title"},
+		{name: "p-summary", node: findByClass(entry, "p-summary"), expectedKey: "description", expectedValue: "A short summary"},
+		{name: "u-photo uses src", node: findByClass(entry, "u-photo"), expectedKey: "image", expectedValue: "/photo.jpg"},
+		{name: "u-url uses href", node: findByClass(entry, "u-url"), expectedKey: "url", expectedValue: "https://example.com/entry"},
+		{name: "dt-published uses datetime", node: findByClass(entry, "dt-published"), expectedKey: "published_time", expectedValue: "2024-01-02T03:04:05Z"},
+		{name: "p-name in nested h-card", node: findByClass(card, "p-name"), expectedKey: "author", expectedValue: "Jane Doe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.node == nil {
+				t.Fatal("Fixture element not found")
+			}
+
+			result := provider.Scrape(tt.node)
+			if result == nil {
+				t.Fatal("Scrape() = nil, want non-nil")
+			}
+
+			if result.Key != tt.expectedKey {
+				t.Errorf("Scrape().Key = %v, want %v", result.Key, tt.expectedKey)
+			}
+
+			if result.Value != tt.expectedValue {
+				t.Errorf("Scrape().Value = %v, want %v", result.Value, tt.expectedValue)
+			}
+		})
+	}
+}
+
+func TestMicroformatsProvider_CanHandle(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(microformatsFixture))
+	if err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+
+	provider := NewMicroformatsProvider()
+
+	// The h-card root is an h-entry property without a mapped key
+	if provider.CanHandle(findByClass(doc, "h-card")) {
+		t.Error("Expected nested root without mapped property to be skipped")
+	}
+
+	// A property class outside of any root is not a microformat
+	var orphan *html.Node
+	for n := findByClass(doc, "h-entry").NextSibling; n != nil; n = n.NextSibling {
+		if n.Type == html.ElementNode {
+			orphan = n
+			break
+		}
+	}
+	if orphan == nil || provider.CanHandle(orphan) {
+		t.Error("Expected property outside a root to be skipped")
+	}
+
+	if provider.CanHandle(&html.Node{Type: html.TextNode, Data: "text"}) {
+		t.Error("Expected text node to be skipped")
+	}
+}
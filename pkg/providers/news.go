@@ -0,0 +1,53 @@
+package providers
+
+import (
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+	"golang.org/x/net/html"
+)
+
+// newsMetaNames lists the Google News meta tags handled by the news provider
+var newsMetaNames = map[string]bool{
+	keys.NewsKeywords:      true,
+	keys.Standout:          true,
+	keys.SyndicationSource: true,
+	keys.OriginalSource:    true,
+}
+
+// NewsProvider extracts news-specific metadata
+type NewsProvider struct {
+	BaseProvider
+}
+
+// NewNewsProvider creates a new news provider
+func NewNewsProvider() *NewsProvider {
+	return &NewsProvider{}
+}
+
+// Name returns the provider name
+func (p *NewsProvider) Name() string {
+	return "news"
+}
+
+// Priority returns the provider priority (after the citation provider)
+func (p *NewsProvider) Priority() int {
+	return 6
+}
+
+// CanHandle determines if this provider can handle the given element
+func (p *NewsProvider) CanHandle(node *html.Node) bool {
+	if node == nil || node.Type != html.ElementNode || node.Data != "meta" {
+		return false
+	}
+
+	return newsMetaNames[p.getAttribute(node, "name")] || newsMetaNames[p.getAttribute(node, "property")]
+}
+
+// Scrape extracts news data from the element
+func (p *NewsProvider) Scrape(node *html.Node) *metadata.ScrapedData {
+	if !p.CanHandle(node) {
+		return nil
+	}
+
+	return p.scrapeMetaTag(node, "")
+}
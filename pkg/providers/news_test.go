@@ -0,0 +1,113 @@
+package providers
+
+import (
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestNewsProvider_Name(t *testing.T) {
+	provider := NewNewsProvider()
+	if provider.Name() != "news" {
+		t.Errorf("Expected name 'news', got '%s'", provider.Name())
+	}
+}
+
+func TestNewsProvider_Priority(t *testing.T) {
+	provider := NewNewsProvider()
+	if provider.Priority() != 6 {
+		t.Errorf("Expected priority 6, got %d", provider.Priority())
+	}
+}
+
+func TestNewsProvider_CanHandle(t *testing.T) {
+	provider := NewNewsProvider()
+
+	tests := []struct {
+		name     string
+		attrs    []html.Attribute
+		element  string
+		expected bool
+	}{
+		{
+			name:     "news_keywords",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "name", Val: "news_keywords"}, {Key: "content", Val: "a, b"}},
+			expected: true,
+		},
+		{
+			name:     "standout",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "name", Val: "standout"}, {Key: "content", Val: "https://example.com"}},
+			expected: true,
+		},
+		{
+			name:     "syndication-source",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "name", Val: "syndication-source"}, {Key: "content", Val: "https://example.com"}},
+			expected: true,
+		},
+		{
+			name:     "original-source",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "property", Val: "original-source"}, {Key: "content", Val: "https://example.com"}},
+			expected: true,
+		},
+		{
+			name:     "keywords is standard meta",
+			element:  "meta",
+			attrs:    []html.Attribute{{Key: "name", Val: "keywords"}, {Key: "content", Val: "a, b"}},
+			expected: false,
+		},
+		{
+			name:     "non-meta element",
+			element:  "div",
+			attrs:    []html.Attribute{{Key: "name", Val: "news_keywords"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &html.Node{Type: html.ElementNode, Data: tt.element, Attr: tt.attrs}
+			if result := provider.CanHandle(node); result != tt.expected {
+				t.Errorf("CanHandle() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewsProvider_Scrape(t *testing.T) {
+	provider := NewNewsProvider()
+
+	node := &html.Node{
+		Type: html.ElementNode,
+		Data: "meta",
+		Attr: []html.Attribute{
+			{Key: "name", Val: "news_keywords"},
+			{Key: "content", Val: "World Cup, soccer"},
+		},
+	}
+
+	result := provider.Scrape(node)
+	if result == nil {
+		t.Fatal("Scrape() = nil, want non-nil")
+	}
+
+	if result.Key != "news_keywords" {
+		t.Errorf("Scrape().Key = %v, want %v", result.Key, "news_keywords")
+	}
+
+	if result.Value != "World Cup, soccer" {
+		t.Errorf("Scrape().Value = %v, want %v", result.Value, "World Cup, soccer")
+	}
+
+	empty := &html.Node{
+		Type: html.ElementNode,
+		Data: "meta",
+		Attr: []html.Attribute{{Key: "name", Val: "standout"}},
+	}
+	if result := provider.Scrape(empty); result != nil {
+		t.Errorf("Scrape() = %v, want nil for empty content", result)
+	}
+}
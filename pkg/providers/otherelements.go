@@ -1,7 +1,10 @@
 package providers
 
 import (
+	"strings"
+
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
 	"golang.org/x/net/html"
 )
 
@@ -27,13 +30,15 @@ func (p *OtherElementsProvider) Priority() int {
 
 // CanHandle determines if this provider can handle the given element
 func (p *OtherElementsProvider) CanHandle(node *html.Node) bool {
-	if node.Type != html.ElementNode {
+	if node == nil || node.Type != html.ElementNode {
 		return false
 	}
 
 	switch node.Data {
 	case "title", "h1":
 		return true
+	case "html":
+		return p.getAttribute(node, "lang") != ""
 	case "link":
 		rel := p.getAttribute(node, "rel")
 		return rel == "icon" || rel == "shortcut icon" || rel == "canonical"
@@ -49,19 +54,27 @@ func (p *OtherElementsProvider) Scrape(node *html.Node) *metadata.ScrapedData {
 	}
 
 	switch node.Data {
+	case "html":
+		if lang := strings.TrimSpace(p.getAttribute(node, "lang")); lang != "" {
+			return &metadata.ScrapedData{
+				Key:       keys.Lang,
+				Value:     lang,
+				Attribute: "lang",
+			}
+		}
 	case "title":
-		content := p.getTextContent(node)
+		content := metadata.NormalizeValue(p.getTextContent(node))
 		if content != "" {
 			return &metadata.ScrapedData{
-				Key:   "title",
+				Key:   keys.Title,
 				Value: content,
 			}
 		}
 	case "h1":
-		content := p.getTextContent(node)
+		content := metadata.NormalizeValue(p.getTextContent(node))
 		if content != "" {
 			return &metadata.ScrapedData{
-				Key:   "firstHeading",
+				Key:   keys.FirstHeading,
 				Value: content,
 			}
 		}
@@ -72,13 +85,15 @@ func (p *OtherElementsProvider) Scrape(node *html.Node) *metadata.ScrapedData {
 			switch rel {
 			case "icon", "shortcut icon":
 				return &metadata.ScrapedData{
-					Key:   rel,
-					Value: href,
+					Key:       rel,
+					Value:     href,
+					Attribute: "href",
 				}
 			case "canonical":
 				return &metadata.ScrapedData{
-					Key:   "url",
-					Value: href,
+					Key:       keys.Canonical,
+					Value:     href,
+					Attribute: "href",
 				}
 			}
 		}
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"strings"
 	"testing"
 
 	"golang.org/x/net/html"
@@ -100,6 +101,25 @@ func TestOtherElementsProvider_CanHandle(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "html element with lang",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "html",
+				Attr: []html.Attribute{
+					{Key: "lang", Val: "en-US"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "html element without lang",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "html",
+			},
+			expected: false,
+		},
 		{
 			name: "text node",
 			node: &html.Node{
@@ -206,6 +226,20 @@ func TestOtherElementsProvider_Scrape(t *testing.T) {
 				value string
 			}{key: "url", value: "https://example.com/page"},
 		},
+		{
+			name: "html element with lang",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "html",
+				Attr: []html.Attribute{
+					{Key: "lang", Val: " fr-CA "},
+				},
+			},
+			expected: &struct {
+				key   string
+				value string
+			}{key: "lang", value: "fr-CA"},
+		},
 		{
 			name: "empty title element",
 			node: &html.Node{
@@ -363,6 +397,20 @@ func TestOtherElementsProvider_getTextContent(t *testing.T) {
 			},
 			expected: "",
 		},
+		{
+			name: "skips script and svg children",
+			node: func() *html.Node {
+				doc, _ := html.Parse(strings.NewReader(`<h1><script>var x = 1;</script>Visible <svg><title>icon</title></svg>text</h1>`))
+				var heading *html.Node
+				for n := range doc.Descendants() {
+					if n.Type == html.ElementNode && n.Data == "h1" {
+						heading = n
+					}
+				}
+				return heading
+			}(),
+			expected: "Visible text",
+		},
 	}
 
 	for _, tt := range tests {
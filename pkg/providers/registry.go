@@ -1,6 +1,8 @@
 package providers
 
 import (
+	"context"
+	"slices"
 	"sort"
 
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
@@ -10,15 +12,19 @@ import (
 // ProviderRegistry manages metadata providers with priority-based resolution
 type ProviderRegistry struct {
 	providers []metadata.MetadataProvider
+
+	// ordered keeps providers in registration order rather than priority
+	ordered bool
 }
 
 // NewRegistry creates a new provider registry
 func NewRegistry(providers []metadata.MetadataProvider) *ProviderRegistry {
-	// Sort providers by priority (lower numbers = higher priority)
+	// Sort providers by priority (lower numbers = higher priority). The sort
+	// is stable so providers sharing a priority keep their registration order.
 	sortedProviders := make([]metadata.MetadataProvider, len(providers))
 	copy(sortedProviders, providers)
 
-	sort.Slice(sortedProviders, func(i, j int) bool {
+	sort.SliceStable(sortedProviders, func(i, j int) bool {
 		return sortedProviders[i].Priority() < sortedProviders[j].Priority()
 	})
 
@@ -27,6 +33,16 @@ func NewRegistry(providers []metadata.MetadataProvider) *ProviderRegistry {
 	}
 }
 
+// NewOrderedRegistry creates a registry that consults providers in the
+// given order, ignoring their priorities, so callers can choose which
+// provider's value wins
+func NewOrderedRegistry(providers []metadata.MetadataProvider) *ProviderRegistry {
+	return &ProviderRegistry{
+		providers: slices.Clone(providers),
+		ordered:   true,
+	}
+}
+
 // GetProviders returns all registered providers
 func (r *ProviderRegistry) GetProviders() []metadata.MetadataProvider {
 	return r.providers
@@ -34,9 +50,15 @@ func (r *ProviderRegistry) GetProviders() []metadata.MetadataProvider {
 
 // ScrapeFromElement attempts to scrape metadata from an element using all providers
 func (r *ProviderRegistry) ScrapeFromElement(node *html.Node) *metadata.ScrapingResult {
+	return r.ScrapeFromElementContext(context.Background(), node, nil)
+}
+
+// ScrapeFromElementContext is like ScrapeFromElement, passing ctx and the
+// document through to providers that implement metadata.ContextProvider
+func (r *ProviderRegistry) ScrapeFromElementContext(ctx context.Context, node *html.Node, document *metadata.Document) *metadata.ScrapingResult {
 	for _, provider := range r.providers {
 		if provider.CanHandle(node) {
-			if data := provider.Scrape(node); data != nil {
+			if data := metadata.ScrapeWithContext(ctx, provider, node, document); data != nil {
 				return &metadata.ScrapingResult{
 					Provider: &provider,
 					Data:     data,
@@ -47,6 +69,29 @@ func (r *ProviderRegistry) ScrapeFromElement(node *html.Node) *metadata.Scraping
 	return nil
 }
 
+// ScrapeAllFromElement scrapes an element with every provider that can
+// handle it, returning the results in priority order
+func (r *ProviderRegistry) ScrapeAllFromElement(node *html.Node) []*metadata.ScrapingResult {
+	return r.ScrapeAllFromElementContext(context.Background(), node, nil)
+}
+
+// ScrapeAllFromElementContext is like ScrapeAllFromElement, passing ctx and
+// the document through to providers that implement metadata.ContextProvider
+func (r *ProviderRegistry) ScrapeAllFromElementContext(ctx context.Context, node *html.Node, document *metadata.Document) []*metadata.ScrapingResult {
+	var results []*metadata.ScrapingResult
+	for _, provider := range r.providers {
+		if provider.CanHandle(node) {
+			if data := metadata.ScrapeWithContext(ctx, provider, node, document); data != nil {
+				results = append(results, &metadata.ScrapingResult{
+					Provider: &provider,
+					Data:     data,
+				})
+			}
+		}
+	}
+	return results
+}
+
 // ResolveValue resolves a value using provider priority
 func (r *ProviderRegistry) ResolveValue(key string, providerData metadata.ProviderData) *string {
 	for _, provider := range r.providers {
@@ -59,12 +104,16 @@ func (r *ProviderRegistry) ResolveValue(key string, providerData metadata.Provid
 	return nil
 }
 
-// AddProvider adds a new provider to the registry
+// AddProvider adds a new provider to the registry, last in an ordered
+// registry
 func (r *ProviderRegistry) AddProvider(provider metadata.MetadataProvider) {
 	r.providers = append(r.providers, provider)
+	if r.ordered {
+		return
+	}
 
 	// Re-sort providers by priority
-	sort.Slice(r.providers, func(i, j int) bool {
+	sort.SliceStable(r.providers, func(i, j int) bool {
 		return r.providers[i].Priority() < r.providers[j].Priority()
 	})
 }
@@ -1,6 +1,7 @@
 package providers
 
 import (
+	"context"
 	"testing"
 
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
@@ -115,6 +116,53 @@ func TestProviderRegistry_ScrapeFromElement_NoHandler(t *testing.T) {
 	}
 }
 
+func TestProviderRegistry_ScrapeAllFromElement(t *testing.T) {
+	first := &MockProvider{name: "first", priority: 2}
+	second := &MockProvider{name: "second", priority: 1}
+	registry := NewRegistry([]metadata.MetadataProvider{first, second})
+
+	node := &html.Node{
+		Type: html.ElementNode,
+		Data: "meta",
+	}
+
+	results := registry.ScrapeAllFromElement(node)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 scraping results, got %d", len(results))
+	}
+
+	if (*results[0].Provider).Name() != "second" || (*results[1].Provider).Name() != "first" {
+		t.Errorf("Expected results in priority order, got %s then %s",
+			(*results[0].Provider).Name(), (*results[1].Provider).Name())
+	}
+
+	unhandled := &html.Node{Type: html.ElementNode, Data: "div"}
+	if results := registry.ScrapeAllFromElement(unhandled); len(results) != 0 {
+		t.Errorf("Expected no results for unhandled element, got %d", len(results))
+	}
+}
+
+func TestProviderRegistry_ScrapeFromElementContext_Cancelled(t *testing.T) {
+	provider := &MockProvider{name: "test", priority: 1}
+	registry := NewRegistry([]metadata.MetadataProvider{provider})
+	node := &html.Node{Type: html.ElementNode, Data: "meta"}
+
+	if result := registry.ScrapeFromElementContext(context.Background(), node, nil); result == nil {
+		t.Error("Expected scraping result with a live context, got nil")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if result := registry.ScrapeFromElementContext(ctx, node, nil); result != nil {
+		t.Errorf("Expected nil result with a cancelled context, got %v", result.Data)
+	}
+
+	if results := registry.ScrapeAllFromElementContext(ctx, node, nil); len(results) != 0 {
+		t.Errorf("Expected no results with a cancelled context, got %d", len(results))
+	}
+}
+
 func TestProviderRegistry_ResolveValue(t *testing.T) {
 	provider := &MockProvider{name: "test", priority: 1}
 	registry := NewRegistry([]metadata.MetadataProvider{provider})
@@ -165,6 +213,51 @@ func TestProviderRegistry_AddProvider(t *testing.T) {
 	}
 }
 
+func TestProviderRegistry_EqualPrioritiesKeepOrder(t *testing.T) {
+	providers := []metadata.MetadataProvider{
+		&MockProvider{name: "c", priority: 2},
+		&MockProvider{name: "a", priority: 1},
+		&MockProvider{name: "b", priority: 2},
+		&MockProvider{name: "d", priority: 2},
+	}
+
+	for i := 0; i < 10; i++ {
+		registry := NewRegistry(providers)
+		registry.AddProvider(&MockProvider{name: "e", priority: 2})
+
+		expected := []string{"a", "c", "b", "d", "e"}
+		for j, provider := range registry.GetProviders() {
+			if provider.Name() != expected[j] {
+				t.Fatalf("Expected provider %d to be '%s', got '%s'", j, expected[j], provider.Name())
+			}
+		}
+	}
+}
+
+func TestNewOrderedRegistry(t *testing.T) {
+	providers := []metadata.MetadataProvider{
+		&MockProvider{name: "twitter", priority: 2},
+		&MockProvider{name: "openGraph", priority: 1},
+	}
+	registry := NewOrderedRegistry(providers)
+	registry.AddProvider(&MockProvider{name: "meta", priority: 0})
+
+	expected := []string{"twitter", "openGraph", "meta"}
+	for i, provider := range registry.GetProviders() {
+		if provider.Name() != expected[i] {
+			t.Errorf("Expected provider %d to be '%s', got '%s'", i, expected[i], provider.Name())
+		}
+	}
+
+	providerData := metadata.ProviderData{
+		"openGraph": {"title": {"OG Title"}},
+		"twitter":   {"title": {"Twitter Title"}},
+	}
+	if value := registry.ResolveValue("title", providerData); value == nil || *value != "Twitter Title" {
+		t.Errorf("ResolveValue() = %v, want %q from the first listed provider", value, "Twitter Title")
+	}
+}
+
 func TestProviderRegistry_RemoveProvider(t *testing.T) {
 	provider1 := &MockProvider{name: "provider1", priority: 1}
 	provider2 := &MockProvider{name: "provider2", priority: 2}
@@ -1,9 +1,11 @@
 package providers
 
 import (
+	"mime"
 	"strings"
 
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
 	"golang.org/x/net/html"
 )
 
@@ -29,19 +31,22 @@ func (p *StandardMetaProvider) Priority() int {
 
 // CanHandle determines if this provider can handle the given element
 func (p *StandardMetaProvider) CanHandle(node *html.Node) bool {
-	if node.Type != html.ElementNode || node.Data != "meta" {
+	if node == nil || node.Type != html.ElementNode || node.Data != "meta" {
 		return false
 	}
 
+	// Charset and http-equiv declarations carry neither a name nor a property
+	if p.getAttribute(node, "charset") != "" || p.getAttribute(node, "http-equiv") != "" {
+		return true
+	}
+
 	name := p.getAttribute(node, "name")
 	property := p.getAttribute(node, "property")
 
-	// Handle standard meta tags that don't have og: or twitter: prefixes
+	// Handle standard meta tags that aren't claimed by a dedicated provider
 	return (name != "" || property != "") &&
-		!strings.HasPrefix(name, OGPrefix) &&
-		!strings.HasPrefix(name, TwitterPrefix) &&
-		!strings.HasPrefix(property, OGPrefix) &&
-		!strings.HasPrefix(property, TwitterPrefix)
+		!isNamespacedMeta(name) &&
+		!isNamespacedMeta(property)
 }
 
 // Scrape extracts standard meta data from the element
@@ -50,5 +55,68 @@ func (p *StandardMetaProvider) Scrape(node *html.Node) *metadata.ScrapedData {
 		return nil
 	}
 
+	if charset := p.getAttribute(node, "charset"); charset != "" {
+		return &metadata.ScrapedData{
+			Key:       keys.Charset,
+			Value:     strings.TrimSpace(charset),
+			Attribute: "charset",
+		}
+	}
+
+	if p.isContentTypeDeclaration(node) {
+		return p.scrapeContentTypeCharset(node)
+	}
+
+	if httpEquiv := p.getAttribute(node, "http-equiv"); httpEquiv != "" {
+		return p.scrapeHTTPEquiv(node, httpEquiv)
+	}
+
 	return p.scrapeMetaTag(node, "")
 }
+
+// isNamespacedMeta reports whether a meta name or property belongs to a
+// dedicated provider rather than the standard meta provider
+func isNamespacedMeta(key string) bool {
+	for _, prefix := range []string{OGPrefix, TwitterPrefix, CitationPrefix, AppLinksPrefix} {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return newsMetaNames[key] || key == AppleITunesAppName
+}
+
+// isContentTypeDeclaration reports whether the node is a
+// <meta http-equiv="Content-Type"> declaration
+func (p *StandardMetaProvider) isContentTypeDeclaration(node *html.Node) bool {
+	return strings.EqualFold(p.getAttribute(node, "http-equiv"), "content-type")
+}
+
+// scrapeHTTPEquiv extracts an http-equiv declaration keyed by its lowercased
+// header name (e.g. content-security-policy)
+func (p *StandardMetaProvider) scrapeHTTPEquiv(node *html.Node, httpEquiv string) *metadata.ScrapedData {
+	content := metadata.NormalizeValue(p.getAttribute(node, "content"))
+	if content == "" {
+		return nil
+	}
+
+	return &metadata.ScrapedData{
+		Key:       strings.ToLower(strings.TrimSpace(httpEquiv)),
+		Value:     content,
+		Attribute: "content",
+	}
+}
+
+// scrapeContentTypeCharset extracts the charset parameter from an
+// http-equiv Content-Type declaration
+func (p *StandardMetaProvider) scrapeContentTypeCharset(node *html.Node) *metadata.ScrapedData {
+	_, params, err := mime.ParseMediaType(p.getAttribute(node, "content"))
+	if err != nil || params["charset"] == "" {
+		return nil
+	}
+
+	return &metadata.ScrapedData{
+		Key:       keys.Charset,
+		Value:     params["charset"],
+		Attribute: "content",
+	}
+}
@@ -76,6 +76,65 @@ func TestStandardMetaProvider_CanHandle(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "citation_ meta tag",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "name", Val: "citation_doi"},
+					{Key: "content", Val: "10.1000/xyz123"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "news meta tag",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "name", Val: "news_keywords"},
+					{Key: "content", Val: "World Cup, soccer"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "apple smart app banner",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "name", Val: "apple-itunes-app"},
+					{Key: "content", Val: "app-id=123456789"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "meta charset declaration",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "charset", Val: "Shift_JIS"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "http-equiv content-type declaration",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "http-equiv", Val: "Content-Type"},
+					{Key: "content", Val: "text/html; charset=windows-1251"},
+				},
+			},
+			expected: true,
+		},
 		{
 			name: "meta tag without name or property",
 			node: &html.Node{
@@ -159,6 +218,73 @@ func TestStandardMetaProvider_Scrape(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "meta charset declaration",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "charset", Val: " Shift_JIS "},
+				},
+			},
+			expected: &struct {
+				key   string
+				value string
+			}{key: "charset", value: "Shift_JIS"},
+		},
+		{
+			name: "http-equiv content-type declaration",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "http-equiv", Val: "content-type"},
+					{Key: "content", Val: "text/html; charset=windows-1251"},
+				},
+			},
+			expected: &struct {
+				key   string
+				value string
+			}{key: "charset", value: "windows-1251"},
+		},
+		{
+			name: "http-equiv content-security-policy",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "http-equiv", Val: "Content-Security-Policy"},
+					{Key: "content", Val: "default-src 'self'"},
+				},
+			},
+			expected: &struct {
+				key   string
+				value string
+			}{key: "content-security-policy", value: "default-src 'self'"},
+		},
+		{
+			name: "http-equiv without content",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "http-equiv", Val: "Permissions-Policy"},
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "http-equiv content-type without charset",
+			node: &html.Node{
+				Type: html.ElementNode,
+				Data: "meta",
+				Attr: []html.Attribute{
+					{Key: "http-equiv", Val: "Content-Type"},
+					{Key: "content", Val: "text/html"},
+				},
+			},
+			expected: nil,
+		},
 		{
 			name: "og: meta tag (should not handle)",
 			node: &html.Node{
@@ -31,7 +31,7 @@ func (p *TwitterProvider) Priority() int {
 
 // CanHandle determines if this provider can handle the given element
 func (p *TwitterProvider) CanHandle(node *html.Node) bool {
-	if node.Type != html.ElementNode || node.Data != "meta" {
+	if node == nil || node.Type != html.ElementNode || node.Data != "meta" {
 		return false
 	}
 
@@ -0,0 +1,163 @@
+package scraper
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+// minParagraphWords is how many words a paragraph needs to be used as a
+// description; shorter ones are usually bylines, captions or buttons
+const minParagraphWords = 8
+
+// nonContentElements hold navigation, chrome or non-visible content that is
+// skipped when reading body text and images
+var nonContentElements = map[string]bool{
+	"nav":      true,
+	"aside":    true,
+	"header":   true,
+	"footer":   true,
+	"form":     true,
+	"script":   true,
+	"style":    true,
+	"noscript": true,
+	"template": true,
+	"svg":      true,
+	"head":     true,
+}
+
+// scrapeBodyDescription records the first meaningful paragraph as the
+// description fallback when enabled
+func (s *scrapeState) scrapeBodyDescription() *scrapeState {
+	if !s.options.DescriptionFromBody {
+		return s
+	}
+
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if s.result.BodyDescription != "" {
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if nonContentElements[n.Data] {
+			return false
+		}
+
+		if n.Data == "p" {
+			if text := metadata.NormalizeValue(s.getTextContent(n)); len(strings.Fields(text)) >= minParagraphWords {
+				s.result.BodyDescription = truncateWords(text, s.descriptionLength())
+			}
+			return false
+		}
+		return true
+	})
+	return s
+}
+
+// scrapeBodyImages records the first BodyImages <img> elements of the body
+// as fallback images when enabled
+func (s *scrapeState) scrapeBodyImages() *scrapeState {
+	if s.options.BodyImages <= 0 {
+		return s
+	}
+
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if len(s.result.BodyImages) >= s.options.BodyImages {
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if nonContentElements[n.Data] {
+			return false
+		}
+
+		if n.Data == "img" {
+			src := strings.TrimSpace(s.getAttribute(n, "src"))
+			if src == "" || strings.HasPrefix(src, "data:") {
+				return false
+			}
+
+			width, _ := strconv.Atoi(strings.TrimSpace(s.getAttribute(n, "width")))
+			height, _ := strconv.Atoi(strings.TrimSpace(s.getAttribute(n, "height")))
+			s.result.BodyImages = append(s.result.BodyImages, metadata.Image{
+				URL:    src,
+				Width:  width,
+				Height: height,
+				Alt:    strings.TrimSpace(s.getAttribute(n, "alt")),
+			})
+			return false
+		}
+		return true
+	})
+	return s
+}
+
+// scrapeWordCount counts the words of visible body text
+func (s *scrapeState) scrapeWordCount() *scrapeState {
+	count := 0
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		switch n.Type {
+		case html.TextNode:
+			count += len(strings.Fields(n.Data))
+		case html.ElementNode:
+			return !nonContentElements[n.Data]
+		}
+		return true
+	})
+
+	s.result.SetWordCount(count)
+	return s
+}
+
+// scrapeLanguage detects the language of the body text when enabled and the
+// page declares no language
+func (s *scrapeState) scrapeLanguage() *scrapeState {
+	if !s.options.DetectLanguage || s.result.Locale() != nil {
+		return s
+	}
+
+	var text strings.Builder
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		switch n.Type {
+		case html.TextNode:
+			text.WriteString(n.Data)
+			text.WriteByte(' ')
+		case html.ElementNode:
+			return !nonContentElements[n.Data]
+		}
+		return true
+	})
+
+	s.result.SetDetectedLanguage(metadata.DetectLanguage(text.String()))
+	return s
+}
+
+// descriptionLength returns the configured body description length
+func (s *scrapeState) descriptionLength() int {
+	if s.options.DescriptionLength > 0 {
+		return s.options.DescriptionLength
+	}
+	return metadata.MaxDescriptionLength
+}
+
+// truncateWords shortens text to at most limit characters, cutting at a word
+// boundary and ending with an ellipsis when cut
+func truncateWords(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:limit-1])
+	if runes[limit-1] != ' ' {
+		if space := strings.LastIndex(cut, " "); space > 0 {
+			cut = cut[:space]
+		}
+	}
+	return strings.TrimRight(cut, " ,;:.") + "…"
+}
@@ -0,0 +1,242 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+const bodyFixture = `<html><head><title>Blog</title></head><body>
+<header><p>Welcome to my little corner of the internet where I write things</p></header>
+<nav><p>Home About Archive Contact Subscribe to the newsletter for more posts</p></nav>
+<article>
+<p>By Jane</p>
+<p>Today I finally   fixed the   <a href="/bug">bug</a> that kept my build failing every other night for months.</p>
+<p>Second paragraph that should never be used as the description of this page.</p>
+</article>
+</body></html>`
+
+func TestScraper_DescriptionFromBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ScrapeOptions
+		expected string
+	}{
+		{
+			name:     "disabled",
+			options:  ScrapeOptions{},
+			expected: "",
+		},
+		{
+			name:     "first meaningful paragraph",
+			options:  ScrapeOptions{DescriptionFromBody: true},
+			expected: "Today I finally fixed the bug that kept my build failing every other night for months.",
+		},
+		{
+			name:     "truncated",
+			options:  ScrapeOptions{DescriptionFromBody: true, DescriptionLength: 30},
+			expected: "Today I finally fixed the bug…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(bodyFixture))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			scraperInstance, _ := CreateScraper()
+			result, err := scraperInstance.WithOptions(tt.options).Scrape(doc)
+			if err != nil {
+				t.Fatalf("Scrape() returned error: %v", err)
+			}
+
+			if result.BodyDescription != tt.expected {
+				t.Errorf("BodyDescription = %q, want %q", result.BodyDescription, tt.expected)
+			}
+
+			description := ""
+			if value := result.Description(); value != nil {
+				description = *value
+			}
+			if description != tt.expected {
+				t.Errorf("Description() = %q, want %q", description, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScraper_DescriptionFromBody_PrefersMeta(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(strings.Replace(bodyFixture, "<title>Blog</title>", `<title>Blog</title><meta name="description" content="Meta">`, 1)))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.WithOptions(ScrapeOptions{DescriptionFromBody: true}).Scrape(doc)
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	if description := result.Description(); description == nil || *description != "Meta" {
+		t.Errorf("Description() = %v, want %q", description, "Meta")
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		limit    int
+		expected string
+	}{
+		{text: "short", limit: 10, expected: "short"},
+		{text: "one two three four", limit: 10, expected: "one two…"},
+		{text: "one, two three", limit: 6, expected: "one…"},
+		{text: "unbrokenword", limit: 5, expected: "unbr…"},
+	}
+
+	for _, tt := range tests {
+		result := truncateWords(tt.text, tt.limit)
+		if result != tt.expected {
+			t.Errorf("truncateWords(%q, %d) = %q, want %q", tt.text, tt.limit, result, tt.expected)
+		}
+		if utf8.RuneCountInString(result) > tt.limit {
+			t.Errorf("truncateWords(%q, %d) = %q, longer than the limit", tt.text, tt.limit, result)
+		}
+	}
+}
+
+func TestScraper_BodyImages(t *testing.T) {
+	const page = `<html><head>%s</head><body>
+<header><img src="/logo.png" alt="Logo"></header>
+<article>
+<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=">
+<img src="/first.jpg" width="800" height="600" alt=" First ">
+<img alt="No source">
+<img src="/second.jpg">
+<img src="/third.jpg">
+</article>
+</body></html>`
+
+	tests := []struct {
+		name     string
+		head     string
+		limit    int
+		expected []metadata.Image
+	}{
+		{
+			name:     "disabled",
+			limit:    0,
+			expected: nil,
+		},
+		{
+			name:  "first N images",
+			limit: 2,
+			expected: []metadata.Image{
+				{URL: "https://example.com/first.jpg", Width: 800, Height: 600, Alt: "First"},
+				{URL: "https://example.com/second.jpg"},
+			},
+		},
+		{
+			name:     "og:image wins",
+			head:     `<meta property="og:image" content="/og.jpg">`,
+			limit:    2,
+			expected: []metadata.Image{{URL: "https://example.com/og.jpg"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(fmt.Sprintf(page, tt.head)))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			pageURL, _ := url.Parse("https://example.com/post")
+			scraperInstance, _ := CreateScraper()
+			result, err := scraperInstance.WithOptions(ScrapeOptions{BodyImages: tt.limit}).ScrapeContext(context.Background(), doc, &metadata.Document{URL: pageURL})
+			if err != nil {
+				t.Fatalf("ScrapeContext() returned error: %v", err)
+			}
+
+			if images := result.Images(); !reflect.DeepEqual(images, tt.expected) {
+				t.Errorf("Images() = %+v, want %+v", images, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScraper_WordCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  ScrapeOptions
+		expected int
+	}{
+		{name: "visible body text", expected: 31},
+		{name: "head only", options: ScrapeOptions{HeadOnly: true}, expected: 0},
+	}
+
+	page := strings.Replace(bodyFixture, "</article>", "<script>var ignored = 1;</script><style>p { color: red }</style></article>", 1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(page))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			scraperInstance, _ := CreateScraper()
+			result, err := scraperInstance.WithOptions(tt.options).Scrape(doc)
+			if err != nil {
+				t.Fatalf("Scrape() returned error: %v", err)
+			}
+
+			if count := result.WordCount(); count != tt.expected {
+				t.Errorf("WordCount() = %v, want %v", count, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScraper_DetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     string
+		options  ScrapeOptions
+		expected string
+	}{
+		{name: "disabled", page: bodyFixture, expected: ""},
+		{name: "undeclared", page: bodyFixture, options: ScrapeOptions{DetectLanguage: true}, expected: "en"},
+		{name: "declared", page: strings.Replace(bodyFixture, "<html>", `<html lang="en-GB">`, 1), options: ScrapeOptions{DetectLanguage: true}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(tt.page))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			scraperInstance, _ := CreateScraper()
+			result, err := scraperInstance.WithOptions(tt.options).Scrape(doc)
+			if err != nil {
+				t.Fatalf("Scrape() returned error: %v", err)
+			}
+
+			language := ""
+			if value := result.DetectedLanguage(); value != nil {
+				language = *value
+			}
+			if language != tt.expected {
+				t.Errorf("DetectedLanguage() = %q, want %q", language, tt.expected)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package scraper
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
 	"golang.org/x/net/html"
 
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
@@ -22,6 +27,20 @@ func CreateScraper() (*Scraper, error) {
 	return NewScraper(registry), nil
 }
 
+// CreateScraperFromDirectory creates a scraper with the plugin providers
+// (.so files exporting NewProvider) found in dir, or the default providers
+// when it holds none. Unlike CreateScraper, a directory or plugin that
+// fails to load is an error rather than a silent fallback.
+func CreateScraperFromDirectory(dir string) (*Scraper, error) {
+	providerList, err := providers.NewLoader().LoadFromDirectory(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := providers.NewRegistry(providerList)
+	return NewScraper(registry), nil
+}
+
 // CreateScraperWithProviders creates a scraper with custom providers
 func CreateScraperWithProviders(providerList []metadata.MetadataProvider) *Scraper {
 	registry := providers.NewRegistry(providerList)
@@ -41,6 +60,20 @@ func CreateScraperWithProviderNames(providerNames []string) (*Scraper, error) {
 	return NewScraper(registry), nil
 }
 
+// CreateScraperWithProviderOrder creates a scraper with the named providers,
+// resolving values from them in the listed order instead of by priority
+func CreateScraperWithProviderOrder(providerNames []string) (*Scraper, error) {
+	loader := providers.NewLoader()
+
+	providerList, err := loader.LoadFromList(providerNames)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := providers.NewOrderedRegistry(providerList)
+	return NewScraper(registry), nil
+}
+
 // ScrapeMetadata is a convenience function to scrape metadata from a document
 func ScrapeMetadata(doc *html.Node) (*metadata.Metadata, error) {
 	scraper, err := CreateScraper()
@@ -51,6 +84,27 @@ func ScrapeMetadata(doc *html.Node) (*metadata.Metadata, error) {
 	return scraper.Scrape(doc)
 }
 
+// ScrapeReader parses HTML from r and scrapes it with the default providers.
+// The input is expected to be UTF-8.
+func ScrapeReader(r io.Reader) (*metadata.Metadata, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	return ScrapeMetadata(doc)
+}
+
+// ScrapeString parses an HTML string and scrapes it with the default providers
+func ScrapeString(document string) (*metadata.Metadata, error) {
+	return ScrapeReader(strings.NewReader(document))
+}
+
+// ScrapeBytes parses HTML bytes and scrapes them with the default providers
+func ScrapeBytes(document []byte) (*metadata.Metadata, error) {
+	return ScrapeReader(bytes.NewReader(document))
+}
+
 // ScrapeMetadataWithProviders is a convenience function to scrape with custom providers
 func ScrapeMetadataWithProviders(doc *html.Node, providerList []metadata.MetadataProvider) (*metadata.Metadata, error) {
 	scraper := CreateScraperWithProviders(providerList)
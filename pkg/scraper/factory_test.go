@@ -1,9 +1,21 @@
 package scraper
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/providers"
 	"golang.org/x/net/html"
 )
 
@@ -85,7 +97,7 @@ func TestCreateScraperWithProviderNames(t *testing.T) {
 			name:          "empty list",
 			providerNames: []string{},
 			expectError:   false,
-			expectedCount: 4, // Should return defaults
+			expectedCount: 9, // Should return defaults
 		},
 	}
 
@@ -120,6 +132,69 @@ func TestCreateScraperWithProviderNames(t *testing.T) {
 	}
 }
 
+func TestCreateScraperWithProviderOrder(t *testing.T) {
+	doc, _ := html.Parse(strings.NewReader(`<head>
+	<meta property="og:title" content="OG Title">
+	<meta name="twitter:title" content="Twitter Title">
+	<meta name="description" content="Description">
+	</head>`))
+
+	tests := []struct {
+		name          string
+		providerNames []string
+		expectedTitle string
+	}{
+		{name: "listed order wins over priority", providerNames: []string{"twitter", "openGraph"}, expectedTitle: "Twitter Title"},
+		{name: "priority order listed", providerNames: []string{"openGraph", "twitter"}, expectedTitle: "OG Title"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraper, err := CreateScraperWithProviderOrder(tt.providerNames)
+			if err != nil {
+				t.Fatalf("CreateScraperWithProviderOrder() returned error: %v", err)
+			}
+
+			result, err := scraper.Scrape(doc)
+			if err != nil {
+				t.Fatalf("Scrape() returned error: %v", err)
+			}
+			if title := result.Title(); title == nil || *title != tt.expectedTitle {
+				t.Errorf("Title() = %v, want %q", title, tt.expectedTitle)
+			}
+			if description := result.Description(); description != nil {
+				t.Errorf("Description() = %q, want nil without the meta provider", *description)
+			}
+		})
+	}
+
+	if _, err := CreateScraperWithProviderOrder([]string{"nonexistent"}); err == nil {
+		t.Error("CreateScraperWithProviderOrder() expected an error for an unknown provider")
+	}
+}
+
+func TestCreateScraperFromDirectory(t *testing.T) {
+	scraper, err := CreateScraperFromDirectory(t.TempDir())
+	if err != nil {
+		t.Fatalf("CreateScraperFromDirectory() returned error: %v", err)
+	}
+	if got, want := len(scraper.registry.GetProviders()), len(providers.NewLoader().LoadDefaults()); got != want {
+		t.Errorf("Provider count = %v, want the %v defaults for a directory without plugins", got, want)
+	}
+
+	if _, err := CreateScraperFromDirectory(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("CreateScraperFromDirectory() expected an error for a missing directory")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.so"), []byte("not a plugin"), 0o644); err != nil {
+		t.Fatalf("Failed to write plugin: %v", err)
+	}
+	if _, err := CreateScraperFromDirectory(dir); err == nil {
+		t.Error("CreateScraperFromDirectory() expected an error for a plugin that fails to load")
+	}
+}
+
 func TestScrapeMetadata(t *testing.T) {
 	// Create a simple HTML document
 	doc := &html.Node{
@@ -240,3 +315,383 @@ func TestScrapeMetadataWithProviderNames_InvalidProvider(t *testing.T) {
 		t.Error("Expected nil result for invalid provider name")
 	}
 }
+
+func TestScrapeMetadata_Microformats(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+<article class="h-entry">
+  <h1 class="p-name">Heading</h1>
+  <p class="p-summary">Entry summary</p>
+  <img class="u-photo" src="https://example.com/photo.jpg">
+</article>
+</body></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result, err := ScrapeMetadata(doc)
+	if err != nil {
+		t.Fatalf("ScrapeMetadata() returned error: %v", err)
+	}
+
+	if description := result.Description(); description == nil || *description != "Entry summary" {
+		t.Errorf("Description() = %v, want %q", description, "Entry summary")
+	}
+
+	if image := result.Image(); image == nil || *image != "https://example.com/photo.jpg" {
+		t.Errorf("Image() = %v, want %q", image, "https://example.com/photo.jpg")
+	}
+
+	// The h1 is still claimed once by the heading pass
+	if headings := result.Other()["firstHeading"]; len(headings) != 1 {
+		t.Errorf("Expected 1 firstHeading value, got %v", headings)
+	}
+}
+
+func TestScrapeMetadata_JSONLDPublishedTime(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<title>Page</title>
+<script type="application/ld+json">{"@type": "NewsArticle", "datePublished": "2024-02-03T04:05:06Z"}</script>
+</head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result, err := ScrapeMetadata(doc)
+	if err != nil {
+		t.Fatalf("ScrapeMetadata() returned error: %v", err)
+	}
+
+	published := result.PublishedTime()
+	if published == nil || published.Format("2006-01-02T15:04:05Z07:00") != "2024-02-03T04:05:06Z" {
+		t.Errorf("PublishedTime() = %v, want 2024-02-03T04:05:06Z", published)
+	}
+}
+
+func TestScrapeMetadata_Locale(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html lang="en-GB"><head>
+<meta property="og:locale:alternate" content="fr_FR">
+</head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result, err := ScrapeMetadata(doc)
+	if err != nil {
+		t.Fatalf("ScrapeMetadata() returned error: %v", err)
+	}
+
+	if locale := result.Locale(); locale == nil || *locale != "en-GB" {
+		t.Errorf("Locale() = %v, want %q", locale, "en-GB")
+	}
+
+	if alternates := result.AlternateLocales(); len(alternates) != 1 || alternates[0] != "fr_FR" {
+		t.Errorf("AlternateLocales() = %v, want [fr_FR]", alternates)
+	}
+}
+
+func TestScraper_WithMultiClaim(t *testing.T) {
+	source := `<html><head><title>Document Title</title></head><body>
+<article class="h-entry">
+  <h1 class="p-name">Entry Heading</h1>
+</article>
+</body></html>`
+
+	tests := []struct {
+		name               string
+		multiClaim         bool
+		expectedMicroTitle []string
+	}{
+		{
+			name:               "single claim leaves the heading to the first provider",
+			multiClaim:         false,
+			expectedMicroTitle: nil,
+		},
+		{
+			name:               "multi claim lets microformats extract the heading too",
+			multiClaim:         true,
+			expectedMicroTitle: []string{"Entry Heading"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(source))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			scraperInstance, err := CreateScraper()
+			if err != nil {
+				t.Fatalf("CreateScraper() returned error: %v", err)
+			}
+
+			result, err := scraperInstance.WithMultiClaim(tt.multiClaim).Scrape(doc)
+			if err != nil {
+				t.Fatalf("Scrape() returned error: %v", err)
+			}
+
+			microTitle := result.GetProviderData("microformats")["title"]
+			if len(microTitle) != len(tt.expectedMicroTitle) {
+				t.Errorf("microformats title = %v, want %v", microTitle, tt.expectedMicroTitle)
+			}
+
+			if headings := result.Other()["firstHeading"]; len(headings) != 1 {
+				t.Errorf("Expected 1 firstHeading value, got %v", headings)
+			}
+
+			// Resolution still follows provider priority, so the document
+			// title (other, priority 4) beats the entry name (microformats, 8)
+			if title := result.Title(); title == nil || *title != "Document Title" {
+				t.Errorf("Title() = %v, want %q", title, "Document Title")
+			}
+		})
+	}
+}
+
+// languageProvider records the document language it was scraped with
+type languageProvider struct {
+	providers.OtherElementsProvider
+	language string
+}
+
+func (p *languageProvider) ScrapeContext(ctx context.Context, node *html.Node, document *metadata.Document) *metadata.ScrapedData {
+	p.language = document.Language
+	return p.Scrape(node)
+}
+
+func TestScraper_ScrapeContext(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html lang="de"><head><title>Titel</title></head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	provider := &languageProvider{}
+	scraperInstance := CreateScraperWithProviders([]metadata.MetadataProvider{provider})
+
+	result, err := scraperInstance.ScrapeContext(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatalf("ScrapeContext() returned error: %v", err)
+	}
+
+	if provider.language != "de" {
+		t.Errorf("Document.Language = %q, want %q", provider.language, "de")
+	}
+
+	if title := result.Title(); title == nil || *title != "Titel" {
+		t.Errorf("Title() = %v, want %q", title, "Titel")
+	}
+}
+
+func TestScraper_ScrapeContext_Cancelled(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head><title>Title</title></head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeContext(ctx, doc, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ScrapeContext() error = %v, want %v", err, context.Canceled)
+	}
+	if result != nil {
+		t.Error("Expected nil result for cancelled scrape")
+	}
+}
+
+func TestScrapeMetadata_ResolveWithSource(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<title>Page Title</title>
+<meta property="og:title" content="OG Title">
+<link rel="icon" href="/icon.png">
+</head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result, err := ScrapeMetadata(doc)
+	if err != nil {
+		t.Fatalf("ScrapeMetadata() returned error: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected metadata.Source
+	}{
+		{
+			key: "title",
+			expected: metadata.Source{
+				Provider:  "openGraph",
+				Key:       "title",
+				Element:   `<meta property="og:title">`,
+				Attribute: "content",
+			},
+		},
+		{
+			key: "firstHeading",
+		},
+		{
+			key: "icon",
+			expected: metadata.Source{
+				Provider:  "other",
+				Key:       "icon",
+				Element:   `<link rel="icon">`,
+				Attribute: "href",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			resolved := result.ResolveWithSource(tt.key)
+			if tt.expected == (metadata.Source{}) {
+				if resolved != nil {
+					t.Errorf("ResolveWithSource(%q) = %+v, want nil", tt.key, resolved)
+				}
+				return
+			}
+
+			if resolved == nil || resolved.Source != tt.expected {
+				t.Errorf("ResolveWithSource(%q) = %+v, want source %+v", tt.key, resolved, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScraper_ScrapeContext_BaseURL(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<meta property="og:image" content="/cover.jpg">
+<link rel="icon" href="icon.png">
+<link rel="alternate" type="application/rss+xml" href="/feed.xml">
+</head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	pageURL, _ := url.Parse("https://example.com/blog/")
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeContext(context.Background(), doc, &metadata.Document{URL: pageURL})
+	if err != nil {
+		t.Fatalf("ScrapeContext() returned error: %v", err)
+	}
+
+	if image := result.Image(); image == nil || *image != "https://example.com/cover.jpg" {
+		t.Errorf("Image() = %v, want %v", image, "https://example.com/cover.jpg")
+	}
+
+	if favicon := result.Favicon(); favicon != "https://example.com/blog/icon.png" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://example.com/blog/icon.png")
+	}
+
+	if len(result.Feeds) != 1 || result.Feeds[0].Href != "https://example.com/feed.xml" {
+		t.Errorf("Feeds = %+v, want one feed at %v", result.Feeds, "https://example.com/feed.xml")
+	}
+}
+
+func TestScraper_ScrapeContext_LeavesDocument(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html lang="fr"><head><title>Titre</title></head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	document := &metadata.Document{}
+	scraperInstance, _ := CreateScraper()
+	if _, err := scraperInstance.ScrapeContext(context.Background(), doc, document); err != nil {
+		t.Fatalf("ScrapeContext() returned error: %v", err)
+	}
+
+	if document.Language != "" {
+		t.Errorf("Document.Language = %q, want the caller's document unchanged", document.Language)
+	}
+}
+
+func TestScraper_WithClock(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head><title>Title</title></head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	fixed := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.WithClock(metadata.FixedClock(fixed)).Scrape(doc)
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	if !result.ScrapedAt.Equal(fixed) {
+		t.Errorf("ScrapedAt = %v, want %v", result.ScrapedAt, fixed)
+	}
+}
+
+func TestScraper_Scrape_Concurrent(t *testing.T) {
+	scraperInstance, _ := CreateScraper()
+
+	const goroutines = 8
+	errs := make(chan error, goroutines)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(title string) {
+			defer wg.Done()
+
+			doc, err := html.Parse(strings.NewReader("<html><head><title>" + title + "</title></head></html>"))
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			result, err := scraperInstance.Scrape(doc)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if got := result.Title(); got == nil || *got != title {
+				errs <- fmt.Errorf("Title() = %v, want %q", got, title)
+			}
+		}(fmt.Sprintf("Title %d", i))
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestScrapeReader(t *testing.T) {
+	const document = `<html><head><title>Title</title><meta property="og:title" content="OG Title"></head></html>`
+
+	tests := []struct {
+		name   string
+		scrape func() (*metadata.Metadata, error)
+	}{
+		{name: "reader", scrape: func() (*metadata.Metadata, error) { return ScrapeReader(strings.NewReader(document)) }},
+		{name: "string", scrape: func() (*metadata.Metadata, error) { return ScrapeString(document) }},
+		{name: "bytes", scrape: func() (*metadata.Metadata, error) { return ScrapeBytes([]byte(document)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.scrape()
+			if err != nil {
+				t.Fatalf("Scrape returned error: %v", err)
+			}
+
+			if title := result.Title(); title == nil || *title != "OG Title" {
+				t.Errorf("Title() = %v, want %q", title, "OG Title")
+			}
+		})
+	}
+}
+
+func TestScrapeReader_ReadError(t *testing.T) {
+	readErr := errors.New("connection reset")
+
+	_, err := ScrapeReader(io.MultiReader(strings.NewReader("<html>"), iotest.ErrReader(readErr)))
+	if !errors.Is(err, readErr) {
+		t.Errorf("ScrapeReader() error = %v, want %v", err, readErr)
+	}
+}
@@ -0,0 +1,51 @@
+package scraper
+
+import (
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+// PreScrapeHook runs before providers see an element. Returning false skips
+// the element.
+type PreScrapeHook func(node *html.Node) bool
+
+// PostScrapeHook runs on each provider's extraction before it is added to
+// the result. It returns the data to add, possibly transformed, or false to
+// reject it.
+type PostScrapeHook func(provider string, data metadata.ScrapedData) (metadata.ScrapedData, bool)
+
+// WithPreScrapeHook adds a hook run, in registration order, before each
+// element is scraped
+func (s *Scraper) WithPreScrapeHook(hook PreScrapeHook) *Scraper {
+	s.preScrapeHooks = append(s.preScrapeHooks, hook)
+	return s
+}
+
+// WithPostScrapeHook adds a hook run, in registration order, on each
+// extraction before it is added to the result
+func (s *Scraper) WithPostScrapeHook(hook PostScrapeHook) *Scraper {
+	s.postScrapeHooks = append(s.postScrapeHooks, hook)
+	return s
+}
+
+// runPreScrapeHooks reports whether every pre-scrape hook accepts node
+func (s *Scraper) runPreScrapeHooks(node *html.Node) bool {
+	for _, hook := range s.preScrapeHooks {
+		if !hook(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// runPostScrapeHooks passes data through every post-scrape hook, stopping
+// when one rejects it
+func (s *Scraper) runPostScrapeHooks(provider string, data metadata.ScrapedData) (metadata.ScrapedData, bool) {
+	for _, hook := range s.postScrapeHooks {
+		var keep bool
+		if data, keep = hook(provider, data); !keep {
+			return data, false
+		}
+	}
+	return data, true
+}
@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+const hooksFixture = `<html><head>
+<title>Page Title</title>
+<meta property="og:url" content="https://example.com/post?utm_source=feed&id=7">
+<meta name="description" content="Description">
+<meta name="robots" content="noindex">
+</head></html>`
+
+// stripTrackingParams removes utm_* query parameters from URL values
+func stripTrackingParams(provider string, data metadata.ScrapedData) (metadata.ScrapedData, bool) {
+	if data.Key != "url" {
+		return data, true
+	}
+
+	u, err := url.Parse(data.Value)
+	if err != nil {
+		return data, true
+	}
+
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(key, "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	data.Value = u.String()
+	return data, true
+}
+
+func TestScraper_Hooks(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(hooksFixture))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	var extracted []string
+	scraperInstance, _ := CreateScraper()
+	scraperInstance.
+		WithPreScrapeHook(func(node *html.Node) bool {
+			// Skip the robots directive
+			for _, attr := range node.Attr {
+				if attr.Key == "name" && attr.Val == "robots" {
+					return false
+				}
+			}
+			return true
+		}).
+		WithPostScrapeHook(stripTrackingParams).
+		WithPostScrapeHook(func(provider string, data metadata.ScrapedData) (metadata.ScrapedData, bool) {
+			extracted = append(extracted, data.Key)
+			return data, data.Key != "description"
+		})
+
+	result, err := scraperInstance.Scrape(doc)
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	if pageURL := result.URL(); pageURL == nil || *pageURL != "https://example.com/post?id=7" {
+		t.Errorf("URL() = %v, want tracking parameters stripped", pageURL)
+	}
+
+	if description := result.Description(); description != nil {
+		t.Errorf("Description() = %v, want nil after rejection", *description)
+	}
+
+	if robots := result.Meta()["robots"]; len(robots) != 0 {
+		t.Errorf("Meta robots = %v, want element skipped", robots)
+	}
+
+	if len(extracted) == 0 {
+		t.Error("Expected post-scrape hooks to run for every extraction")
+	}
+
+	resolved := result.ResolveWithSource("url")
+	if resolved == nil || resolved.Source.Attribute != "content" {
+		t.Errorf("ResolveWithSource() = %+v, want the content attribute", resolved)
+	}
+}
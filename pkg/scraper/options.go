@@ -0,0 +1,62 @@
+package scraper
+
+import "unicode/utf8"
+
+// ScrapeOptions bounds the work a scrape does, protecting callers from
+// pathological or adversarial documents. Zero values mean no limit.
+type ScrapeOptions struct {
+	// HeadOnly skips the <body>, so only the document head is scraped
+	HeadOnly bool
+
+	// MaxNodes caps how many nodes each pass over the document visits;
+	// nodes beyond the limit, in document order, are ignored. ScrapeStream
+	// counts start tags instead.
+	MaxNodes int
+
+	// MaxDepth ignores nodes nested deeper than this below the document
+	// root. It does not apply to ScrapeStream, which builds no tree.
+	MaxDepth int
+
+	// MaxValueLength truncates scraped values to this many bytes, on a
+	// UTF-8 boundary. A truncated JSON-LD block no longer parses, so its
+	// properties are dropped. Values are always stripped of control and
+	// zero-width characters (see metadata.SanitizeValue), whatever the
+	// options.
+	MaxValueLength int
+
+	// DescriptionFromBody extracts the first meaningful paragraph of the
+	// body as a fallback for pages without a description tag
+	DescriptionFromBody bool
+
+	// DescriptionLength truncates the body description to this many
+	// characters; defaults to metadata.MaxDescriptionLength
+	DescriptionLength int
+
+	// BodyImages collects up to this many <img> elements from the body as
+	// fallback images for pages without an og:image or twitter:image
+	BodyImages int
+
+	// DetectLanguage guesses the language from the body text when the page
+	// declares none, exposed as Metadata.DetectedLanguage
+	DetectLanguage bool
+}
+
+// WithOptions sets the limits applied to each scrape
+func (s *Scraper) WithOptions(options ScrapeOptions) *Scraper {
+	s.options = options
+	return s
+}
+
+// truncateValue shortens value to MaxValueLength bytes without splitting a
+// UTF-8 sequence
+func (o ScrapeOptions) truncateValue(value string) string {
+	if o.MaxValueLength <= 0 || len(value) <= o.MaxValueLength {
+		return value
+	}
+
+	cut := o.MaxValueLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut]
+}
@@ -0,0 +1,153 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const optionsFixture = `<html><head>
+<title>Page Title</title>
+<meta name="description" content="Description">
+</head><body>
+<div><div><div><h1>Deep Heading</h1></div></div></div>
+</body></html>`
+
+func TestScraper_WithOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     ScrapeOptions
+		title       string
+		description string
+		heading     bool
+	}{
+		{
+			name:        "no limits",
+			options:     ScrapeOptions{},
+			title:       "Page Title",
+			description: "Description",
+			heading:     true,
+		},
+		{
+			name:        "head only",
+			options:     ScrapeOptions{HeadOnly: true},
+			title:       "Page Title",
+			description: "Description",
+			heading:     false,
+		},
+		{
+			name:        "max depth",
+			options:     ScrapeOptions{MaxDepth: 4},
+			title:       "Page Title",
+			description: "Description",
+			heading:     false,
+		},
+		{
+			name:        "max nodes",
+			options:     ScrapeOptions{MaxNodes: 6},
+			title:       "Page Title",
+			description: "",
+			heading:     false,
+		},
+		{
+			name:        "max value length",
+			options:     ScrapeOptions{MaxValueLength: 4},
+			title:       "Page",
+			description: "Desc",
+			heading:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader(optionsFixture))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			scraperInstance, _ := CreateScraper()
+			result, err := scraperInstance.WithOptions(tt.options).Scrape(doc)
+			if err != nil {
+				t.Fatalf("Scrape() returned error: %v", err)
+			}
+
+			if title := result.Other()["title"]; len(title) == 0 || title[0] != tt.title {
+				t.Errorf("Other title = %v, want %q", title, tt.title)
+			}
+
+			description := ""
+			if value := result.Description(); value != nil {
+				description = *value
+			}
+			if description != tt.description {
+				t.Errorf("Description() = %q, want %q", description, tt.description)
+			}
+
+			if heading := len(result.Other()["firstHeading"]) > 0; heading != tt.heading {
+				t.Errorf("firstHeading scraped = %v, want %v", heading, tt.heading)
+			}
+		})
+	}
+}
+
+func TestScraper_WithOptions_Stream(t *testing.T) {
+	scraperInstance, _ := CreateScraper()
+
+	result, err := scraperInstance.WithOptions(ScrapeOptions{HeadOnly: true}).ScrapeStream(&errAfterReader{r: strings.NewReader(`<html><head><title>Title</title></head>`)})
+	if err != nil {
+		t.Fatalf("ScrapeStream() returned error: %v", err)
+	}
+	if title := result.Title(); title == nil || *title != "Title" {
+		t.Errorf("Title() = %v, want %q", title, "Title")
+	}
+
+	result, err = scraperInstance.WithOptions(ScrapeOptions{MaxNodes: 3}).ScrapeStream(strings.NewReader(optionsFixture))
+	if err != nil {
+		t.Fatalf("ScrapeStream() returned error: %v", err)
+	}
+	if description := result.Description(); description != nil {
+		t.Errorf("Description() = %v, want nil beyond the node limit", *description)
+	}
+}
+
+func TestScrapeOptions_truncateValue(t *testing.T) {
+	tests := []struct {
+		limit    int
+		value    string
+		expected string
+	}{
+		{limit: 0, value: "unlimited", expected: "unlimited"},
+		{limit: 10, value: "short", expected: "short"},
+		{limit: 3, value: "abcdef", expected: "abc"},
+		{limit: 2, value: "héllo", expected: "h"},
+	}
+
+	for _, tt := range tests {
+		options := ScrapeOptions{MaxValueLength: tt.limit}
+		if result := options.truncateValue(tt.value); result != tt.expected {
+			t.Errorf("truncateValue(%q) = %q, want %q", tt.value, result, tt.expected)
+		}
+	}
+}
+
+func TestScraper_SanitizesValues(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader("<html><head><meta name=\"description\" content=\"Safe\x1b]0;pwned\x07 \u202etext\"><link rel=\"alternate\" type=\"application/rss+xml\" title=\"Feed\x1b[2J\" href=\"/feed\u200b.xml\"></head></html>"))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.Scrape(doc)
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	if description := result.Description(); description == nil || *description != "Safe]0;pwned text" {
+		t.Errorf("Description() = %v, want %q", description, "Safe]0;pwned text")
+	}
+
+	if len(result.Feeds) != 1 || *result.Feeds[0].Title != "Feed[2J" || result.Feeds[0].Href != "/feed.xml" {
+		t.Errorf("Feeds = %+v, want a sanitized feed", result.Feeds)
+	}
+}
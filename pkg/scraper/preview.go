@@ -0,0 +1,152 @@
+package scraper
+
+import (
+	"io"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"github.com/alvincrespo/glypto-go/pkg/metadata/keys"
+	"github.com/alvincrespo/glypto-go/pkg/providers"
+	"golang.org/x/net/html"
+)
+
+// previewRegistry resolves values for preview scrapes. It is only consulted
+// when reading results, never for element dispatch.
+var previewRegistry = providers.NewRegistry([]metadata.MetadataProvider{
+	providers.NewOpenGraphProvider(),
+	providers.NewTwitterProvider(),
+	providers.NewOtherElementsProvider(),
+})
+
+// ScrapePreview is a fast path for link-preview use cases. It tokenizes the
+// document without building a DOM, recognizes only og:/twitter: meta tags,
+// meta refresh, <base href>, the <title>, and icon links, and stops once the
+// head has been read.
+func ScrapePreview(r io.Reader) (*metadata.Metadata, error) {
+	return ScrapePreviewWithOptions(r, ScrapeOptions{})
+}
+
+// ScrapePreviewWithOptions is ScrapePreview with MaxValueLength applied to
+// the values it records; the other options do not affect the preview
+func ScrapePreviewWithOptions(r io.Reader, options ScrapeOptions) (*metadata.Metadata, error) {
+	return (&Scraper{options: options}).ScrapePreview(r)
+}
+
+// ScrapePreview runs the preview fast path with the scraper's options and
+// clock; its providers are not used
+func (s *Scraper) ScrapePreview(r io.Reader) (*metadata.Metadata, error) {
+	options := s.options
+	result := metadata.NewMetadata(previewRegistry)
+	result.ScrapedAt = s.now()
+	z := html.NewTokenizer(r)
+	inTitle := false
+
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return result, nil
+			}
+			return nil, z.Err()
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return result, nil
+			case "title":
+				inTitle = true
+			case "base":
+				if hasAttr && result.BaseHref == "" {
+					result.BaseHref = strings.TrimSpace(tagAttributes(z)["href"])
+				}
+			case "meta":
+				if hasAttr {
+					scrapePreviewMeta(result, options, tagAttributes(z))
+				}
+			case "link":
+				if hasAttr {
+					scrapePreviewLink(result, options, tagAttributes(z))
+				}
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "head":
+				return result, nil
+			case "title":
+				inTitle = false
+			}
+
+		case html.TextToken:
+			if inTitle {
+				if title := metadata.NormalizeValue(string(z.Text())); title != "" {
+					addPreviewData(result, options, "other", "title", title)
+				}
+				inTitle = false
+			}
+		}
+	}
+}
+
+// addPreviewData sanitizes and truncates a value before recording it
+func addPreviewData(result *metadata.Metadata, options ScrapeOptions, provider, key, value string) {
+	result.AddData(provider, key, options.truncateValue(metadata.SanitizeValue(value)))
+}
+
+// tagAttributes collects the current tag's attributes
+func tagAttributes(z *html.Tokenizer) map[string]string {
+	attrs := make(map[string]string)
+	for {
+		key, val, more := z.TagAttr()
+		if _, exists := attrs[string(key)]; !exists {
+			attrs[string(key)] = string(val)
+		}
+		if !more {
+			return attrs
+		}
+	}
+}
+
+// scrapePreviewMeta records og: and twitter: meta tags and meta refresh
+func scrapePreviewMeta(result *metadata.Metadata, options ScrapeOptions, attrs map[string]string) {
+	if strings.EqualFold(strings.TrimSpace(attrs["http-equiv"]), keys.Refresh) && attrs["content"] != "" {
+		addPreviewData(result, options, "other", keys.Refresh, attrs["content"])
+		return
+	}
+
+	property := attrs["property"]
+	if property == "" {
+		property = attrs["name"]
+	}
+
+	content := metadata.NormalizeValue(attrs["content"])
+	if content == "" {
+		return
+	}
+
+	if key, found := strings.CutPrefix(property, providers.OGPrefix); found && key != "" {
+		addPreviewData(result, options, "openGraph", key, content)
+	} else if key, found := strings.CutPrefix(property, providers.TwitterPrefix); found && key != "" {
+		addPreviewData(result, options, "twitter", key, content)
+	}
+}
+
+// scrapePreviewLink records icon links
+func scrapePreviewLink(result *metadata.Metadata, options ScrapeOptions, attrs map[string]string) {
+	rel := attrs["rel"]
+	href := attrs["href"]
+	if href != "" && (rel == "icon" || rel == "shortcut icon") {
+		addPreviewData(result, options, "other", rel, href)
+	}
+
+	if normalized, isIcon := iconRel(rel); isIcon && href != "" {
+		result.Icons = append(result.Icons, &metadata.Icon{
+			Rel:   normalized,
+			Href:  metadata.SanitizeValue(href),
+			Type:  metadata.SanitizeValue(attrs["type"]),
+			Sizes: metadata.SanitizeValue(attrs["sizes"]),
+		})
+	}
+}
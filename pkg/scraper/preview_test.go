@@ -0,0 +1,157 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+const previewFixture = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Tom &amp; Jerry</title>
+  <meta property="og:title" content="OG Title">
+  <meta property="og:image" content="https://example.com/a.jpg">
+  <meta property="og:image:width" content="1200">
+  <meta name="twitter:card" content="summary_large_image">
+  <meta name="description" content="Ignored by preview">
+  <meta http-equiv="Refresh" content="5; url=/next">
+  <link rel="icon" href="/favicon.png">
+  <link rel="stylesheet" href="/style.css">
+</head>
+<body>
+  <h1>Heading</h1>
+  <meta property="og:description" content="After head">
+</body>
+</html>`
+
+func TestScrapePreview(t *testing.T) {
+	result, err := ScrapePreview(strings.NewReader(previewFixture))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+
+	if title := result.Title(); title == nil || *title != "OG Title" {
+		t.Errorf("Title() = %v, want %q", title, "OG Title")
+	}
+
+	if title := result.Other()["title"]; len(title) != 1 || title[0] != "Tom & Jerry" {
+		t.Errorf("Other title = %v, want [Tom & Jerry]", title)
+	}
+
+	if favicon := result.Favicon(); favicon != "/favicon.png" {
+		t.Errorf("Favicon() = %v, want /favicon.png", favicon)
+	}
+
+	if len(result.Icons) != 1 || result.Icons[0].Rel != "icon" {
+		t.Errorf("Icons = %+v, want one icon", result.Icons)
+	}
+
+	if card := result.TwitterCard()["card"]; len(card) != 1 || card[0] != "summary_large_image" {
+		t.Errorf("Twitter card = %v, want [summary_large_image]", card)
+	}
+
+	if images := result.Images(); len(images) != 1 || images[0].Width != 1200 {
+		t.Errorf("Images() = %+v, want one 1200px image", images)
+	}
+
+	if description := result.Description(); description != nil {
+		t.Errorf("Description() = %v, want nil (standard meta and body are skipped)", *description)
+	}
+
+	if target := result.RefreshURL(); target == nil || *target != "/next" {
+		t.Errorf("RefreshURL() = %v, want %q", target, "/next")
+	}
+
+	result, err = ScrapePreview(strings.NewReader(strings.Replace(previewFixture, "<head>", `<head><base href="https://cdn.example.com/">`, 1)))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+	if favicon := result.Favicon(); favicon != "https://cdn.example.com/favicon.png" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://cdn.example.com/favicon.png")
+	}
+}
+
+func TestScrapePreview_NoHead(t *testing.T) {
+	result, err := ScrapePreview(strings.NewReader(`<title>Only Title</title>`))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+
+	if title := result.Title(); title == nil || *title != "Only Title" {
+		t.Errorf("Title() = %v, want %q", title, "Only Title")
+	}
+}
+
+func BenchmarkScrapePreview(b *testing.B) {
+	page := previewFixture + strings.Repeat("<p>body content</p>", 5000)
+
+	for i := 0; i < b.N; i++ {
+		if _, err := ScrapePreview(strings.NewReader(page)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScrapeFull(b *testing.B) {
+	page := previewFixture + strings.Repeat("<p>body content</p>", 5000)
+
+	for i := 0; i < b.N; i++ {
+		doc, err := html.Parse(strings.NewReader(page))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ScrapeMetadata(doc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScrapePreview_NormalizesValues(t *testing.T) {
+	result, err := ScrapePreview(strings.NewReader("<head><title>\n  Tom &amp;amp;\n\tJerry </title><meta property=\"og:description\" content=\" Cats  &amp;amp; mice \"></head>"))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+
+	if title := result.Other()["title"]; len(title) != 1 || title[0] != "Tom & Jerry" {
+		t.Errorf("Other title = %v, want [Tom & Jerry]", title)
+	}
+
+	if description := result.Description(); description == nil || *description != "Cats & mice" {
+		t.Errorf("Description() = %v, want %q", description, "Cats & mice")
+	}
+}
+
+func TestScrapePreviewWithOptions(t *testing.T) {
+	page := "<head><title>Title</title><meta property=\"og:title\" content=\"\x1b[31mRed\u200b title\"><meta property=\"og:description\" content=\"" + strings.Repeat("a", 100) + "\"></head>"
+
+	result, err := ScrapePreviewWithOptions(strings.NewReader(page), ScrapeOptions{MaxValueLength: 10})
+	if err != nil {
+		t.Fatalf("ScrapePreviewWithOptions() returned error: %v", err)
+	}
+
+	if title := result.Title(); title == nil || *title != "[31mRed ti" {
+		t.Errorf("Title() = %v, want %q", title, "[31mRed ti")
+	}
+
+	if description := result.Description(); description == nil || *description != strings.Repeat("a", 10) {
+		t.Errorf("Description() = %v, want 10 bytes", description)
+	}
+}
+
+func TestScraper_ScrapePreview_Clock(t *testing.T) {
+	fixed := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.WithClock(metadata.FixedClock(fixed)).ScrapePreview(strings.NewReader("<head><title>Title</title></head>"))
+	if err != nil {
+		t.Fatalf("ScrapePreview() returned error: %v", err)
+	}
+
+	if !result.ScrapedAt.Equal(fixed) {
+		t.Errorf("ScrapedAt = %v, want %v", result.ScrapedAt, fixed)
+	}
+}
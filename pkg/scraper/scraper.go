@@ -1,18 +1,35 @@
 package scraper
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/alvincrespo/glypto-go/pkg/metadata"
 	"golang.org/x/net/html"
 )
 
-// Scraper provides metadata extraction functionality
+// Scraper provides metadata extraction functionality. A Scraper holds only
+// its configuration, so once configured it can serve concurrent scrapes.
 type Scraper struct {
-	registry metadata.Registry
+	registry   metadata.Registry
+	multiClaim bool
+	clock      metadata.Clock
+	options    ScrapeOptions
+
+	preScrapeHooks  []PreScrapeHook
+	postScrapeHooks []PostScrapeHook
+}
+
+// scrapeState holds the state of a single scrape
+type scrapeState struct {
+	*Scraper
 	doc      *html.Node
 	result   *metadata.Metadata
+	ctx      context.Context
+	document *metadata.Document
+	stats    ScrapeStats
 }
 
 // NewScraper creates a new scraper instance
@@ -22,25 +39,153 @@ func NewScraper(registry metadata.Registry) *Scraper {
 	}
 }
 
+// WithMultiClaim lets every capable provider extract from each element
+// instead of only the first provider that yields data. Resolution still
+// follows provider priority. Registries that don't implement
+// metadata.MultiClaimRegistry fall back to a single claim per element.
+func (s *Scraper) WithMultiClaim(enabled bool) *Scraper {
+	s.multiClaim = enabled
+	return s
+}
+
+// WithClock sets the clock used to timestamp results, defaulting to
+// metadata.SystemClock
+func (s *Scraper) WithClock(clock metadata.Clock) *Scraper {
+	s.clock = clock
+	return s
+}
+
 // Scrape extracts metadata from an HTML document
 func (s *Scraper) Scrape(doc *html.Node) (*metadata.Metadata, error) {
+	return s.ScrapeContext(context.Background(), doc, nil)
+}
+
+// ScrapeContext extracts metadata from an HTML document, passing ctx and the
+// document description to providers that implement
+// metadata.ContextProvider. When document has no language, the root
+// element's lang attribute is used, and when it has a URL, relative links are
+// resolved against it. Scraping stops early and returns ctx.Err() once ctx
+// is done.
+func (s *Scraper) ScrapeContext(ctx context.Context, doc *html.Node, document *metadata.Document) (*metadata.Metadata, error) {
+	result, _, err := s.scrape(ctx, doc, document)
+	return result, err
+}
+
+// scrape runs every pass over doc, returning the state alongside the result
+// so callers can read its stats
+func (s *Scraper) scrape(ctx context.Context, doc *html.Node, document *metadata.Document) (*metadata.Metadata, *scrapeState, error) {
 	if doc == nil {
-		return nil, fmt.Errorf("HTML document cannot be nil")
+		return nil, nil, fmt.Errorf("HTML document cannot be nil")
 	}
 
-	s.doc = doc
-	s.result = metadata.NewMetadata(s.registry)
+	// The language found below is set on a copy, leaving the caller's
+	// document as it was
+	var described metadata.Document
+	if document != nil {
+		described = *document
+	}
+
+	state := s.newScrape(ctx, &described)
+	state.doc = doc
+	if described.Language == "" {
+		described.Language = state.documentLanguage(doc)
+	}
 
-	return s.scrapeMetaTags().
+	result := state.scrapeBaseTag().
+		scrapeHTMLTag().
+		scrapeMetaTags().
 		scrapeTitleTag().
 		scrapeHeadingTags().
+		scrapeOutline().
 		scrapeLinkTags().
 		scrapeFeedLinks().
-		getResult(), nil
+		scrapeIconLinks().
+		scrapeMicroformats().
+		scrapeScriptTags().
+		scrapeBodyDescription().
+		scrapeBodyImages().
+		scrapeWordCount().
+		scrapeLanguage().
+		getResult()
+
+	if err := ctx.Err(); err != nil {
+		return nil, state, err
+	}
+	return result, state, nil
+}
+
+// newScrape starts the state for a single scrape
+func (s *Scraper) newScrape(ctx context.Context, document *metadata.Document) *scrapeState {
+	result := metadata.NewMetadata(s.registry)
+	result.BaseURL = document.URL
+	result.ScrapedAt = s.now()
+
+	return &scrapeState{
+		Scraper:  s,
+		ctx:      ctx,
+		document: document,
+		result:   result,
+	}
+}
+
+// now returns the current time from the configured clock
+func (s *Scraper) now() time.Time {
+	if s.clock == nil {
+		return metadata.SystemClock.Now()
+	}
+	return s.clock.Now()
+}
+
+// documentLanguage returns the lang attribute of the root <html> element
+func (s *scrapeState) documentLanguage(doc *html.Node) string {
+	var lang string
+	s.walkNodes(doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == "html" {
+			lang = strings.TrimSpace(s.getAttribute(n, "lang"))
+			return false
+		}
+		return true
+	})
+	return lang
+}
+
+// scrapeBaseTag records the first <base href>, which later passes resolve
+// relative links against
+func (s *scrapeState) scrapeBaseTag() *scrapeState {
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if s.result.BaseHref != "" {
+			return false
+		}
+		if n.Type == html.ElementNode && n.Data == "base" {
+			s.recordBase(n)
+			return false
+		}
+		return true
+	})
+	return s
+}
+
+// recordBase sets the result's BaseHref from a <base> element's href
+func (s *scrapeState) recordBase(n *html.Node) {
+	if s.result.BaseHref == "" {
+		s.result.BaseHref = strings.TrimSpace(s.getAttribute(n, "href"))
+	}
+}
+
+// scrapeHTMLTag extracts data from the root <html> element (e.g. lang)
+func (s *scrapeState) scrapeHTMLTag() *scrapeState {
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == "html" {
+			s.scrapeFromElement(n)
+			return false
+		}
+		return true
+	})
+	return s
 }
 
 // scrapeMetaTags extracts metadata from <meta> tags
-func (s *Scraper) scrapeMetaTags() *Scraper {
+func (s *scrapeState) scrapeMetaTags() *scrapeState {
 	s.walkNodes(s.doc, func(n *html.Node) bool {
 		if n.Type == html.ElementNode && n.Data == "meta" {
 			s.scrapeFromElement(n)
@@ -51,8 +196,8 @@ func (s *Scraper) scrapeMetaTags() *Scraper {
 }
 
 // scrapeTitleTag extracts data from <title> tag
-func (s *Scraper) scrapeTitleTag() *Scraper {
-	s.walkNodes(s.doc, func(n *html.Node) bool {
+func (s *scrapeState) scrapeTitleTag() *scrapeState {
+	s.walkContent(s.doc, func(n *html.Node) bool {
 		if n.Type == html.ElementNode && n.Data == "title" {
 			s.scrapeFromElement(n)
 		}
@@ -62,8 +207,8 @@ func (s *Scraper) scrapeTitleTag() *Scraper {
 }
 
 // scrapeHeadingTags extracts data from <h1> tags
-func (s *Scraper) scrapeHeadingTags() *Scraper {
-	s.walkNodes(s.doc, func(n *html.Node) bool {
+func (s *scrapeState) scrapeHeadingTags() *scrapeState {
+	s.walkContent(s.doc, func(n *html.Node) bool {
 		if n.Type == html.ElementNode && n.Data == "h1" {
 			s.scrapeFromElement(n)
 		}
@@ -72,8 +217,29 @@ func (s *Scraper) scrapeHeadingTags() *Scraper {
 	return s
 }
 
+// headingLevels maps heading elements to their outline level
+var headingLevels = map[string]int{"h1": 1, "h2": 2, "h3": 3, "h4": 4, "h5": 5, "h6": 6}
+
+// scrapeOutline records every h1–h6 heading in document order
+func (s *scrapeState) scrapeOutline() *scrapeState {
+	s.walkContent(s.doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		if level, isHeading := headingLevels[n.Data]; isHeading {
+			if text := metadata.NormalizeValue(s.getTextContent(n)); text != "" {
+				s.result.AddHeading(level, s.options.truncateValue(text))
+			}
+			return false
+		}
+		return true
+	})
+	return s
+}
+
 // scrapeLinkTags extracts data from <link> tags with rel attribute
-func (s *Scraper) scrapeLinkTags() *Scraper {
+func (s *scrapeState) scrapeLinkTags() *scrapeState {
 	s.walkNodes(s.doc, func(n *html.Node) bool {
 		if n.Type == html.ElementNode && n.Data == "link" && s.hasAttribute(n, "rel") {
 			s.scrapeFromElement(n)
@@ -83,27 +249,103 @@ func (s *Scraper) scrapeLinkTags() *Scraper {
 	return s
 }
 
+// scrapeIconLinks records every icon link along with its sizes and type
+func (s *scrapeState) scrapeIconLinks() *scrapeState {
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == "link" {
+			s.recordIcon(n)
+		}
+		return true
+	})
+	return s
+}
+
+// recordIcon adds a <link> element to the result's icons when it declares one
+func (s *scrapeState) recordIcon(n *html.Node) {
+	rel, isIcon := iconRel(s.getAttribute(n, "rel"))
+	href := s.getAttribute(n, "href")
+	if isIcon && href != "" {
+		s.result.Icons = append(s.result.Icons, &metadata.Icon{
+			Rel:   rel,
+			Href:  s.result.ResolveURL(metadata.SanitizeValue(href)),
+			Type:  metadata.SanitizeValue(s.getAttribute(n, "type")),
+			Sizes: metadata.SanitizeValue(s.getAttribute(n, "sizes")),
+		})
+	}
+}
+
+// iconRel normalizes a link rel attribute, reporting whether it declares an
+// icon
+func iconRel(rel string) (string, bool) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(rel)), " ")
+	switch normalized {
+	case metadata.IconRelIcon, metadata.IconRelShortcutIcon, metadata.IconRelAppleTouchIcon,
+		metadata.IconRelAppleTouchLegacy, metadata.IconRelMaskIcon:
+		return normalized, true
+	default:
+		return "", false
+	}
+}
+
 // scrapeFeedLinks extracts RSS/Atom feed links
-func (s *Scraper) scrapeFeedLinks() *Scraper {
+func (s *scrapeState) scrapeFeedLinks() *scrapeState {
 	s.walkNodes(s.doc, func(n *html.Node) bool {
 		if n.Type == html.ElementNode && n.Data == "link" {
-			rel := s.getAttribute(n, "rel")
-			if rel == "alternate" {
-				title := s.getAttribute(n, "title")
-				feedType := s.getAttribute(n, "type")
-				href := s.getAttribute(n, "href")
-
-				if href != "" {
-					feed := &metadata.Feed{
-						Type: feedType,
-						Href: href,
-					}
-					if title != "" {
-						feed.Title = &title
-					}
-					s.result.Feeds = append(s.result.Feeds, feed)
-				}
-			}
+			s.recordFeed(n)
+		}
+		return true
+	})
+	return s
+}
+
+// recordFeed adds a <link rel="alternate"> element to the result's feeds
+func (s *scrapeState) recordFeed(n *html.Node) {
+	if s.getAttribute(n, "rel") != "alternate" {
+		return
+	}
+
+	title := metadata.NormalizeValue(s.getAttribute(n, "title"))
+	href := metadata.SanitizeValue(s.getAttribute(n, "href"))
+	if href == "" {
+		return
+	}
+
+	feed := &metadata.Feed{
+		Type: metadata.SanitizeValue(s.getAttribute(n, "type")),
+		Href: s.result.ResolveURL(href),
+	}
+	if title != "" {
+		feed.Title = &title
+	}
+	s.result.Feeds = append(s.result.Feeds, feed)
+}
+
+// scrapeMicroformats extracts microformats2 properties from elements not
+// already visited by the tag-specific passes
+func (s *scrapeState) scrapeMicroformats() *scrapeState {
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if n.Type != html.ElementNode {
+			return true
+		}
+
+		switch n.Data {
+		case "html", "meta", "title", "h1", "link":
+			return true
+		}
+
+		if s.hasMicroformatProperty(n) {
+			s.scrapeFromElement(n)
+		}
+		return true
+	})
+	return s
+}
+
+// scrapeScriptTags extracts structured data from <script> tags
+func (s *scrapeState) scrapeScriptTags() *scrapeState {
+	s.walkNodes(s.doc, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.Data == "script" && s.hasAttribute(n, "type") {
+			s.scrapeFromElement(n)
 		}
 		return true
 	})
@@ -111,23 +353,128 @@ func (s *Scraper) scrapeFeedLinks() *Scraper {
 }
 
 // scrapeFromElement attempts to scrape metadata from an element
-func (s *Scraper) scrapeFromElement(node *html.Node) {
+func (s *scrapeState) scrapeFromElement(node *html.Node) {
+	if !s.runPreScrapeHooks(node) {
+		return
+	}
+
+	if contextRegistry, ok := s.registry.(metadata.ContextRegistry); ok {
+		if s.multiClaim {
+			for _, extraction := range contextRegistry.ScrapeAllFromElementContext(s.context(), node, s.document) {
+				s.addExtraction(node, extraction)
+			}
+		} else if extraction := contextRegistry.ScrapeFromElementContext(s.context(), node, s.document); extraction != nil {
+			s.addExtraction(node, extraction)
+		}
+		return
+	}
+
+	if multiClaimRegistry, ok := s.registry.(metadata.MultiClaimRegistry); ok && s.multiClaim {
+		for _, extraction := range multiClaimRegistry.ScrapeAllFromElement(node) {
+			s.addExtraction(node, extraction)
+		}
+		return
+	}
+
 	if extraction := s.registry.ScrapeFromElement(node); extraction != nil {
-		s.result.AddData(
-			(*extraction.Provider).Name(),
-			extraction.Data.Key,
-			extraction.Data.Value,
-		)
+		s.addExtraction(node, extraction)
 	}
 }
 
-// walkNodes recursively walks through HTML nodes
-func (s *Scraper) walkNodes(n *html.Node, fn func(*html.Node) bool) {
+// context returns the context of the current scrape
+func (s *scrapeState) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// addExtraction records a provider's extracted data in the result along
+// with the element it came from
+func (s *scrapeState) addExtraction(node *html.Node, extraction *metadata.ScrapingResult) {
+	provider := (*extraction.Provider).Name()
+	s.recordMatch(provider)
+
+	data, keep := s.runPostScrapeHooks(provider, *extraction.Data)
+	if !keep {
+		return
+	}
+
+	// Hooks may rewrite the value, so infer the attribute from the original
+	attribute := data.Attribute
+	if attribute == "" {
+		attribute = s.valueAttribute(node, extraction.Data.Value)
+	}
+
+	value := s.options.truncateValue(metadata.SanitizeValue(data.Value))
+	s.stats.TextBytes += len(value)
+	s.result.AddSourcedData(metadata.Source{
+		Provider:  provider,
+		Key:       data.Key,
+		Element:   s.describeElement(node),
+		Attribute: attribute,
+	}, value)
+}
+
+// identifyingAttributes are the attributes included when describing an
+// element, in display order
+var identifyingAttributes = []string{"property", "name", "http-equiv", "charset", "rel", "itemprop", "class", "type"}
+
+// describeElement renders an element's tag with its identifying attributes,
+// e.g. <meta property="og:title">
+func (s *Scraper) describeElement(n *html.Node) string {
+	var b strings.Builder
+	b.WriteString("<" + n.Data)
+	for _, key := range identifyingAttributes {
+		if s.hasAttribute(n, key) {
+			fmt.Fprintf(&b, " %s=%q", key, s.getAttribute(n, key))
+		}
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// valueAttribute returns the attribute holding value, or "" when the value
+// did not come from an attribute (e.g. text content)
+func (s *Scraper) valueAttribute(n *html.Node, value string) string {
+	for _, attr := range n.Attr {
+		if strings.TrimSpace(attr.Val) == value {
+			return attr.Key
+		}
+	}
+	return ""
+}
+
+// walkNodes recursively walks through HTML nodes within the configured
+// limits
+func (s *scrapeState) walkNodes(n *html.Node, fn func(*html.Node) bool) {
+	visited := 0
+	s.walk(n, 0, &visited, fn)
+}
+
+// walk visits n and its descendants, counting visited nodes
+func (s *scrapeState) walk(n *html.Node, depth int, visited *int, fn func(*html.Node) bool) {
+	if s.context().Err() != nil {
+		return
+	}
+
+	if s.options.MaxDepth > 0 && depth > s.options.MaxDepth {
+		return
+	}
+	if s.options.MaxNodes > 0 && *visited >= s.options.MaxNodes {
+		return
+	}
+	if s.options.HeadOnly && n.Type == html.ElementNode && n.Data == "body" {
+		return
+	}
+
+	*visited++
+	s.stats.NodesVisited++
 	if !fn(n) {
 		return
 	}
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		s.walkNodes(c, fn)
+		s.walk(c, depth+1, visited, fn)
 	}
 }
 
@@ -151,7 +498,31 @@ func (s *Scraper) hasAttribute(n *html.Node, key string) bool {
 	return false
 }
 
-// getTextContent extracts text content from a node
+// hasMicroformatProperty checks if a node carries a microformats2 property class
+func (s *Scraper) hasMicroformatProperty(n *html.Node) bool {
+	for _, class := range strings.Fields(s.getAttribute(n, "class")) {
+		for _, prefix := range []string{"p-", "u-", "dt-", "e-"} {
+			if strings.HasPrefix(class, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// walkContent is walkNodes without descending into non-text elements, so
+// e.g. an SVG <title> is not mistaken for the page title
+func (s *scrapeState) walkContent(n *html.Node, fn func(*html.Node) bool) {
+	s.walkNodes(n, func(n *html.Node) bool {
+		if n.Type == html.ElementNode && metadata.IsNonTextElement(n.Data) {
+			return false
+		}
+		return fn(n)
+	})
+}
+
+// getTextContent extracts text content from a node, skipping non-text
+// elements (see metadata.IsNonTextElement)
 func (s *Scraper) getTextContent(n *html.Node) string {
 	if n.Type == html.TextNode {
 		return n.Data
@@ -159,12 +530,15 @@ func (s *Scraper) getTextContent(n *html.Node) string {
 
 	var result strings.Builder
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && metadata.IsNonTextElement(c.Data) {
+			continue
+		}
 		result.WriteString(s.getTextContent(c))
 	}
 	return strings.TrimSpace(result.String())
 }
 
 // getResult returns the scraping result
-func (s *Scraper) getResult() *metadata.Metadata {
+func (s *scrapeState) getResult() *metadata.Metadata {
 	return s.result
 }
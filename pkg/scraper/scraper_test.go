@@ -1,6 +1,8 @@
 package scraper
 
 import (
+	"context"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -192,8 +194,7 @@ func TestScraper_Scrape_ValidDocument(t *testing.T) {
 func TestScraper_scrapeMetaTags(t *testing.T) {
 	provider := &MockProvider{name: "test", priority: 1, element: "meta"}
 	registry := &MockRegistry{providers: []metadata.MetadataProvider{provider}}
-	scraper := NewScraper(registry)
-	scraper.result = metadata.NewMetadata(registry)
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
 
 	// Create HTML with meta tag
 	doc := &html.Node{
@@ -216,11 +217,57 @@ func TestScraper_scrapeMetaTags(t *testing.T) {
 	}
 }
 
+func TestScraper_WithMultiClaim_Fallback(t *testing.T) {
+	first := &MockProvider{name: "first", priority: 1, element: "title"}
+	second := &MockProvider{name: "second", priority: 2, element: "title"}
+	registry := &MockRegistry{providers: []metadata.MetadataProvider{first, second}}
+	scraper := NewScraper(registry).WithMultiClaim(true)
+
+	doc := &html.Node{
+		Type: html.ElementNode,
+		Data: "title",
+		FirstChild: &html.Node{
+			Type: html.TextNode,
+			Data: "Test Title",
+		},
+	}
+
+	result, err := scraper.Scrape(doc)
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	// MockRegistry does not implement MultiClaimRegistry, so only the first
+	// provider claims the element
+	if len(result.GetProviderData("second")) != 0 {
+		t.Errorf("Expected no data for second provider, got %v", result.GetProviderData("second"))
+	}
+}
+
+func TestScraper_scrapeHTMLTag(t *testing.T) {
+	provider := &MockProvider{name: "test", priority: 1, element: "html"}
+	registry := &MockRegistry{providers: []metadata.MetadataProvider{provider}}
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
+	scraper.doc = &html.Node{
+		Type: html.DocumentNode,
+		FirstChild: &html.Node{
+			Type: html.ElementNode,
+			Data: "html",
+			Attr: []html.Attribute{{Key: "lang", Val: "en"}},
+		},
+	}
+
+	result := scraper.scrapeHTMLTag()
+
+	if result != scraper {
+		t.Error("scrapeHTMLTag() should return scraper for chaining")
+	}
+}
+
 func TestScraper_scrapeTitleTag(t *testing.T) {
 	provider := &MockProvider{name: "test", priority: 1, element: "title"}
 	registry := &MockRegistry{providers: []metadata.MetadataProvider{provider}}
-	scraper := NewScraper(registry)
-	scraper.result = metadata.NewMetadata(registry)
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
 
 	// Create HTML with title tag
 	titleNode := &html.Node{
@@ -248,8 +295,7 @@ func TestScraper_scrapeTitleTag(t *testing.T) {
 func TestScraper_scrapeHeadingTags(t *testing.T) {
 	provider := &MockProvider{name: "test", priority: 1, element: "h1"}
 	registry := &MockRegistry{providers: []metadata.MetadataProvider{provider}}
-	scraper := NewScraper(registry)
-	scraper.result = metadata.NewMetadata(registry)
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
 
 	// Create HTML with h1 tag
 	doc := &html.Node{
@@ -276,8 +322,7 @@ func TestScraper_scrapeHeadingTags(t *testing.T) {
 func TestScraper_scrapeLinkTags(t *testing.T) {
 	provider := &MockProvider{name: "test", priority: 1, element: "link"}
 	registry := &MockRegistry{providers: []metadata.MetadataProvider{provider}}
-	scraper := NewScraper(registry)
-	scraper.result = metadata.NewMetadata(registry)
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
 
 	// Create HTML with link tag
 	doc := &html.Node{
@@ -303,8 +348,7 @@ func TestScraper_scrapeLinkTags(t *testing.T) {
 
 func TestScraper_scrapeFeedLinks(t *testing.T) {
 	registry := &MockRegistry{}
-	scraper := NewScraper(registry)
-	scraper.result = metadata.NewMetadata(registry)
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
 
 	// Create HTML with RSS feed link
 	doc := &html.Node{
@@ -349,8 +393,7 @@ func TestScraper_scrapeFeedLinks(t *testing.T) {
 
 func TestScraper_scrapeFeedLinks_NoTitle(t *testing.T) {
 	registry := &MockRegistry{}
-	scraper := NewScraper(registry)
-	scraper.result = metadata.NewMetadata(registry)
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
 
 	// Create HTML with RSS feed link without title
 	doc := &html.Node{
@@ -505,3 +548,193 @@ func TestScraper_getTextContent(t *testing.T) {
 		})
 	}
 }
+
+func TestScraper_describeElement(t *testing.T) {
+	scraper := NewScraper(&MockRegistry{})
+
+	tests := []struct {
+		name     string
+		node     *html.Node
+		expected string
+	}{
+		{
+			name:     "element without attributes",
+			node:     &html.Node{Type: html.ElementNode, Data: "title"},
+			expected: "<title>",
+		},
+		{
+			name: "meta with identifying and value attributes",
+			node: &html.Node{Type: html.ElementNode, Data: "meta", Attr: []html.Attribute{
+				{Key: "content", Val: "Title"},
+				{Key: "property", Val: "og:title"},
+			}},
+			expected: `<meta property="og:title">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := scraper.describeElement(tt.node); result != tt.expected {
+				t.Errorf("describeElement() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScraper_valueAttribute(t *testing.T) {
+	scraper := NewScraper(&MockRegistry{})
+	node := &html.Node{Type: html.ElementNode, Data: "time", Attr: []html.Attribute{
+		{Key: "class", Val: "dt-published"},
+		{Key: "datetime", Val: " 2024-01-01 "},
+	}}
+
+	if attribute := scraper.valueAttribute(node, "2024-01-01"); attribute != "datetime" {
+		t.Errorf("valueAttribute() = %v, want datetime", attribute)
+	}
+
+	if attribute := scraper.valueAttribute(node, "January 1st"); attribute != "" {
+		t.Errorf("valueAttribute() = %v, want empty for text content", attribute)
+	}
+}
+
+func TestScraper_scrapeIconLinks(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<link rel="Shortcut  Icon" href="/favicon.ico">
+<link rel="icon" type="image/png" sizes="32x32" href="/favicon-32.png">
+<link rel="apple-touch-icon" href="/apple-touch-icon.png">
+<link rel="mask-icon" href="/mask.svg" color="#000">
+<link rel="stylesheet" href="/style.css">
+<link rel="icon">
+</head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	registry := &MockRegistry{}
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
+	scraper.doc = doc
+
+	if result := scraper.scrapeIconLinks(); result != scraper {
+		t.Error("scrapeIconLinks() should return scraper for chaining")
+	}
+
+	expected := []metadata.Icon{
+		{Rel: "shortcut icon", Href: "/favicon.ico"},
+		{Rel: "icon", Href: "/favicon-32.png", Type: "image/png", Sizes: "32x32"},
+		{Rel: "apple-touch-icon", Href: "/apple-touch-icon.png"},
+		{Rel: "mask-icon", Href: "/mask.svg"},
+	}
+
+	if len(scraper.result.Icons) != len(expected) {
+		t.Fatalf("Expected %d icons, got %d: %+v", len(expected), len(scraper.result.Icons), scraper.result.Icons)
+	}
+	for i, icon := range scraper.result.Icons {
+		if *icon != expected[i] {
+			t.Errorf("Icons[%d] = %+v, want %+v", i, *icon, expected[i])
+		}
+	}
+}
+
+func TestScraper_scrapeOutline(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+<h1>Title</h1>
+<h2>First   <em>Section</em></h2>
+<h3></h3>
+<section><h3>Subsection</h3></section>
+<h2>Second Section</h2>
+</body></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	registry := &MockRegistry{}
+	scraper := NewScraper(registry).newScrape(context.Background(), &metadata.Document{})
+	scraper.doc = doc
+
+	if result := scraper.scrapeOutline(); result != scraper {
+		t.Error("scrapeOutline() should return scraper for chaining")
+	}
+
+	expected := []metadata.Heading{
+		{Level: 1, Text: "Title"},
+		{Level: 2, Text: "First Section"},
+		{Level: 3, Text: "Subsection"},
+		{Level: 2, Text: "Second Section"},
+	}
+
+	headings := scraper.result.Headings()
+	if len(headings) != len(expected) {
+		t.Fatalf("Headings() = %v, want %v", headings, expected)
+	}
+	for i := range expected {
+		if headings[i] != expected[i] {
+			t.Errorf("Headings()[%d] = %v, want %v", i, headings[i], expected[i])
+		}
+	}
+}
+
+const nonTextFixture = `<html><head><title>Real Title</title></head><body>
+<svg><title>Icon</title></svg>
+<template><h1>Template Heading</h1></template>
+<h1><script>var x = 1;</script>Heading <style>.a { color: red }</style><svg><title>icon</title></svg>Text</h1>
+</body></html>`
+
+func TestScraper_SkipsNonTextSubtrees(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(nonTextFixture))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.Scrape(doc)
+	if err != nil {
+		t.Fatalf("Scrape() returned error: %v", err)
+	}
+
+	if title := result.Other()["title"]; len(title) != 1 || title[0] != "Real Title" {
+		t.Errorf("Other title = %v, want [Real Title]", title)
+	}
+
+	if heading := result.Other()["firstHeading"]; len(heading) != 1 || heading[0] != "Heading Text" {
+		t.Errorf("Other firstHeading = %v, want [Heading Text]", heading)
+	}
+
+	if headings := result.Headings(); len(headings) != 1 || headings[0].Text != "Heading Text" {
+		t.Errorf("Headings() = %v, want only the visible heading", headings)
+	}
+}
+
+func TestScraper_scrapeBaseTag(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<base href="/static/">
+<base href="/ignored/">
+<link rel="alternate" type="application/rss+xml" href="feed.xml">
+<link rel="icon" href="icon.png">
+</head></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	pageURL, _ := url.Parse("https://example.com/blog/post")
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeContext(context.Background(), doc, &metadata.Document{URL: pageURL})
+	if err != nil {
+		t.Fatalf("ScrapeContext() returned error: %v", err)
+	}
+
+	if result.BaseHref != "/static/" {
+		t.Errorf("BaseHref = %q, want %q", result.BaseHref, "/static/")
+	}
+
+	if len(result.Feeds) != 1 || result.Feeds[0].Href != "https://example.com/static/feed.xml" {
+		t.Errorf("Feeds = %+v, want feed.xml resolved against the base", result.Feeds)
+	}
+
+	if favicon := result.Favicon(); favicon != "https://example.com/static/icon.png" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://example.com/static/icon.png")
+	}
+
+	if candidates := result.URLCandidates(); candidates.Fetched != "https://example.com/blog/post" {
+		t.Errorf("URLCandidates().Fetched = %v, want the page URL", candidates.Fetched)
+	}
+}
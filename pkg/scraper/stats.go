@@ -0,0 +1,48 @@
+package scraper
+
+import (
+	"context"
+	"time"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+// ScrapeStats describes the work a single scrape did
+type ScrapeStats struct {
+	// NodesVisited counts the nodes visited across every pass over the
+	// document
+	NodesVisited int
+
+	// Matches counts the elements each provider extracted data from, by
+	// provider name, including extractions later rejected by hooks
+	Matches map[string]int
+
+	// TextBytes is the total size of the values added to the result
+	TextBytes int
+
+	// Duration is how long the scrape took
+	Duration time.Duration
+}
+
+// ScrapeWithStats is ScrapeContext that also reports what the scrape cost.
+// Stats are returned per call, so a shared Scraper reports each scrape
+// separately.
+func (s *Scraper) ScrapeWithStats(ctx context.Context, doc *html.Node, document *metadata.Document) (*metadata.Metadata, ScrapeStats, error) {
+	started := time.Now()
+	result, state, err := s.scrape(ctx, doc, document)
+	if state == nil {
+		return result, ScrapeStats{}, err
+	}
+
+	state.stats.Duration = time.Since(started)
+	return result, state.stats, err
+}
+
+// recordMatch counts an extraction by provider
+func (s *scrapeState) recordMatch(provider string) {
+	if s.stats.Matches == nil {
+		s.stats.Matches = make(map[string]int)
+	}
+	s.stats.Matches[provider]++
+}
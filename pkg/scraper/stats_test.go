@@ -0,0 +1,70 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestScraper_ScrapeWithStats(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><head>
+<title>Title</title>
+<meta property="og:title" content="OG">
+<meta property="og:type" content="article">
+<meta name="description" content="Description">
+</head><body><p>Body</p></body></html>`))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	scraperInstance, _ := CreateScraper()
+	result, stats, err := scraperInstance.ScrapeWithStats(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatalf("ScrapeWithStats() returned error: %v", err)
+	}
+	if result == nil {
+		t.Fatal("ScrapeWithStats() returned nil metadata")
+	}
+
+	if stats.Matches["openGraph"] != 2 {
+		t.Errorf("Matches[openGraph] = %v, want %v", stats.Matches["openGraph"], 2)
+	}
+	if stats.Matches["meta"] != 1 {
+		t.Errorf("Matches[meta] = %v, want %v", stats.Matches["meta"], 1)
+	}
+	if stats.Matches["other"] != 1 {
+		t.Errorf("Matches[other] = %v, want %v", stats.Matches["other"], 1)
+	}
+
+	if expected := len("Title") + len("OG") + len("article") + len("Description"); stats.TextBytes != expected {
+		t.Errorf("TextBytes = %v, want %v", stats.TextBytes, expected)
+	}
+
+	if stats.NodesVisited == 0 {
+		t.Error("NodesVisited = 0, want the nodes walked by each pass")
+	}
+
+	if stats.Duration <= 0 {
+		t.Errorf("Duration = %v, want positive", stats.Duration)
+	}
+}
+
+func TestScraper_ScrapeWithStats_PerCall(t *testing.T) {
+	scraperInstance, _ := CreateScraper()
+
+	small, _ := html.Parse(strings.NewReader(`<title>A</title>`))
+	large, _ := html.Parse(strings.NewReader(`<title>A</title><body>` + strings.Repeat("<p>text</p>", 50) + `</body>`))
+
+	_, largeStats, _ := scraperInstance.ScrapeWithStats(context.Background(), large, nil)
+	_, smallStats, _ := scraperInstance.ScrapeWithStats(context.Background(), small, nil)
+
+	if smallStats.NodesVisited >= largeStats.NodesVisited {
+		t.Errorf("NodesVisited = %v after a %v-node scrape, want stats per call", smallStats.NodesVisited, largeStats.NodesVisited)
+	}
+
+	if _, _, err := scraperInstance.ScrapeWithStats(context.Background(), nil, nil); err == nil {
+		t.Error("ScrapeWithStats(nil) should return an error")
+	}
+}
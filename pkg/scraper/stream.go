@@ -0,0 +1,147 @@
+package scraper
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+	"golang.org/x/net/html"
+)
+
+// textElements are the elements whose text content providers read, so the
+// stream collects their text before scraping them
+var textElements = map[string]bool{
+	"title":  true,
+	"h1":     true,
+	"script": true,
+}
+
+// ScrapeStream extracts metadata by tokenizing r instead of parsing it into a
+// DOM. It stops reading once </head> and the first <h1> have been seen, or
+// just </head> in HeadOnly mode, so large bodies are never fully read or
+// parsed.
+func (s *Scraper) ScrapeStream(r io.Reader) (*metadata.Metadata, error) {
+	return s.ScrapeStreamContext(context.Background(), r, nil)
+}
+
+// ScrapeStreamContext is ScrapeStream with a context and document
+// description, as for ScrapeContext. Elements are scraped in document order
+// and on their own, so microformats, which need the surrounding tree, and
+// the heading outline are not extracted.
+func (s *Scraper) ScrapeStreamContext(ctx context.Context, r io.Reader, document *metadata.Document) (*metadata.Metadata, error) {
+	var described metadata.Document
+	if document != nil {
+		described = *document
+	}
+	state := s.newScrape(ctx, &described)
+
+	z := html.NewTokenizer(r)
+	var (
+		open        *html.Node
+		text        strings.Builder
+		headDone    bool
+		headingDone = s.options.HeadOnly
+		started     int
+		skipped     int
+	)
+
+	for !(headDone && headingDone) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		switch tt := z.Next(); tt {
+		case html.ErrorToken:
+			if z.Err() != io.EOF {
+				return nil, z.Err()
+			}
+			return state.getResult(), nil
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if started++; s.options.MaxNodes > 0 && started > s.options.MaxNodes {
+				return state.getResult(), nil
+			}
+
+			node := tokenNode(z)
+			switch {
+			case skipped > 0:
+				if opensNonText(tt, node) {
+					skipped++
+				}
+			case node.Data == "body":
+				headDone = true
+			case open == nil && textElements[node.Data] && !(node.Data == "h1" && headingDone):
+				open = node
+				text.Reset()
+			case opensNonText(tt, node):
+				skipped++
+			default:
+				state.scrapeStreamElement(node)
+			}
+
+		case html.TextToken:
+			if open != nil && skipped == 0 {
+				text.Write(z.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch {
+			case skipped > 0:
+				if metadata.IsNonTextElement(string(name)) {
+					skipped--
+				}
+			case open != nil && string(name) == open.Data:
+				open.AppendChild(&html.Node{Type: html.TextNode, Data: text.String()})
+				state.scrapeFromElement(open)
+				headingDone = headingDone || open.Data == "h1"
+				open = nil
+			case string(name) == "head":
+				headDone = true
+			}
+		}
+	}
+
+	return state.getResult(), nil
+}
+
+// scrapeStreamElement scrapes a single tokenized element
+func (s *scrapeState) scrapeStreamElement(node *html.Node) {
+	switch node.Data {
+	case "html":
+		if s.document.Language == "" {
+			s.document.Language = strings.TrimSpace(s.getAttribute(node, "lang"))
+		}
+		s.scrapeFromElement(node)
+	case "meta":
+		s.scrapeFromElement(node)
+	case "base":
+		s.recordBase(node)
+	case "link":
+		if s.hasAttribute(node, "rel") {
+			s.scrapeFromElement(node)
+		}
+		s.recordFeed(node)
+		s.recordIcon(node)
+	}
+}
+
+// opensNonText reports whether a start tag opens a non-text subtree,
+// whose content the stream skips until the matching end tag
+func opensNonText(tt html.TokenType, node *html.Node) bool {
+	return tt == html.StartTagToken && metadata.IsNonTextElement(node.Data)
+}
+
+// tokenNode builds a detached element node from the current start tag
+func tokenNode(z *html.Tokenizer) *html.Node {
+	name, hasAttr := z.TagName()
+	node := &html.Node{Type: html.ElementNode, Data: string(name)}
+
+	for hasAttr {
+		var key, val []byte
+		key, val, hasAttr = z.TagAttr()
+		node.Attr = append(node.Attr, html.Attribute{Key: string(key), Val: string(val)})
+	}
+	return node
+}
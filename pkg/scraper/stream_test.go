@@ -0,0 +1,167 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alvincrespo/glypto-go/pkg/metadata"
+)
+
+const streamFixture = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <title>Tom &amp; Jerry</title>
+  <meta property="og:title" content="OG Title">
+  <meta name="description" content="Description">
+  <link rel="icon" sizes="32x32" href="/icon.png">
+  <link rel="alternate" type="application/rss+xml" href="/feed.xml">
+  <script type="application/ld+json">{"@type": "Article", "headline": "Headline"}</script>
+</head>
+<body>
+  <h1>First <em>Heading</em></h1>
+  <h1>Second Heading</h1>
+</body>
+</html>`
+
+// errAfterReader fails any read once its content is exhausted, to prove the
+// stream stopped before reaching the rest of the document
+type errAfterReader struct {
+	r io.Reader
+}
+
+var errReadPastHeading = errors.New("read past the first heading")
+
+func (e *errAfterReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	if err == io.EOF {
+		return n, errReadPastHeading
+	}
+	return n, err
+}
+
+func TestScraper_ScrapeStream(t *testing.T) {
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeStream(strings.NewReader(streamFixture))
+	if err != nil {
+		t.Fatalf("ScrapeStream() returned error: %v", err)
+	}
+
+	if title := result.Title(); title == nil || *title != "OG Title" {
+		t.Errorf("Title() = %v, want %q", title, "OG Title")
+	}
+
+	if title := result.Other()["title"]; len(title) != 1 || title[0] != "Tom & Jerry" {
+		t.Errorf("Other title = %v, want [Tom & Jerry]", title)
+	}
+
+	if heading := result.Other()["firstHeading"]; len(heading) != 1 || heading[0] != "First Heading" {
+		t.Errorf("Other firstHeading = %v, want [First Heading]", heading)
+	}
+
+	if description := result.Description(); description == nil || *description != "Description" {
+		t.Errorf("Description() = %v, want %q", description, "Description")
+	}
+
+	if locale := result.Locale(); locale == nil || *locale != "en" {
+		t.Errorf("Locale() = %v, want %q", locale, "en")
+	}
+
+	if len(result.Icons) != 1 || result.Icons[0].Sizes != "32x32" {
+		t.Errorf("Icons = %+v, want one 32x32 icon", result.Icons)
+	}
+
+	if len(result.Feeds) != 1 || result.Feeds[0].Href != "/feed.xml" {
+		t.Errorf("Feeds = %+v, want one feed", result.Feeds)
+	}
+
+	if jsonLd := result.GetProviderData("jsonLd"); len(jsonLd) == 0 {
+		t.Error("Expected JSON-LD data from the head script")
+	}
+}
+
+func TestScraper_ScrapeStream_StopsAfterHeading(t *testing.T) {
+	head, _, _ := strings.Cut(streamFixture, "\n  <h1>Second")
+	r := &errAfterReader{r: strings.NewReader(head)}
+
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeStream(r)
+	if err != nil {
+		t.Fatalf("ScrapeStream() returned error: %v", err)
+	}
+
+	if heading := result.Other()["firstHeading"]; len(heading) != 1 {
+		t.Errorf("Other firstHeading = %v, want only the first heading", heading)
+	}
+}
+
+func TestScraper_ScrapeStreamContext(t *testing.T) {
+	pageURL, _ := url.Parse("https://example.com/blog/")
+	scraperInstance, _ := CreateScraper()
+	document := &metadata.Document{URL: pageURL}
+	result, err := scraperInstance.ScrapeStreamContext(context.Background(), strings.NewReader(streamFixture), document)
+	if err != nil {
+		t.Fatalf("ScrapeStreamContext() returned error: %v", err)
+	}
+
+	if document.Language != "" {
+		t.Errorf("Document.Language = %q, want the caller's document unchanged", document.Language)
+	}
+
+	if favicon := result.Favicon(); favicon != "https://example.com/icon.png" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://example.com/icon.png")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := scraperInstance.ScrapeStreamContext(ctx, strings.NewReader(streamFixture), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("ScrapeStreamContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestScraper_ScrapeStream_ReadError(t *testing.T) {
+	r := &errAfterReader{r: strings.NewReader("<html><head><title>Title")}
+
+	scraperInstance, _ := CreateScraper()
+	if _, err := scraperInstance.ScrapeStream(r); !errors.Is(err, errReadPastHeading) {
+		t.Errorf("ScrapeStream() error = %v, want %v", err, errReadPastHeading)
+	}
+}
+
+func TestScraper_ScrapeStream_SkipsNonTextSubtrees(t *testing.T) {
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeStream(strings.NewReader(nonTextFixture))
+	if err != nil {
+		t.Fatalf("ScrapeStream() returned error: %v", err)
+	}
+
+	if title := result.Other()["title"]; len(title) != 1 || title[0] != "Real Title" {
+		t.Errorf("Other title = %v, want [Real Title]", title)
+	}
+
+	if heading := result.Other()["firstHeading"]; len(heading) != 1 || heading[0] != "Heading Text" {
+		t.Errorf("Other firstHeading = %v, want [Heading Text]", heading)
+	}
+}
+
+func TestScraper_ScrapeStream_BaseHref(t *testing.T) {
+	page := strings.Replace(streamFixture, "<title>", `<base href="https://cdn.example.com/assets/"><title>`, 1)
+
+	scraperInstance, _ := CreateScraper()
+	result, err := scraperInstance.ScrapeStream(strings.NewReader(page))
+	if err != nil {
+		t.Fatalf("ScrapeStream() returned error: %v", err)
+	}
+
+	if favicon := result.Favicon(); favicon != "https://cdn.example.com/icon.png" {
+		t.Errorf("Favicon() = %v, want %v", favicon, "https://cdn.example.com/icon.png")
+	}
+
+	if len(result.Feeds) != 1 || result.Feeds[0].Href != "https://cdn.example.com/feed.xml" {
+		t.Errorf("Feeds = %+v, want the feed resolved against the base", result.Feeds)
+	}
+}